@@ -1,12 +1,24 @@
 package main
 
 import (
+	"flag"
+	"os"
+
 	"github.com/RozmiDan/gameReviewHub/internal/app"
 	"github.com/RozmiDan/gameReviewHub/internal/config"
 )
 
 func main() {
+	fakeRating := flag.Bool("fake-rating", false, "run embedded in-memory rating service instead of the external one")
+	flag.Parse()
+
 	cnfg := config.MustLoad()
+	if *fakeRating {
+		cnfg.GrpcInfo.Fake = true
+	}
 
-	app.Run(cnfg)
+	// причина уже в логе; здесь — только код выхода для оркестратора
+	if err := app.Run(cnfg); err != nil {
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,202 @@
+// migrate — управление схемой БД встроенными миграциями goose (db/migrations).
+//
+//	migrate up [-to N]
+//	migrate down [-to N]
+//	migrate redo
+//	migrate status
+//	migrate version
+//	migrate create [-dir db/migrations] <name>
+//
+// Конфиг тот же, что у сервиса (CONFIG_PATH); create работает без конфига и БД.
+// Операции над БД берут тот же advisory lock, что и сервис при postgres.auto_migrate,
+// поэтому migrate можно запускать при работающих репликах.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/db"
+	"github.com/RozmiDan/gameReviewHub/internal/config"
+	"github.com/pressly/goose/v3"
+)
+
+const usage = `usage:
+  migrate up [-to N]                 apply pending migrations (up to version N)
+  migrate down [-to N]               roll back the last migration (down to version N)
+  migrate redo                       roll back and re-apply the last migration
+  migrate status                     list migrations and their state
+  migrate version                    print the current schema version
+  migrate create [-dir DIR] <name>   create an empty migration file`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "migrate:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cmd string, args []string) error {
+	// create только пишет файл в исходники
+	if cmd == "create" {
+		return create(args)
+	}
+
+	cfg := config.MustLoad()
+	conn := db.Open(cfg)
+	defer conn.Close()
+
+	migrator, err := db.NewMigrator(conn)
+	if err != nil {
+		return err
+	}
+
+	switch cmd {
+	case "up":
+		return up(ctx, migrator, args)
+	case "down":
+		return down(ctx, migrator, args)
+	case "redo":
+		return redo(ctx, migrator)
+	case "status":
+		return status(ctx, migrator)
+	case "version":
+		return version(ctx, migrator)
+	}
+	return fmt.Errorf("unknown command %q\n%s", cmd, usage)
+}
+
+func up(ctx context.Context, m *goose.Provider, args []string) error {
+	fs := flag.NewFlagSet("up", flag.ExitOnError)
+	to := fs.Int64("to", 0, "apply migrations up to and including this version (default: all)")
+	_ = fs.Parse(args)
+
+	var (
+		results []*goose.MigrationResult
+		err     error
+	)
+	if *to > 0 {
+		results, err = m.UpTo(ctx, *to)
+	} else {
+		results, err = m.Up(ctx)
+	}
+	printResults(results)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		fmt.Println("no pending migrations")
+	}
+	return version(ctx, m)
+}
+
+func down(ctx context.Context, m *goose.Provider, args []string) error {
+	fs := flag.NewFlagSet("down", flag.ExitOnError)
+	to := fs.Int64("to", -1, "roll back all migrations above this version, 0 = all (default: only the last one)")
+	_ = fs.Parse(args)
+
+	var (
+		results []*goose.MigrationResult
+		err     error
+	)
+	if *to >= 0 {
+		results, err = m.DownTo(ctx, *to)
+	} else {
+		var res *goose.MigrationResult
+		res, err = m.Down(ctx)
+		if res != nil {
+			results = append(results, res)
+		}
+	}
+	printResults(results)
+	if err != nil {
+		return err
+	}
+	return version(ctx, m)
+}
+
+// redo — проверка Down-части свежей миграции: откатить последнюю и применить её заново
+func redo(ctx context.Context, m *goose.Provider) error {
+	current, err := m.GetDBVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if current == 0 {
+		return errors.New("no applied migrations to redo")
+	}
+
+	res, err := m.ApplyVersion(ctx, current, false)
+	printResults([]*goose.MigrationResult{res})
+	if err != nil {
+		return err
+	}
+	res, err = m.ApplyVersion(ctx, current, true)
+	printResults([]*goose.MigrationResult{res})
+	return err
+}
+
+func status(ctx context.Context, m *goose.Provider) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tSTATE\tAPPLIED\tFILE")
+	for _, s := range statuses {
+		applied := "-"
+		if !s.AppliedAt.IsZero() {
+			applied = s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", s.Source.Version, s.State, applied, s.Source.Path)
+	}
+	return tw.Flush()
+}
+
+func version(ctx context.Context, m *goose.Provider) error {
+	current, target, err := m.GetVersions(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("version: %d (latest: %d)\n", current, target)
+	return nil
+}
+
+func create(args []string) error {
+	fs := flag.NewFlagSet("create", flag.ExitOnError)
+	dir := fs.String("dir", db.MigrationsDir, "migrations directory")
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		return errors.New("create needs exactly one name")
+	}
+
+	path, err := db.CreateMigration(*dir, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("created %s\n", path)
+	return nil
+}
+
+func printResults(results []*goose.MigrationResult) {
+	for _, r := range results {
+		if r != nil {
+			fmt.Println(r.String())
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// replay — просмотр и переотправка сообщений из dead-letter store (таблица kafka_dead_letters).
+//
+//	replay list [-all] [-limit 50] [-after 0]
+//	replay inspect <id>
+//	replay republish [-id N] [-rate 10] [-dry-run]
+//
+// Конфиг тот же, что у сервиса (CONFIG_PATH).
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/config"
+	"github.com/RozmiDan/gameReviewHub/internal/deadletter"
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	postgres_storage "github.com/RozmiDan/gameReviewHub/internal/repo/postgre"
+	gamekafka "github.com/RozmiDan/gameReviewHub/pkg/kafka"
+	"github.com/RozmiDan/gameReviewHub/pkg/postgres"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+const usage = `usage:
+  replay list [-all] [-limit 50] [-after 0]
+  replay inspect <id>
+  replay republish [-id N] [-rate 10] [-dry-run]`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "replay:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cmd string, args []string) error {
+	cfg := config.MustLoad()
+	logger, _ := zap.NewProduction()
+	defer logger.Sync() //nolint:errcheck
+
+	pg, err := postgres.New(cfg.PostgreURL.URL)
+	if err != nil {
+		return err
+	}
+	defer pg.Close()
+	repo := postgres_storage.New(pg, logger)
+
+	switch cmd {
+	case "list":
+		return list(ctx, repo, args)
+	case "inspect":
+		return inspect(ctx, repo, args)
+	case "republish":
+		return republish(ctx, cfg, repo, logger, args)
+	}
+	return fmt.Errorf("unknown command %q\n%s", cmd, usage)
+}
+
+func list(ctx context.Context, repo *postgres_storage.RatingRepository, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	all := fs.Bool("all", false, "include already replayed messages")
+	limit := fs.Int("limit", 50, "max rows")
+	after := fs.Int64("after", 0, "show ids greater than this")
+	_ = fs.Parse(args)
+
+	letters, err := repo.ListDeadLetters(ctx, !*all, *after, *limit)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTOPIC\tKEY\tCREATED\tATTEMPTS\tREPLAYED\tERROR")
+	for _, dl := range letters {
+		replayed := "-"
+		if dl.ReplayedAt != nil {
+			replayed = dl.ReplayedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d\t%s\t%s\n", dl.ID, dl.Topic, dl.Key,
+			dl.CreatedAt.Format(time.RFC3339), dl.Attempts, replayed, dl.Error)
+	}
+	return tw.Flush()
+}
+
+func inspect(ctx context.Context, repo *postgres_storage.RatingRepository, args []string) error {
+	if len(args) != 1 {
+		return errors.New("inspect needs exactly one id")
+	}
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("bad id %q: %w", args[0], err)
+	}
+
+	dl, err := repo.GetDeadLetter(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// тело показываем разобранным, если это поддерживаемая версия схемы оценки
+	view := struct {
+		*entity.DeadLetter
+		Key     string                `json:"key"`
+		Payload string                `json:"payload"`
+		Rating  *entity.RatingMessage `json:"decoded_rating,omitempty"`
+	}{DeadLetter: dl, Key: string(dl.Key), Payload: string(dl.Payload)}
+	if msg, err := gamekafka.DecodeRating(toMessage(*dl)); err == nil {
+		view.Rating = &msg
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(view)
+}
+
+func republish(ctx context.Context, cfg *config.Config, repo *postgres_storage.RatingRepository,
+	logger *zap.Logger, args []string) error {
+
+	fs := flag.NewFlagSet("republish", flag.ExitOnError)
+	id := fs.Int64("id", 0, "republish a single message (default: all pending)")
+	rate := fs.Float64("rate", 10, "messages per second, 0 = unlimited")
+	dryRun := fs.Bool("dry-run", false, "only print what would be republished")
+	_ = fs.Parse(args)
+
+	pub := gamekafka.NewReplayer(&cfg.Kafka, logger)
+	defer pub.Close()
+	replayer := deadletter.New(repo, pub, logger, *rate, *dryRun)
+
+	if *id != 0 {
+		if err := replayer.ReplayOne(ctx, *id); err != nil {
+			return err
+		}
+		if *dryRun {
+			fmt.Printf("dry run: would republish %d\n", *id)
+			return nil
+		}
+		fmt.Printf("republished %d\n", *id)
+		return nil
+	}
+
+	ok, failed, err := replayer.ReplayPending(ctx, func(res deadletter.Result) {
+		status := "ok"
+		if *dryRun {
+			status = "dry run"
+		}
+		if res.Err != nil {
+			status = "FAILED: " + res.Err.Error()
+		}
+		fmt.Printf("%d\t%s\t%s\n", res.Letter.ID, res.Letter.Topic, status)
+	})
+	if *dryRun {
+		fmt.Printf("dry run: %d would be republished, %d failed\n", ok, failed)
+	} else {
+		fmt.Printf("done: %d republished, %d failed\n", ok, failed)
+	}
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d messages failed", failed)
+	}
+	return nil
+}
+
+func toMessage(dl entity.DeadLetter) kafka.Message {
+	msg := kafka.Message{Topic: dl.Topic, Key: dl.Key, Value: dl.Payload}
+	for k, v := range dl.Headers {
+		msg.Headers = append(msg.Headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	return msg
+}
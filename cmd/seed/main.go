@@ -0,0 +1,161 @@
+// seed — проверка и массовая загрузка данных из файлов.
+//
+//	seed games    [-format auto] [-batch 500] [-on-conflict skip] [-dry-run] <file>
+//	seed comments [-format auto] [-batch 500] [-on-conflict skip] [-dry-run] <file>
+//	seed ratings  [-format auto] [-batch 500] [-on-conflict skip] [-dry-run] <file>
+//
+// Форматы: json (seed_*.json), jsonl (load-test/games.csv), jmeter (load-test/comments.csv,
+// load-test/ratings.csv) и csv с заголовком; auto определяет формат по содержимому, "-" — stdin.
+// Конфиг тот же, что у сервиса (CONFIG_PATH); в dry-run он не нужен.
+// Игры загружать раньше комментариев и оценок: записи к несуществующим играм пропускаются.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/config"
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	postgres_storage "github.com/RozmiDan/gameReviewHub/internal/repo/postgre"
+	"github.com/RozmiDan/gameReviewHub/internal/seed"
+	gamekafka "github.com/RozmiDan/gameReviewHub/pkg/kafka"
+	"github.com/RozmiDan/gameReviewHub/pkg/postgres"
+	"go.uber.org/zap"
+)
+
+const usage = `usage:
+  seed games    [flags] <file>   load games via COPY
+  seed comments [flags] <file>   load comments via COPY
+  seed ratings  [flags] <file>   publish ratings to Kafka
+
+flags:
+  -format auto|json|jsonl|jmeter|csv   input format (default auto)
+  -batch N                             records per batch (default 500)
+  -on-conflict skip|upsert|error       existing records (default skip)
+  -dry-run                             only validate the file`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "seed:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, kind string, args []string) error {
+	if kind != seed.KindGames && kind != seed.KindComments && kind != seed.KindRatings {
+		return fmt.Errorf("unknown command %q\n%s", kind, usage)
+	}
+
+	fs := flag.NewFlagSet(kind, flag.ExitOnError)
+	format := fs.String("format", seed.FormatAuto, "input format: auto, json, jsonl, jmeter, csv")
+	batch := fs.Int("batch", 500, "records per batch")
+	onConflict := fs.String("on-conflict", entity.ConflictSkip, "existing records: skip, upsert, error")
+	dryRun := fs.Bool("dry-run", false, "only validate the file, write nothing")
+	_ = fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%s needs exactly one file\n%s", kind, usage)
+	}
+	mode, err := entity.ParseConflictMode(*onConflict)
+	if err != nil {
+		return fmt.Errorf("%w %q", err, *onConflict)
+	}
+
+	// 1) источник
+	var in io.Reader = os.Stdin
+	if name := fs.Arg(0); name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	src, err := seed.NewReader(in, *format)
+	if err != nil {
+		return err
+	}
+
+	// 2) логи только warn и выше: info репозитория и продьюсера на каждую запись забили бы прогресс
+	logCfg := zap.NewProductionConfig()
+	logCfg.Level = zap.NewAtomicLevelAt(zap.WarnLevel)
+	logger, err := logCfg.Build()
+	if err != nil {
+		return err
+	}
+	defer logger.Sync() //nolint:errcheck
+
+	opts := []seed.Option{
+		seed.BatchSize(*batch),
+		seed.OnConflict(mode),
+		seed.DryRun(*dryRun),
+		seed.OnProgress(func(p seed.Progress) {
+			fmt.Printf("%s: batch %d, read %d, written %d, skipped %d, invalid %d, %s\n",
+				p.Kind, p.Batches, p.Read, p.Written, p.Skipped, p.Invalid, p.Elapsed.Round(time.Millisecond))
+		}),
+		seed.OnInvalid(func(kind string, err *seed.RecordError) {
+			fmt.Fprintf(os.Stderr, "%s: invalid %v\n", kind, err)
+		}),
+	}
+
+	// 3) dry-run не трогает ни БД, ни Kafka
+	loader := seed.New(nil, nil, logger, opts...)
+	if !*dryRun {
+		cfg := config.MustLoad()
+		pg, err := postgres.New(cfg.PostgreURL.URL)
+		if err != nil {
+			return err
+		}
+		defer pg.Close()
+		repo := postgres_storage.New(pg, logger)
+
+		var producer seed.RatingProducer
+		if kind == seed.KindRatings {
+			if len(cfg.Kafka.Brokers) == 0 {
+				return errors.New("kafka.brokers is empty: ratings can only be published to Kafka")
+			}
+			// недоставленные оценки — в kafka_dead_letters, как у сервиса; переотправка — cmd/replay
+			p := gamekafka.NewProducer(&cfg.Kafka, logger, gamekafka.WithDeadLetters(repo))
+			// Close дожидается отправки буфера async-writer'а
+			defer p.Close()
+			producer = p
+		}
+		loader = seed.New(repo, producer, logger, opts...)
+	}
+
+	// 4) загрузка
+	stats, err := load(ctx, loader, kind, src)
+	fmt.Printf("done: %d read, %d valid, %d invalid, %d written, %d skipped in %s\n",
+		stats.Read, stats.Valid, stats.Invalid, stats.Written, stats.Skipped, stats.Elapsed.Round(time.Millisecond))
+	if err != nil {
+		return err
+	}
+	if stats.Invalid > 0 {
+		return fmt.Errorf("%d invalid records", stats.Invalid)
+	}
+	return nil
+}
+
+func load(ctx context.Context, loader *seed.Loader, kind string, src seed.Reader) (seed.Stats, error) {
+	switch kind {
+	case seed.KindGames:
+		return loader.LoadGames(ctx, src)
+	case seed.KindComments:
+		return loader.LoadComments(ctx, src)
+	}
+	return loader.LoadRatings(ctx, src)
+}
@@ -1,43 +1,120 @@
 package db
 
 import (
+	"context"
+	"database/sql"
 	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/RozmiDan/gameReviewHub/internal/config"
 
 	"github.com/jackc/pgx"
 	"github.com/jackc/pgx/stdlib"
 	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
 	"go.uber.org/zap"
 )
 
 //go:embed migrations/*.sql
 var embedMigrations embed.FS
 
-func SetupPostgres(cfg *config.Config, logger *zap.Logger) {
+// MigrationsDir — каталог миграций в исходниках: в него пишет migrate create, в бинарник он встраивается
+const MigrationsDir = "db/migrations"
 
-	pgxConf := pgx.ConnConfig{
+// Open открывает *sql.DB для goose по полям postgres.* конфига
+func Open(cfg *config.Config) *sql.DB {
+	return stdlib.OpenDB(pgx.ConnConfig{
 		Host:     cfg.PostgreURL.Host,
 		Port:     cfg.PostgreURL.Port,
 		Database: cfg.PostgreURL.Database,
 		User:     cfg.PostgreURL.User,
 		Password: cfg.PostgreURL.Password,
+	})
+}
+
+// NewMigrator — goose над встроенными миграциями.
+// Каждая операция держит pg advisory lock: реплики, стартующие одновременно, и ручной migrate
+// не накатывают одну миграцию дважды — второй ждёт первого и видит, что применять уже нечего.
+func NewMigrator(db *sql.DB, opts ...goose.ProviderOption) (*goose.Provider, error) {
+	migrations, err := fs.Sub(embedMigrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, fmt.Errorf("migrations lock: %w", err)
+	}
+	opts = append(opts, goose.WithSessionLocker(locker))
+	return goose.NewProvider(goose.DialectPostgres, db, migrations, opts...)
+}
+
+// SetupPostgres накатывает новые миграции при старте сервиса (postgres.auto_migrate)
+func SetupPostgres(ctx context.Context, cfg *config.Config, logger *zap.Logger) error {
+	db := Open(cfg)
+	defer db.Close()
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	results, err := migrator.Up(ctx)
+	if err != nil {
+		return fmt.Errorf("apply migrations: %w", err)
 	}
+	for _, r := range results {
+		logger.Info("migration applied", zap.Int64("version", r.Source.Version),
+			zap.String("file", r.Source.Path), zap.Duration("took", r.Duration))
+	}
+	return nil
+}
+
+const migrationTemplate = `-- +goose Up
+
+-- +goose Down
+`
+
+var migrationName = regexp.MustCompile(`[^a-z0-9]+`)
 
-	goose.SetBaseFS(embedMigrations)
-	if err := goose.SetDialect("postgres"); err != nil {
-		logger.Error("can't set dialect in goose",
-			zap.Error(err),
-		)
-		os.Exit(1)
+// CreateMigration пишет пустую миграцию со следующим номером: 013_add_index.sql.
+// Номера последовательные и трёхзначные, как у существующих файлов, а не timestamp goose.
+func CreateMigration(dir, name string) (string, error) {
+	name = strings.Trim(migrationName.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", errors.New("migration name is empty")
 	}
 
-	db := stdlib.OpenDB(pgxConf)
-	if err := goose.Up(db, "migrations"); err != nil {
-		logger.Error("can't setup migrations",
-			zap.Error(err),
-		)
-		os.Exit(1)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	var last int64
+	for _, e := range entries {
+		prefix, _, ok := strings.Cut(e.Name(), "_")
+		if !ok || e.IsDir() || filepath.Ext(e.Name()) != ".sql" {
+			continue
+		}
+		if v, err := strconv.ParseInt(prefix, 10, 64); err == nil && v > last {
+			last = v
+		}
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%03d_%s.sql", last+1, name))
+	// O_EXCL: два create одновременно не перезапишут друг друга
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := f.WriteString(migrationTemplate); err != nil {
+		return "", err
 	}
+	return path, nil
 }
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/RozmiDan/gameReviewHub/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"003_create_games.sql", "012_event_outbox_trace.sql", "README.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+
+	path, err := CreateMigration(dir, "Add games-title Index")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "013_add_games_title_index.sql"), path)
+
+	body, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, migrationTemplate, string(body))
+
+	path, err = CreateMigration(dir, "next")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "014_next.sql"), path)
+
+	_, err = CreateMigration(dir, "  !! ")
+	require.Error(t, err)
+}
+
+func TestNewMigrator_EmbeddedSources(t *testing.T) {
+	// провайдер собирается без подключения: миграции читаются из embed, соединение открывается лениво
+	conn := Open(&config.Config{})
+	defer conn.Close()
+	migrator, err := NewMigrator(conn)
+	require.NoError(t, err)
+
+	sources := migrator.ListSources()
+	require.NotEmpty(t, sources)
+	require.Equal(t, int64(3), sources[0].Version)
+
+	entries, err := os.ReadDir("migrations")
+	require.NoError(t, err)
+	require.Len(t, sources, len(entries))
+}
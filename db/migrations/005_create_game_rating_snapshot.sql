@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS game_rating_snapshot (
+  game_id        UUID        PRIMARY KEY REFERENCES games(id) ON DELETE CASCADE,
+  average_rating DOUBLE PRECISION NOT NULL,
+  ratings_count  BIGINT      NOT NULL,
+  updated_at     TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS game_rating_snapshot;
@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS event_outbox (
+  id           UUID        PRIMARY KEY,
+  event_type   TEXT        NOT NULL,
+  aggregate    TEXT        NOT NULL,
+  aggregate_id UUID        NOT NULL,
+  game_id      UUID        NOT NULL,
+  payload      JSONB       NOT NULL,
+  request_id   TEXT        NOT NULL DEFAULT '',
+  occurred_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+  published_at TIMESTAMP WITH TIME ZONE
+);
+
+-- relay выбирает только неотправленные события в порядке появления
+CREATE INDEX IF NOT EXISTS idx_event_outbox_pending
+  ON event_outbox(occurred_at) WHERE published_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS event_outbox;
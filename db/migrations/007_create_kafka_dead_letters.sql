@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS kafka_dead_letters (
+  id              BIGSERIAL   PRIMARY KEY,
+  topic           TEXT        NOT NULL,
+  msg_key         BYTEA,
+  payload         BYTEA       NOT NULL,
+  headers         JSONB       NOT NULL DEFAULT '{}',
+  error           TEXT        NOT NULL DEFAULT '',
+  attempts        INT         NOT NULL DEFAULT 0,
+  created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  last_attempt_at TIMESTAMP WITH TIME ZONE,
+  replayed_at     TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_kafka_dead_letters_pending
+  ON kafka_dead_letters(id) WHERE replayed_at IS NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS kafka_dead_letters;
@@ -0,0 +1,49 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+  id                   UUID        PRIMARY KEY DEFAULT uuid_generate_v4(),
+  url                  TEXT        NOT NULL,
+  secret               TEXT        NOT NULL,
+  event_types          TEXT[]      NOT NULL,
+  game_id              UUID        REFERENCES games(id) ON DELETE CASCADE,
+  active               BOOLEAN     NOT NULL DEFAULT true,
+  consecutive_failures INT         NOT NULL DEFAULT 0,
+  disabled_at          TIMESTAMP WITH TIME ZONE,
+  created_at           TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  id              BIGSERIAL   PRIMARY KEY,
+  subscription_id UUID        NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+  event_id        UUID        NOT NULL,
+  event_type      TEXT        NOT NULL,
+  payload         JSONB       NOT NULL,
+  status          TEXT        NOT NULL DEFAULT 'pending',
+  attempts        INT         NOT NULL DEFAULT 0,
+  last_error      TEXT        NOT NULL DEFAULT '',
+  next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  delivered_at    TIMESTAMP WITH TIME ZONE,
+  UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due
+  ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+
+-- журнал попыток доставки
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+  id          BIGSERIAL   PRIMARY KEY,
+  delivery_id BIGINT      NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+  attempt     INT         NOT NULL,
+  status_code INT         NOT NULL DEFAULT 0,
+  error       TEXT        NOT NULL DEFAULT '',
+  duration_ms BIGINT      NOT NULL,
+  created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery
+  ON webhook_delivery_attempts(delivery_id, attempt);
+
+-- +goose Down
+DROP TABLE IF EXISTS webhook_delivery_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
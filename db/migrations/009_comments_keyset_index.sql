@@ -0,0 +1,7 @@
+-- +goose Up
+-- keyset по (created_at, id): догрузка пропущенных комментариев для SSE по Last-Event-ID
+CREATE INDEX IF NOT EXISTS idx_comments_game_keyset
+  ON comments(game_id, created_at, id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_comments_game_keyset;
@@ -0,0 +1,36 @@
+-- +goose Up
+ALTER TABLE comments
+  ADD COLUMN IF NOT EXISTS status            TEXT NOT NULL DEFAULT 'visible'
+    CHECK (status IN ('visible', 'pending', 'hidden')),
+  ADD COLUMN IF NOT EXISTS moderated_by      TEXT,
+  ADD COLUMN IF NOT EXISTS moderated_at      TIMESTAMP WITH TIME ZONE,
+  ADD COLUMN IF NOT EXISTS moderation_reason TEXT;
+
+-- очередь модерации: старые первыми
+CREATE INDEX IF NOT EXISTS idx_comments_pending
+  ON comments(created_at) WHERE status = 'pending';
+
+-- журнал без внешнего ключа: записи переживают удаление комментария и игры
+CREATE TABLE IF NOT EXISTS comment_moderation_audit (
+  id          BIGSERIAL   PRIMARY KEY,
+  comment_id  UUID        NOT NULL,
+  game_id     UUID        NOT NULL,
+  action      TEXT        NOT NULL,
+  from_status TEXT        NOT NULL,
+  to_status   TEXT        NOT NULL,
+  moderator   TEXT        NOT NULL,
+  reason      TEXT        NOT NULL DEFAULT '',
+  created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_audit_comment
+  ON comment_moderation_audit(comment_id, id);
+
+-- +goose Down
+DROP TABLE IF EXISTS comment_moderation_audit;
+DROP INDEX IF EXISTS idx_comments_pending;
+ALTER TABLE comments
+  DROP COLUMN IF EXISTS moderation_reason,
+  DROP COLUMN IF EXISTS moderated_at,
+  DROP COLUMN IF EXISTS moderated_by,
+  DROP COLUMN IF EXISTS status;
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS comment_reports (
+  id         BIGSERIAL   PRIMARY KEY,
+  comment_id UUID        NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+  user_id    UUID        NOT NULL,
+  reason     TEXT        NOT NULL CHECK (reason IN ('spam', 'harassment', 'spoiler', 'other')),
+  details    TEXT        NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  -- одна жалоба от пользователя на комментарий
+  UNIQUE (comment_id, user_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS comment_reports;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- W3C traceparent запроса, породившего событие: relay продолжает тот же трейс
+ALTER TABLE event_outbox
+  ADD COLUMN IF NOT EXISTS trace_parent TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE event_outbox
+  DROP COLUMN IF EXISTS trace_parent;
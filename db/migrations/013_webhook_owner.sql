@@ -0,0 +1,13 @@
+-- +goose Up
+-- владелец подписки — имя партнёра из WEBHOOK_PARTNERS; подписки без владельца (созданные до него)
+-- продолжают получать события, но через API их не видит никто — привязать можно UPDATE-ом
+ALTER TABLE webhook_subscriptions
+  ADD COLUMN IF NOT EXISTS owner TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_owner
+  ON webhook_subscriptions(owner, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_webhook_subscriptions_owner;
+ALTER TABLE webhook_subscriptions
+  DROP COLUMN IF EXISTS owner;
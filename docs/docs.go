@@ -15,184 +15,191 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/games": {
+        "/admin/comments/pending": {
             "get": {
-                "description": "Возвращает упорядоченный по id список игр с поддержкой limit/offset.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Комментарии со статусом pending, старые первыми.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "games"
+                    "moderation"
                 ],
-                "summary": "Получить список игр",
+                "summary": "Очередь модерации",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer-токен модератора",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "default": 10,
-                        "description": "Максимальное число игр",
+                        "description": "Размер страницы (по умолчанию 20)",
                         "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "default": 0,
-                        "description": "Смещение для пагинации",
+                        "description": "Номер страницы (с 0)",
                         "name": "offset",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список игр и мета",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ListGamesResponse"
+                            "$ref": "#/definitions/handlers.ListPendingResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверные параметры запроса",
+                        "description": "Некорректные параметры",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_mainpage.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_moderation.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
+                    "401": {
+                        "description": "Нужен токен модератора",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_mainpage.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_moderation.ErrorResponse"
                         }
                     },
-                    "504": {
-                        "description": "Таймаут обработки запроса",
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_mainpage.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_moderation.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Создаёт запись о новой игре.",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/admin/comments/reports": {
+            "get": {
+                "description": "Сводка по каждому комментарию: число жалоб, разбивка по причинам, время последней жалобы.\nКомментарии, скрытые автоматически по жалобам, имеют статус pending.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "games"
+                    "moderation"
                 ],
-                "summary": "Создание игры",
+                "summary": "Жалобы на комментарии",
                 "parameters": [
                     {
-                        "description": "Тело запроса",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.CreateGameRequest"
-                        }
+                        "type": "string",
+                        "description": "Bearer-токен модератора",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "visible",
+                            "pending",
+                            "hidden"
+                        ],
+                        "type": "string",
+                        "description": "Только с этим статусом",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы (с 0)",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Игра успешно создана",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateGameResponse"
-                        },
-                        "headers": {
-                            "Location": {
-                                "type": "string",
-                                "description": "UUID созданного ресурса (/games/{id})"
-                            }
+                            "$ref": "#/definitions/handlers.ListReportsResponse"
                         }
                     },
                     "400": {
-                        "description": "Некорректный запрос (невалидный UUID, отсутствие полей, неверный формат даты)",
+                        "description": "Некорректные параметры",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_creategametopic.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_moderation.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Конфликт — игра с таким именем уже существует",
+                    "401": {
+                        "description": "Нужен токен модератора",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_creategametopic.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_moderation.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_creategametopic.ErrorResponse"
-                        }
-                    },
-                    "504": {
-                        "description": "Таймаут обработки запроса",
-                        "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_creategametopic.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_moderation.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/games/{game_id}": {
+        "/admin/comments/{comment_id}/audit": {
             "get": {
-                "description": "Возвращает все поля сущности Game для переданного UUID игры.",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "games"
+                    "moderation"
                 ],
-                "summary": "Получить информацию об игре",
+                "summary": "Журнал модерации комментария",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID игры",
-                        "name": "game_id",
+                        "description": "Bearer-токен модератора",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID комментария",
+                        "name": "comment_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Данные игры",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GameTopicResponse"
+                            "$ref": "#/definitions/handlers.AuditResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат UUID",
+                        "description": "Некорректный comment_id",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_gametopic.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_moderation.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Игра не найдена",
+                    "401": {
+                        "description": "Нужен токен модератора",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_gametopic.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_moderation.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_gametopic.ErrorResponse"
-                        }
-                    },
-                    "504": {
-                        "description": "Таймаут обработки запроса",
-                        "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_gametopic.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_moderation.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/games/{game_id}/comments": {
-            "get": {
-                "description": "Возвращает упорядоченный по убыванию даты список комментариев к игре.",
+        "/admin/comments/{comment_id}/{action}": {
+            "post": {
+                "description": "approve: pending → visible; hide: visible или pending → hidden, reason обязателен; restore: hidden → visible.\nМодератор и причина пишутся в комментарий и в журнал.",
                 "consumes": [
                     "application/json"
                 ],
@@ -200,61 +207,120 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "comments"
+                    "moderation"
                 ],
-                "summary": "Получить список комментариев",
+                "summary": "Модерация комментария",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID игры",
-                        "name": "game_id",
+                        "description": "Bearer-токен модератора",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID комментария",
+                        "name": "comment_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Максимальное число комментариев",
-                        "name": "limit",
-                        "in": "query"
+                        "enum": [
+                            "approve",
+                            "hide",
+                            "restore"
+                        ],
+                        "type": "string",
+                        "description": "Действие",
+                        "name": "action",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Сдвиг для пагинации",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Причина",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ModerateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список комментариев и мета",
+                        "description": "Комментарий после модерации",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ListCommentsResponse"
+                            "$ref": "#/definitions/entity.Comment"
                         }
                     },
                     "400": {
-                        "description": "Неверные параметры запроса",
+                        "description": "Некорректный запрос",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_listcomments.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_moderation.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Нужен токен модератора",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_moderation.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Комментарий не найден",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_moderation.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Действие недопустимо для текущего статуса",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_moderation.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_listcomments.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_moderation.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/log-level": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Уровень логирования",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer-токен модератора",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LogLevelResponse"
                         }
                     },
-                    "504": {
-                        "description": "Таймаут обработки запроса",
+                    "401": {
+                        "description": "Нужен токен модератора",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_listcomments.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_loglevel.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Добавляет комментарий пользователя к указанной игре.",
+            "put": {
+                "description": "Действует до следующей смены или рестарта; после рестарта уровень снова берётся из конфига.",
                 "consumes": [
                     "application/json"
                 ],
@@ -262,64 +328,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "comments"
+                    "admin"
                 ],
-                "summary": "Постинг комментария",
+                "summary": "Сменить уровень логирования",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID игры",
-                        "name": "game_id",
-                        "in": "path",
+                        "description": "Bearer-токен модератора",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     },
                     {
-                        "description": "Тело запроса с полем user_id и text",
+                        "description": "Новый уровень: debug, info, warn, error",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.PostCommentRequest"
+                            "$ref": "#/definitions/handlers.LogLevelRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ID созданного комментария",
+                        "description": "Уровень после смены",
                         "schema": {
-                            "$ref": "#/definitions/handlers.AddCommentResponse"
+                            "$ref": "#/definitions/handlers.LogLevelResponse"
                         }
                     },
                     "400": {
-                        "description": "Некорректные входные данные",
-                        "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_addcomment.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Игра не найдена",
-                        "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_addcomment.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "Некорректный запрос или неизвестный уровень",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_addcomment.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_loglevel.ErrorResponse"
                         }
                     },
-                    "504": {
-                        "description": "Таймаут запроса",
+                    "401": {
+                        "description": "Нужен токен модератора",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_addcomment.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_loglevel.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/games/{game_id}/rating": {
-            "post": {
-                "description": "Отправить новую оценку (1–10) для указанной игры",
+        "/games": {
+            "get": {
+                "description": "Возвращает упорядоченный по id список игр с поддержкой limit/offset.",
                 "consumes": [
                     "application/json"
                 ],
@@ -329,58 +383,980 @@ const docTemplate = `{
                 "tags": [
                     "games"
                 ],
-                "summary": "Поставить оценку игре",
+                "summary": "Получить список игр",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Идентификатор игры",
-                        "name": "game_id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Максимальное число игр",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "Тело запроса с user_id и rating",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.PostRatingRequest"
-                        }
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Смещение для пагинации",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Пустой ответ — OK",
-                        "schema": {}
-                    },
-                    "400": {
-                        "description": "Некорректный запрос",
+                        "description": "Список игр и мета",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_postrating.ErrorResponse"
+                            "$ref": "#/definitions/handlers.ListGamesResponse"
                         }
                     },
-                    "404": {
-                        "description": "Игра не найдена",
+                    "400": {
+                        "description": "Неверные параметры запроса",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_postrating.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_mainpage.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Внутренняя ошибка сервера",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_postrating.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_mainpage.ErrorResponse"
                         }
                     },
-                    "503": {
-                        "description": "Брокер недоступен",
+                    "504": {
+                        "description": "Таймаут обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_mainpage.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт запись о новой игре.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Создание игры",
+                "parameters": [
+                    {
+                        "description": "Тело запроса",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateGameRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Игра успешно создана",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateGameResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "UUID созданного ресурса (/games/{id})"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос (невалидный UUID, отсутствие полей, неверный формат даты)",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_creategametopic.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Конфликт — игра с таким именем уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_creategametopic.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_creategametopic.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Таймаут обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_creategametopic.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/export": {
+            "get": {
+                "description": "Потоковая выгрузка всех игр (по id) с рейтингами из снапшотов (их пишет консьюмер rating-updates);\nбез снапшота рейтинг неизвестен: null, в CSV — пустые ячейки.\nФормат — параметр format или расширение пути: /games/export.csv. Без limit выгружается весь каталог.\nИтог выгрузки — в трейлере X-Export-Status: complete или failed (оборвана после начала).",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Выгрузка каталога игр",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ndjson",
+                        "description": "csv или ndjson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Максимальное число игр",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Строки выгрузки",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ExportGame"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_export.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_export.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Выгрузки выключены",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_export.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{game_id}": {
+            "get": {
+                "description": "Возвращает все поля сущности Game для переданного UUID игры.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Получить информацию об игре",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID игры",
+                        "name": "game_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные игры",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GameTopicResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_gametopic.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Игра не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_gametopic.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_gametopic.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Таймаут обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_gametopic.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{game_id}/comments": {
+            "get": {
+                "description": "Возвращает упорядоченный по убыванию даты список комментариев к игре.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Получить список комментариев",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID игры",
+                        "name": "game_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Максимальное число комментариев",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Сдвиг для пагинации",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список комментариев и мета",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListCommentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_listcomments.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_listcomments.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Таймаут обработки запроса",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_listcomments.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Добавляет комментарий пользователя к указанной игре. Комментарий проверяется фильтром:\nпри отказе возвращается 422 с кодом правила (banned_words, too_many_links, repeated_text, comment_too_long),\nсомнительный комментарий сохраняется со статусом pending (ответ 202) и появится после проверки модератором.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Постинг комментария",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID игры",
+                        "name": "game_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Тело запроса с полем user_id и text",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PostCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ID созданного комментария",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddCommentResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Комментарий отправлен на модерацию",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddCommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные входные данные",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_addcomment.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Игра не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_addcomment.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Комментарий отклонён фильтром",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_addcomment.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_addcomment.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Таймаут запроса",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_addcomment.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{game_id}/comments/export": {
+            "get": {
+                "description": "Потоковая выгрузка видимых комментариев игры по времени создания. Формат — параметр format\nили расширение пути. Итог — в трейлере X-Export-Status.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Выгрузка комментариев игры",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID игры",
+                        "name": "game_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "ndjson",
+                        "description": "csv или ndjson",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Строки выгрузки",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ExportComment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_export.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Игра не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_export.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_export.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Выгрузки выключены",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_export.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{game_id}/comments/stream": {
+            "get": {
+                "description": "SSE-поток: каждое событие comment содержит комментарий в JSON, id события — id комментария.\nПри переподключении браузер присылает Last-Event-ID, и пропущенные комментарии досылаются.\nДля первого подключения тот же курсор можно передать в query last_event_id.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Живая лента комментариев",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID игры",
+                        "name": "game_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "id последнего полученного комментария",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "то же, что Last-Event-ID",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поток событий",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный game_id",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_commentstream.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Игра не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_commentstream.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_commentstream.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Лента отключена",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_commentstream.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{game_id}/comments/{comment_id}/reports": {
+            "post": {
+                "description": "Одна жалоба от пользователя на комментарий. Набравший достаточно жалоб комментарий\nскрывается до проверки модератором.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Жалоба на комментарий",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID игры",
+                        "name": "game_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID комментария",
+                        "name": "comment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "user_id, причина (spam, harassment, spoiler, other) и пояснение",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReportCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Некорректные входные данные",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_reportcomment.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Комментарий не найден",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_reportcomment.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Пользователь уже жаловался на этот комментарий",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_reportcomment.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_reportcomment.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Таймаут запроса",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_reportcomment.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{game_id}/rating": {
+            "post": {
+                "description": "Отправить новую оценку (1–10) для указанной игры",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Поставить оценку игре",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор игры",
+                        "name": "game_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Тело запроса с user_id и rating",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PostRatingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пустой ответ — OK",
+                        "schema": {}
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_postrating.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Игра не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_postrating.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_postrating.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Брокер недоступен",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_postrating.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Таймаут обработки запроса",
                         "schema": {
                             "$ref": "#/definitions/internal_controller_http_handlers_postrating.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Процесс жив. Зависимости не проверяет.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LivenessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Проверяет Postgres, Redis, Kafka и rating service с таймаутами. Падение некритичной зависимости даёт status=degraded и 200.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness",
+                "responses": {
+                    "200": {
+                        "description": "Готов (ok или degraded)",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "Недоступна критичная зависимость",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Список подписок на вебхуки",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListWebhooksResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Нужен токен партнёра",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Подписка на события (GameCreated, CommentAdded, CommentDeleted — комментарий скрыт модерацией), опционально по одной игре.\nНужен токен партнёра (Authorization: Bearer); подписки видны и управляются только их владельцем.\nURL должен вести на публичный адрес: loopback, частные сети и metadata облака отклоняются.\nЗапросы подписываются HMAC-SHA256 секретом: X-GameHub-Signature = \"sha256=\" + hex(hmac(secret, timestamp + \".\" + body)).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Создание подписки на вебхуки",
+                "parameters": [
+                    {
+                        "description": "URL, секрет, типы событий и game_id",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Подписка создана",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateWebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Нужен токен партнёра",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Игра не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhook_id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Подписка на вебхуки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID подписки",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entity.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный webhook_id",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Нужен токен партнёра",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Подписка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Удаление подписки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID подписки",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    },
+                    "401": {
+                        "description": "Нужен токен партнёра",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Подписка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhook_id}/deliveries": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Журнал доставок вебхука",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID подписки",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы (с 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ListDeliveriesResponse"
+                        }
                     },
-                    "504": {
-                        "description": "Таймаут обработки запроса",
+                    "400": {
+                        "description": "Некорректные параметры",
                         "schema": {
-                            "$ref": "#/definitions/internal_controller_http_handlers_postrating.ErrorResponse"
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Нужен токен партнёра",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Подписка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhook_id}/enable": {
+            "post": {
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Повторное включение подписки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID подписки",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": ""
+                    },
+                    "401": {
+                        "description": "Нужен токен партнёра",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Подписка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_webhooks.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/ratings": {
+            "get": {
+                "description": "WebSocket. Подписка — в query (game_ids через запятую или top) либо сообщением\n{\"action\":\"subscribe\",\"game_ids\":[...]} / {\"action\":\"subscribe\",\"top\":10}; новая подписка заменяет прежнюю.\nСервер шлёт {\"type\":\"rating\",\"game_id\":...,\"average_rating\":...,\"ratings_count\":...,\"delta\":...,\"rank\":...}.\nКлиент, который не успевает читать, отключается с кодом 1013 — после переподключения стоит перечитать GET /games.",
+                "tags": [
+                    "games"
+                ],
+                "summary": "Живые обновления рейтингов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID игр через запятую",
+                        "name": "game_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Подписка на первые N игр рейтинга",
+                        "name": "top",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверная подписка",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller_http_handlers_ratingstream.ErrorResponse"
                         }
                     }
                 }
@@ -400,6 +1376,19 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "moderated_at": {
+                    "type": "string"
+                },
+                "moderated_by": {
+                    "description": "заполняются только для модераторов",
+                    "type": "string"
+                },
+                "moderation_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
                 "text": {
                     "type": "string"
                 },
@@ -465,17 +1454,257 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.ModerationAudit": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "comment_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "game_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "moderator": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ReportedComment": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "game_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_reported_at": {
+                    "type": "string"
+                },
+                "moderated_at": {
+                    "type": "string"
+                },
+                "moderated_by": {
+                    "description": "заполняются только для модераторов",
+                    "type": "string"
+                },
+                "moderation_reason": {
+                    "type": "string"
+                },
+                "reasons": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "reports_count": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.WebhookAttempt": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivery_id": {
+                    "type": "integer"
+                },
+                "delivery_status": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entity.WebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "consecutive_failures": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "disabled_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "game_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "handlers.AddCommentResponse": {
             "type": "object",
             "properties": {
                 "id": {
                     "type": "string"
+                },
+                "status": {
+                    "description": "visible | pending",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.AuditResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ModerationAudit"
+                    }
+                }
+            }
+        },
+        "handlers.CreateGameRequest": {
+            "type": "object",
+            "properties": {
+                "creator": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "genre": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "release_date": {
+                    "description": "or time.Time + правильный UnmarshalJSON",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreateGameResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreateWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "game_id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreateWebhookResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ExportComment": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "game_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "handlers.CreateGameRequest": {
+        "handlers.ExportGame": {
             "type": "object",
             "properties": {
+                "average_rating": {
+                    "description": "null — неизвестен",
+                    "type": "number"
+                },
                 "creator": {
                     "type": "string"
                 },
@@ -491,16 +1720,11 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "ratings_count": {
+                    "description": "null — неизвестен",
+                    "type": "integer"
+                },
                 "release_date": {
-                    "description": "or time.Time + правильный UnmarshalJSON",
-                    "type": "string"
-                }
-            }
-        },
-        "handlers.CreateGameResponse": {
-            "type": "object",
-            "properties": {
-                "id": {
                     "type": "string"
                 }
             }
@@ -527,6 +1751,20 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.ListDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.WebhookAttempt"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/internal_controller_http_handlers_webhooks.Pagination"
+                }
+            }
+        },
         "handlers.ListGamesResponse": {
             "type": "object",
             "properties": {
@@ -541,6 +1779,79 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.ListPendingResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Comment"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/internal_controller_http_handlers_moderation.Pagination"
+                }
+            }
+        },
+        "handlers.ListReportsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.ReportedComment"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/internal_controller_http_handlers_moderation.Pagination"
+                }
+            }
+        },
+        "handlers.ListWebhooksResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.WebhookSubscription"
+                    }
+                }
+            }
+        },
+        "handlers.LivenessResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LogLevelRequest": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string",
+                    "example": "debug"
+                }
+            }
+        },
+        "handlers.LogLevelResponse": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string",
+                    "example": "info"
+                }
+            }
+        },
+        "handlers.ModerateRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
         "handlers.PostCommentRequest": {
             "type": "object",
             "properties": {
@@ -563,6 +1874,55 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.ReportCommentRequest": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "spam | harassment | spoiler | other",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "health.CheckResult": {
+            "type": "object",
+            "properties": {
+                "critical": {
+                    "type": "boolean"
+                },
+                "duration": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "health.Report": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/health.CheckResult"
+                    }
+                },
+                "ready": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
         "internal_controller_http_handlers_addcomment.APIError": {
             "type": "object",
             "properties": {
@@ -584,6 +1944,25 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controller_http_handlers_commentstream.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_http_handlers_commentstream.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/internal_controller_http_handlers_commentstream.APIError"
+                }
+            }
+        },
         "internal_controller_http_handlers_creategametopic.APIError": {
             "type": "object",
             "properties": {
@@ -605,6 +1984,25 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controller_http_handlers_export.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_http_handlers_export.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/internal_controller_http_handlers_export.APIError"
+                }
+            }
+        },
         "internal_controller_http_handlers_gametopic.APIError": {
             "type": "object",
             "properties": {
@@ -660,6 +2058,25 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controller_http_handlers_loglevel.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_http_handlers_loglevel.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/internal_controller_http_handlers_loglevel.APIError"
+                }
+            }
+        },
         "internal_controller_http_handlers_mainpage.APIError": {
             "type": "object",
             "properties": {
@@ -696,6 +2113,39 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_controller_http_handlers_moderation.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_http_handlers_moderation.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/internal_controller_http_handlers_moderation.APIError"
+                }
+            }
+        },
+        "internal_controller_http_handlers_moderation.Pagination": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
         "internal_controller_http_handlers_postrating.APIError": {
             "type": "object",
             "properties": {
@@ -716,6 +2166,81 @@ const docTemplate = `{
                     "$ref": "#/definitions/internal_controller_http_handlers_postrating.APIError"
                 }
             }
+        },
+        "internal_controller_http_handlers_ratingstream.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_http_handlers_ratingstream.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/internal_controller_http_handlers_ratingstream.APIError"
+                }
+            }
+        },
+        "internal_controller_http_handlers_reportcomment.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "машинно-читаемый код ошибки",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "человеко-читаемое сообщение",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_http_handlers_reportcomment.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/internal_controller_http_handlers_reportcomment.APIError"
+                }
+            }
+        },
+        "internal_controller_http_handlers_webhooks.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "машинно-читаемый код ошибки",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "человеко-читаемое сообщение",
+                    "type": "string"
+                }
+            }
+        },
+        "internal_controller_http_handlers_webhooks.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/internal_controller_http_handlers_webhooks.APIError"
+                }
+            }
+        },
+        "internal_controller_http_handlers_webhooks.Pagination": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,62 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	postgres_storage "github.com/RozmiDan/gameReviewHub/internal/repo/postgre"
+)
+
+// TestGetCommentsAfter_Keyset проверяет догрузку по Last-Event-ID, в том числе при одинаковом created_at
+func TestGetCommentsAfter_Keyset(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+
+	ctx := context.Background()
+	gameID := "00000000-0000-0000-0000-000000000036"
+	_, err := conn.Pool.Exec(ctx,
+		`INSERT INTO games(id,name,genre,creator,description,release_date)
+		   VALUES($1,'Stream','G','G','G','2020-01-01')`, gameID)
+	require.NoError(t, err)
+
+	// c2 и c3 с одинаковым временем — порядок между ними задаёт id
+	base := time.Now().Add(-time.Minute)
+	ids := []string{
+		"00000000-0000-0000-0000-0000000000c1",
+		"00000000-0000-0000-0000-0000000000c2",
+		"00000000-0000-0000-0000-0000000000c3",
+		"00000000-0000-0000-0000-0000000000c4",
+	}
+	times := []time.Time{base, base.Add(time.Second), base.Add(time.Second), base.Add(2 * time.Second)}
+	for i, id := range ids {
+		_, err := conn.Pool.Exec(ctx,
+			`INSERT INTO comments(id, game_id, user_id, text, created_at) VALUES($1, $2, $3, $4, $5)`,
+			id, gameID, "22222222-2222-2222-2222-222222222222", "text", times[i])
+		require.NoError(t, err)
+	}
+
+	page, err := repo.GetCommentsAfter(ctx, gameID, ids[0], 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.Equal(t, ids[1], page[0].ID)
+	require.Equal(t, ids[2], page[1].ID)
+	require.Equal(t, gameID, page[0].GameID)
+
+	page, err = repo.GetCommentsAfter(ctx, gameID, page[1].ID, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, ids[3], page[0].ID)
+
+	page, err = repo.GetCommentsAfter(ctx, gameID, ids[3], 2)
+	require.NoError(t, err)
+	require.Empty(t, page)
+
+	_, err = repo.GetCommentsAfter(ctx, gameID, "00000000-0000-0000-0000-0000000000ff", 2)
+	require.ErrorIs(t, err, entity.ErrCommentNotFound)
+}
@@ -0,0 +1,50 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	postgres_storage "github.com/RozmiDan/gameReviewHub/internal/repo/postgre"
+)
+
+// TestDeadLetters_Lifecycle: сохранение, выборка pending, неудачная и успешная переотправка
+func TestDeadLetters_Lifecycle(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+	ctx := context.Background()
+
+	require.NoError(t, repo.SaveDeadLetter(ctx, entity.DeadLetter{
+		Topic:   "ratings",
+		Key:     []byte("game-1"),
+		Payload: []byte(`{"game_id":"game-1","user_id":"u","rating":5}`),
+		Headers: map[string]string{"event-id": "evt-1"},
+		Error:   "leader not available",
+	}))
+
+	pending, err := repo.ListDeadLetters(ctx, true, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	id := pending[0].ID
+	require.Equal(t, "evt-1", pending[0].Headers["event-id"])
+
+	require.NoError(t, repo.MarkDeadLetterAttempt(ctx, id, errors.New("still down")))
+	dl, err := repo.GetDeadLetter(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, 1, dl.Attempts)
+	require.Equal(t, "still down", dl.Error)
+	require.Nil(t, dl.ReplayedAt)
+
+	require.NoError(t, repo.MarkDeadLetterAttempt(ctx, id, nil))
+	pending, err = repo.ListDeadLetters(ctx, true, 0, 10)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	_, err = repo.GetDeadLetter(ctx, id+100)
+	require.ErrorIs(t, err, entity.ErrDeadLetterNotFound)
+}
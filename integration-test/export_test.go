@@ -0,0 +1,102 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	postgres_storage "github.com/RozmiDan/gameReviewHub/internal/repo/postgre"
+)
+
+// TestExportGames_Batches: курсор отдаёт каталог по id батчами нужного размера, limit/offset как у списка
+func TestExportGames_Batches(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+	ctx := context.Background()
+
+	games := make([]entity.Game, 5)
+	for i := range games {
+		games[i] = entity.Game{ID: fmt.Sprintf("00000000-0000-4000-8000-00000000000%d", i+1), Name: fmt.Sprintf("Export %d", i),
+			Genre: "g", Creator: "c", ReleaseDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	}
+	_, err := repo.CopyGames(ctx, games, entity.ConflictError)
+	require.NoError(t, err)
+
+	var sizes []int
+	var ids []string
+	err = repo.ExportGames(ctx, 0, 0, 2, func(batch []entity.Game) error {
+		sizes = append(sizes, len(batch))
+		for _, g := range batch {
+			ids = append(ids, g.ID)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{2, 2, 1}, sizes)
+	require.Equal(t, games[0].ID, ids[0])
+	require.Equal(t, games[4].ID, ids[4])
+
+	ids = nil
+	err = repo.ExportGames(ctx, 2, 1, 10, func(batch []entity.Game) error {
+		for _, g := range batch {
+			ids = append(ids, g.ID)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{games[1].ID, games[2].ID}, ids)
+
+	// ошибка fn прерывает выгрузку и возвращается как есть
+	stop := fmt.Errorf("client gone")
+	err = repo.ExportGames(ctx, 0, 0, 2, func([]entity.Game) error { return stop })
+	require.ErrorIs(t, err, stop)
+
+	// рейтинги из снапшотов: только для игр с оценками
+	_, err = repo.UpsertRatingSnapshot(ctx, entity.RatingUpdate{GameID: games[0].ID, AverageRating: 7, RatingsCount: 3, UpdatedAt: time.Now()})
+	require.NoError(t, err)
+	ratings, err := repo.GetRatingSnapshots(ctx, []string{games[0].ID, games[1].ID})
+	require.NoError(t, err)
+	require.Len(t, ratings, 1)
+	require.Equal(t, games[0].ID, ratings[0].GameID)
+	require.EqualValues(t, 3, ratings[0].RatingsCount)
+}
+
+// TestExportComments_VisibleOnly: скрытые модерацией комментарии в выгрузку не попадают
+func TestExportComments_VisibleOnly(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+	ctx := context.Background()
+
+	gameID, err := repo.AddGameTopic(ctx, &entity.Game{Name: "Export Comments", Genre: "g", Creator: "c",
+		Description: "d", ReleaseDate: time.Now()})
+	require.NoError(t, err)
+
+	now := time.Now()
+	comments := []entity.Comment{
+		{ID: "bbbbbbbb-0000-4000-8000-000000000001", GameID: gameID, UserID: "11111111-1111-1111-1111-111111111111",
+			Text: "first", Status: entity.CommentVisible, CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: "bbbbbbbb-0000-4000-8000-000000000002", GameID: gameID, UserID: "11111111-1111-1111-1111-111111111111",
+			Text: "hidden", Status: entity.CommentHidden, CreatedAt: now.Add(-time.Minute)},
+		{ID: "bbbbbbbb-0000-4000-8000-000000000003", GameID: gameID, UserID: "11111111-1111-1111-1111-111111111111",
+			Text: "second", Status: entity.CommentVisible, CreatedAt: now},
+	}
+	_, err = repo.CopyComments(ctx, comments, entity.ConflictError)
+	require.NoError(t, err)
+
+	var texts []string
+	err = repo.ExportComments(ctx, gameID, 1, func(batch []entity.Comment) error {
+		for _, c := range batch {
+			texts = append(texts, c.Text)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second"}, texts)
+}
@@ -65,7 +65,7 @@ func mustConn(t *testing.T) *postgres.Postgres {
 
 func cleanupTables(t *testing.T, conn *postgres.Postgres) {
 	_, err := conn.Pool.Exec(context.Background(),
-		`TRUNCATE comments, games RESTART IDENTITY CASCADE;`)
+		`TRUNCATE comments, games, event_outbox, kafka_dead_letters, webhook_subscriptions, comment_moderation_audit, comment_reports RESTART IDENTITY CASCADE;`)
 	require.NoError(t, err)
 }
 
@@ -77,7 +77,7 @@ func TestAddComment_GameNotFound(t *testing.T) {
 
 	ctx := context.Background()
 	// не создаём игру
-	id, err := repo.AddComment(ctx, "00000000-0000-0000-0000-000000000002", "11111111-1111-1111-1111-111111111111", "text")
+	id, err := repo.AddComment(ctx, "00000000-0000-0000-0000-000000000002", "11111111-1111-1111-1111-111111111111", "text", entity.CommentVisible)
 	require.Empty(t, id)
 	require.ErrorIs(t, err, entity.ErrGameNotFound)
 }
@@ -96,7 +96,7 @@ func TestGetCommentsGame_Empty(t *testing.T) {
 		   VALUES($1,'G','G','G','G','2020-01-01')`, gameID)
 	require.NoError(t, err)
 
-	comments, err := repo.GetCommentsGame(ctx, gameID, 10, 0)
+	comments, err := repo.GetCommentsGame(ctx, gameID, 10, 0, false)
 	require.NoError(t, err)
 	require.Len(t, comments, 0)
 }
@@ -133,19 +133,19 @@ func TestGetCommentsGame_Pagination(t *testing.T) {
 	// В БД от newest к oldest: c5, c4, c3, c2, c1
 
 	// страница 0, limit=2 → [c5, c4]
-	page0, err := repo.GetCommentsGame(ctx, gameID, 2, 0)
+	page0, err := repo.GetCommentsGame(ctx, gameID, 2, 0, false)
 	require.NoError(t, err)
 	require.Len(t, page0, 2)
 	require.Equal(t, "c5", page0[0].Text)
 	require.Equal(t, "c4", page0[1].Text)
 
-	page1, err := repo.GetCommentsGame(ctx, gameID, 2, 1)
+	page1, err := repo.GetCommentsGame(ctx, gameID, 2, 1, false)
 	require.NoError(t, err)
 	require.Len(t, page1, 2)
 	require.Equal(t, "c3", page1[0].Text)
 	require.Equal(t, "c2", page1[1].Text)
 
-	page2, err := repo.GetCommentsGame(ctx, gameID, 2, 2)
+	page2, err := repo.GetCommentsGame(ctx, gameID, 2, 2, false)
 	require.NoError(t, err)
 	require.Len(t, page2, 1)
 	require.Equal(t, "c1", page2[0].Text)
@@ -0,0 +1,57 @@
+package integration_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/RozmiDan/gameReviewHub/db"
+)
+
+// TestMigrator_ConcurrentUp: реплики стартуют одновременно с auto_migrate — миграция применяется один раз
+func TestMigrator_ConcurrentUp(t *testing.T) {
+	ctx := context.Background()
+	conn, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	migrator, err := db.NewMigrator(conn)
+	require.NoError(t, err)
+	latest, target, err := migrator.GetVersions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, target, latest)
+
+	// откатываем последнюю миграцию, чтобы было что применять
+	_, err = migrator.Down(ctx)
+	require.NoError(t, err)
+
+	const replicas = 4
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		applied int
+	)
+	for range replicas {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m, err := db.NewMigrator(conn)
+			require.NoError(t, err)
+			results, err := m.Up(ctx)
+			require.NoError(t, err)
+
+			mu.Lock()
+			applied += len(results)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 1, applied)
+	current, err := migrator.GetDBVersion(ctx)
+	require.NoError(t, err)
+	require.Equal(t, latest, current)
+}
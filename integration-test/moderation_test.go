@@ -0,0 +1,134 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	postgres_storage "github.com/RozmiDan/gameReviewHub/internal/repo/postgre"
+	"github.com/RozmiDan/gameReviewHub/internal/usecase"
+	"github.com/RozmiDan/gameReviewHub/pkg/postgres"
+)
+
+// TestModeration_HideAndRestore: скрытый комментарий пропадает из публичной ленты,
+// модератор видит его с причиной, каждое действие попадает в журнал
+func TestModeration_HideAndRestore(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+
+	ctx := context.Background()
+	gameID := "00000000-0000-0000-0000-000000000038"
+	_, err := conn.Pool.Exec(ctx,
+		`INSERT INTO games(id,name,genre,creator,description,release_date)
+		   VALUES($1,'Moderation','G','G','G','2020-01-01')`, gameID)
+	require.NoError(t, err)
+
+	commentID, err := repo.AddComment(ctx, gameID, "22222222-2222-2222-2222-222222222222", "buy cheap keys", entity.CommentVisible)
+	require.NoError(t, err)
+
+	uc := usecase.New(nil, repo, zap.NewNop(), nil, nil,
+		usecase.WithTransactor(repo), usecase.WithModeration(repo), usecase.WithEvents(repo))
+	modCtx := context.WithValue(ctx, entity.ModeratorKey{}, "alice")
+
+	// 1) hide
+	c, err := uc.ModerateComment(modCtx, commentID, entity.ModerationHide, "spam")
+	require.NoError(t, err)
+	require.Equal(t, entity.CommentHidden, c.Status)
+	require.Equal(t, "alice", c.ModeratedBy)
+	require.NotNil(t, c.ModeratedAt)
+
+	public, err := repo.GetCommentsGame(ctx, gameID, 10, 0, false)
+	require.NoError(t, err)
+	require.Empty(t, public)
+
+	all, err := repo.GetCommentsGame(ctx, gameID, 10, 0, true)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	require.Equal(t, entity.CommentHidden, all[0].Status)
+	require.Equal(t, "spam", all[0].ModerationReason)
+
+	// 2) повторно скрыть нельзя, журнал не растёт
+	_, err = uc.ModerateComment(modCtx, commentID, entity.ModerationHide, "spam")
+	require.ErrorIs(t, err, entity.ErrInvalidModeration)
+
+	// 3) restore
+	_, err = uc.ModerateComment(modCtx, commentID, entity.ModerationRestore, "")
+	require.NoError(t, err)
+	public, err = repo.GetCommentsGame(ctx, gameID, 10, 0, false)
+	require.NoError(t, err)
+	require.Len(t, public, 1)
+	require.Empty(t, public[0].ModeratedBy)
+
+	// 4) в outbox: скрытие — CommentDeleted, восстановление — снова CommentAdded
+	require.Equal(t, []string{entity.EventCommentDeleted, entity.EventCommentAdded}, outboxEvents(t, conn, commentID))
+
+	audit, err := uc.GetModerationAudit(modCtx, commentID)
+	require.NoError(t, err)
+	require.Len(t, audit, 2)
+	require.Equal(t, entity.ModerationHide, audit[0].Action)
+	require.Equal(t, entity.CommentVisible, audit[0].FromStatus)
+	require.Equal(t, "spam", audit[0].Reason)
+	require.Equal(t, entity.ModerationRestore, audit[1].Action)
+	require.Equal(t, entity.CommentVisible, audit[1].ToStatus)
+}
+
+// TestModeration_PendingQueue: pending не виден публично, approve выводит его в ленту
+func TestModeration_PendingQueue(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+
+	ctx := context.Background()
+	gameID := "00000000-0000-0000-0000-000000000039"
+	_, err := conn.Pool.Exec(ctx,
+		`INSERT INTO games(id,name,genre,creator,description,release_date)
+		   VALUES($1,'Queue','G','G','G','2020-01-01')`, gameID)
+	require.NoError(t, err)
+
+	commentID, err := repo.AddComment(ctx, gameID, "22222222-2222-2222-2222-222222222222", "first!", entity.CommentPending)
+	require.NoError(t, err)
+
+	pending, err := repo.ListCommentsByStatus(ctx, entity.CommentPending, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, gameID, pending[0].GameID)
+
+	public, err := repo.GetCommentsGame(ctx, gameID, 10, 0, false)
+	require.NoError(t, err)
+	require.Empty(t, public)
+
+	uc := usecase.New(nil, repo, zap.NewNop(), nil, nil,
+		usecase.WithTransactor(repo), usecase.WithModeration(repo))
+	_, err = uc.ModerateComment(context.WithValue(ctx, entity.ModeratorKey{}, "bob"), commentID,
+		entity.ModerationApprove, "")
+	require.NoError(t, err)
+
+	pending, err = repo.ListCommentsByStatus(ctx, entity.CommentPending, 10, 0)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+	public, err = repo.GetCommentsGame(ctx, gameID, 10, 0, false)
+	require.NoError(t, err)
+	require.Len(t, public, 1)
+}
+
+// outboxEvents — типы событий по агрегату в порядке появления
+func outboxEvents(t *testing.T, conn *postgres.Postgres, aggregateID string) []string {
+	t.Helper()
+	rows, err := conn.Pool.Query(context.Background(),
+		`SELECT event_type FROM event_outbox WHERE aggregate_id = $1 ORDER BY occurred_at`, aggregateID)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	types := []string{}
+	for rows.Next() {
+		var typ string
+		require.NoError(t, rows.Scan(&typ))
+		types = append(types, typ)
+	}
+	require.NoError(t, rows.Err())
+	return types
+}
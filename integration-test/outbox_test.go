@@ -0,0 +1,77 @@
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/RozmiDan/gameReviewHub/internal/outbox"
+	postgres_storage "github.com/RozmiDan/gameReviewHub/internal/repo/postgre"
+	"github.com/RozmiDan/gameReviewHub/internal/usecase"
+)
+
+type failingPublisher struct{}
+
+func (failingPublisher) PublishEvent(ctx context.Context, evt entity.DomainEvent) error {
+	return errors.New("outbox unavailable")
+}
+
+type recordingPublisher struct {
+	events []entity.DomainEvent
+}
+
+func (p *recordingPublisher) PublishEvent(ctx context.Context, evt entity.DomainEvent) error {
+	p.events = append(p.events, evt)
+	return nil
+}
+
+// TestOutbox_GameCreated: игра и событие появляются атомарно, relay отправляет событие один раз
+func TestOutbox_GameCreated(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+	ctx := context.Background()
+
+	uc := usecase.New(nil, repo, zap.NewNop(), nil, nil, usecase.WithTransactor(repo), usecase.WithEvents(repo))
+	gameID, err := uc.CreateGameTopic(ctx, &entity.Game{Name: "Outboxed", ReleaseDate: time.Now()})
+	require.NoError(t, err)
+
+	var pending int
+	require.NoError(t, conn.Pool.QueryRow(ctx,
+		`SELECT count(*) FROM event_outbox WHERE game_id = $1 AND published_at IS NULL`, gameID).Scan(&pending))
+	require.Equal(t, 1, pending)
+
+	pub := &recordingPublisher{}
+	relay := outbox.New(repo, pub, zap.NewNop(), time.Second, 10)
+
+	n, err := relay.Flush(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, entity.EventGameCreated, pub.events[0].Type)
+	require.Equal(t, gameID, pub.events[0].GameID)
+
+	n, err = relay.Flush(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+// TestOutbox_RollbackOnEventFailure: без события не остаётся и записи
+func TestOutbox_RollbackOnEventFailure(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+	ctx := context.Background()
+
+	uc := usecase.New(nil, repo, zap.NewNop(), nil, nil, usecase.WithTransactor(repo), usecase.WithEvents(failingPublisher{}))
+	_, err := uc.CreateGameTopic(ctx, &entity.Game{Name: "Rolled back", ReleaseDate: time.Now()})
+	require.ErrorIs(t, err, entity.ErrInternal)
+
+	var games int
+	require.NoError(t, conn.Pool.QueryRow(ctx, `SELECT count(*) FROM games`).Scan(&games))
+	require.Zero(t, games)
+}
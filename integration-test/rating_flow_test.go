@@ -0,0 +1,49 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/RozmiDan/gameReviewHub/internal/fakerating"
+	rating "github.com/RozmiDan/gameReviewHub/internal/repo/grpcclient"
+	postgres_storage "github.com/RozmiDan/gameReviewHub/internal/repo/postgre"
+	"github.com/RozmiDan/gameReviewHub/internal/usecase"
+)
+
+// TestRatingFlow_FakeRatingService прогоняет публикацию оценки и чтение рейтинга игры
+// через настоящий gRPC-клиент и встроенный фейковый rating service, без внешнего репозитория
+func TestRatingFlow_FakeRatingService(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+
+	ctx := context.Background()
+	gameID := "00000000-0000-0000-0000-000000000010"
+	_, err := conn.Pool.Exec(ctx,
+		`INSERT INTO games(id,name,genre,creator,description,release_date)
+		   VALUES($1,'Rated','G','G','G','2020-01-01')`, gameID)
+	require.NoError(t, err)
+
+	fake := fakerating.New(zap.NewNop())
+	srv, dial := fake.ServeBufconn()
+	defer srv.Stop()
+
+	client, err := rating.New(ctx, zap.NewNop(), []string{"passthrough:///fake-rating"}, time.Second,
+		rating.Dialer(dial))
+	require.NoError(t, err)
+
+	uc := usecase.New(client, repo, zap.NewNop(), fake, nil)
+
+	require.NoError(t, uc.PostRating(ctx, gameID, "33333333-3333-3333-3333-333333333333", 8))
+	require.NoError(t, uc.PostRating(ctx, gameID, "44444444-4444-4444-4444-444444444444", 6))
+
+	game, err := uc.GetTopicGame(ctx, gameID)
+	require.NoError(t, err)
+	require.Equal(t, "Rated", game.Name)
+	require.Equal(t, int64(2), game.Rating.RatingsCount)
+	require.InDelta(t, 7.0, game.Rating.AverageRating, 1e-9)
+}
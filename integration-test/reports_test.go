@@ -0,0 +1,82 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	postgres_storage "github.com/RozmiDan/gameReviewHub/internal/repo/postgre"
+	"github.com/RozmiDan/gameReviewHub/internal/usecase"
+)
+
+// TestReports_ThresholdAndSummary: дубль жалобы отклоняется, на пороге комментарий уходит на модерацию,
+// сводка для модераторов отсортирована по числу жалоб
+func TestReports_ThresholdAndSummary(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+
+	ctx := context.Background()
+	gameID := "00000000-0000-0000-0000-000000000040"
+	_, err := conn.Pool.Exec(ctx,
+		`INSERT INTO games(id,name,genre,creator,description,release_date)
+		   VALUES($1,'Reports','G','G','G','2020-01-01')`, gameID)
+	require.NoError(t, err)
+
+	author := "22222222-2222-2222-2222-222222222222"
+	noisy, err := repo.AddComment(ctx, gameID, author, "spoilers everywhere", entity.CommentVisible)
+	require.NoError(t, err)
+	quiet, err := repo.AddComment(ctx, gameID, author, "meh", entity.CommentVisible)
+	require.NoError(t, err)
+
+	uc := usecase.New(nil, repo, zap.NewNop(), nil, nil,
+		usecase.WithTransactor(repo), usecase.WithModeration(repo), usecase.WithReports(repo, 2),
+		usecase.WithEvents(repo))
+
+	rep := func(commentID, userID, reason string) error {
+		return uc.ReportComment(ctx, entity.CommentReport{
+			CommentID: commentID, GameID: gameID, UserID: userID, Reason: reason,
+		})
+	}
+	u1 := "00000000-0000-0000-0000-0000000000a1"
+	u2 := "00000000-0000-0000-0000-0000000000a2"
+
+	require.NoError(t, rep(noisy, u1, entity.ReportSpoiler))
+	require.ErrorIs(t, rep(noisy, u1, entity.ReportSpam), entity.ErrDuplicateReport)
+	require.NoError(t, rep(quiet, u1, entity.ReportOther))
+
+	// чужая игра в URL
+	err = uc.ReportComment(ctx, entity.CommentReport{
+		CommentID: noisy, GameID: "00000000-0000-0000-0000-000000000099", UserID: u2, Reason: entity.ReportSpam,
+	})
+	require.ErrorIs(t, err, entity.ErrCommentNotFound)
+
+	// порог
+	require.NoError(t, rep(noisy, u2, entity.ReportSpoiler))
+	public, err := repo.GetCommentsGame(ctx, gameID, 10, 0, false)
+	require.NoError(t, err)
+	require.Len(t, public, 1)
+	require.Equal(t, quiet, public[0].ID)
+
+	audit, err := repo.ListModerationAudit(ctx, noisy)
+	require.NoError(t, err)
+	require.Len(t, audit, 1)
+	require.Equal(t, entity.ModerationAutoHide, audit[0].Action)
+	require.Equal(t, []string{entity.EventCommentDeleted}, outboxEvents(t, conn, noisy))
+	require.Empty(t, outboxEvents(t, conn, quiet))
+
+	reported, err := uc.ListReportedComments(ctx, "", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, reported, 2)
+	require.Equal(t, noisy, reported[0].ID)
+	require.Equal(t, 2, reported[0].ReportsCount)
+	require.Equal(t, map[string]int{entity.ReportSpoiler: 2}, reported[0].Reasons)
+	require.Equal(t, entity.CommentPending, reported[0].Status)
+
+	pendingOnly, err := uc.ListReportedComments(ctx, entity.CommentPending, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, pendingOnly, 1)
+}
@@ -0,0 +1,82 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	postgres_storage "github.com/RozmiDan/gameReviewHub/internal/repo/postgre"
+)
+
+// TestCopyGames_ConflictModes: COPY батчем, повторная загрузка в режимах skip, upsert и error
+func TestCopyGames_ConflictModes(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+	ctx := context.Background()
+
+	release := time.Date(2020, 6, 2, 0, 0, 0, 0, time.UTC)
+	games := []entity.Game{
+		{ID: "4b825dc6-1e82-4f38-9f8e-3a2f1b3c4d5e", Name: "Overwatch 2", Genre: "Hero shooter", Creator: "Blizzard", ReleaseDate: release},
+		{ID: "9c8ef1a2-3b4c-4d5e-8f9a-1bc2d3e4f5a6", Name: "Valorant", Genre: "Tactical shooter", Creator: "Riot", ReleaseDate: release},
+	}
+
+	n, err := repo.CopyGames(ctx, games, entity.ConflictSkip)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, n)
+
+	// skip: существующие по id и по name не трогаются
+	n, err = repo.CopyGames(ctx, append(games, entity.Game{
+		ID: "1a2b3c4d-5e6f-4a8b-9c0d-1e2f3a4b5c6d", Name: "Valorant", Genre: "dup", Creator: "x", ReleaseDate: release,
+	}), entity.ConflictSkip)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	// upsert: обновление по id
+	games[1].Genre = "FPS"
+	n, err = repo.CopyGames(ctx, games[1:], entity.ConflictUpsert)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+	game, err := repo.GetGameTopic(ctx, games[1].ID)
+	require.NoError(t, err)
+	require.Equal(t, "FPS", game.Genre)
+
+	// error: первый же конфликт прерывает батч
+	_, err = repo.CopyGames(ctx, games, entity.ConflictError)
+	require.ErrorIs(t, err, entity.ErrGameAlreadyExists)
+}
+
+// TestCopyComments_SkipsUnknownGames: в режиме skip комментарии к несуществующим играм пропускаются, в error — ошибка
+func TestCopyComments_SkipsUnknownGames(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+	ctx := context.Background()
+
+	gameID, err := repo.AddGameTopic(ctx, &entity.Game{Name: "Seed Game", Genre: "g", Creator: "c",
+		Description: "d", ReleaseDate: time.Now()})
+	require.NoError(t, err)
+
+	comments := []entity.Comment{
+		{ID: "aaaaaaaa-0000-4000-8000-000000000001", GameID: gameID, UserID: "11111111-1111-1111-1111-111111111111",
+			Text: "gg", Status: entity.CommentVisible, CreatedAt: time.Now()},
+		{ID: "aaaaaaaa-0000-4000-8000-000000000002", GameID: "00000000-0000-0000-0000-000000000002",
+			UserID: "11111111-1111-1111-1111-111111111111", Text: "orphan", Status: entity.CommentVisible, CreatedAt: time.Now()},
+	}
+
+	n, err := repo.CopyComments(ctx, comments, entity.ConflictSkip)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+
+	list, err := repo.GetCommentsGame(ctx, gameID, 10, 0, false)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "gg", list[0].Text)
+
+	_, err = repo.CopyComments(ctx, comments[1:], entity.ConflictError)
+	require.ErrorIs(t, err, entity.ErrGameNotFound)
+}
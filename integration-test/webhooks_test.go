@@ -0,0 +1,170 @@
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	postgres_storage "github.com/RozmiDan/gameReviewHub/internal/repo/postgre"
+	"github.com/RozmiDan/gameReviewHub/internal/usecase"
+	"github.com/RozmiDan/gameReviewHub/internal/webhook"
+)
+
+const webhookSecret = "integration-secret-0123456789"
+
+// получатели — httptest на 127.0.0.1, поэтому проверка адресов отключена
+var localGuard = webhook.NewGuard(true)
+
+func partnerCtx(name string) context.Context {
+	return context.WithValue(context.Background(), entity.PartnerKey{}, name)
+}
+
+// TestWebhooks_CommentAddedDelivered: подписка → комментарий → подписанный POST на httptest-получатель
+func TestWebhooks_CommentAddedDelivered(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+	ctx := partnerCtx("acme")
+
+	gameID := "00000000-0000-0000-0000-000000000020"
+	_, err := conn.Pool.Exec(ctx,
+		`INSERT INTO games(id,name,genre,creator,description,release_date)
+		   VALUES($1,'Hooked','G','G','G','2020-01-01')`, gameID)
+	require.NoError(t, err)
+
+	received := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+	}))
+	defer receiver.Close()
+
+	uc := usecase.New(nil, repo, zap.NewNop(), nil, nil, usecase.WithTransactor(repo), usecase.WithWebhooks(repo, localGuard))
+	subID, err := uc.CreateWebhook(ctx, &entity.WebhookSubscription{
+		URL:        receiver.URL,
+		Secret:     webhookSecret,
+		EventTypes: []string{entity.EventCommentAdded},
+		GameID:     gameID,
+	})
+	require.NoError(t, err)
+
+	comment, err := uc.AddComment(ctx, gameID, "11111111-1111-1111-1111-111111111111", "hello")
+	require.NoError(t, err)
+
+	n, err := webhook.New(repo, zap.NewNop(), webhook.Destinations(localGuard)).Flush(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	req := <-received
+	body := <-bodies
+	ts, err := strconv.ParseInt(req.Header.Get(webhook.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	require.Equal(t, "sha256="+webhook.Sign(webhookSecret, ts, body), req.Header.Get(webhook.HeaderSignature))
+
+	var evt entity.DomainEvent
+	require.NoError(t, json.Unmarshal(body, &evt))
+	require.Equal(t, entity.EventCommentAdded, evt.Type)
+	require.Equal(t, comment.ID, evt.AggregateID)
+
+	attempts, err := uc.ListWebhookAttempts(ctx, subID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	require.Equal(t, entity.WebhookDelivered, attempts[0].Status)
+	require.Equal(t, http.StatusOK, attempts[0].StatusCode)
+}
+
+// TestWebhooks_DisabledAfterFailures: получатель всё время отвечает 500 — подписка отключается
+func TestWebhooks_DisabledAfterFailures(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+	ctx := partnerCtx("acme")
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	uc := usecase.New(nil, repo, zap.NewNop(), nil, nil, usecase.WithTransactor(repo), usecase.WithWebhooks(repo, localGuard))
+	subID, err := uc.CreateWebhook(ctx, &entity.WebhookSubscription{
+		URL:        receiver.URL,
+		Secret:     webhookSecret,
+		EventTypes: []string{entity.EventGameCreated},
+	})
+	require.NoError(t, err)
+
+	for _, name := range []string{"First", "Second"} {
+		_, err := uc.CreateGameTopic(ctx, &entity.Game{Name: name, ReleaseDate: time.Now()})
+		require.NoError(t, err)
+	}
+
+	// без задержки между попытками, отключение после двух неудач подряд
+	dispatcher := webhook.New(repo, zap.NewNop(), webhook.Destinations(localGuard), webhook.Backoff(5, time.Nanosecond, time.Nanosecond), webhook.DisableAfter(2))
+	_, err = dispatcher.Flush(ctx)
+	require.NoError(t, err)
+
+	sub, err := uc.GetWebhook(ctx, subID)
+	require.NoError(t, err)
+	require.False(t, sub.Active)
+	require.NotNil(t, sub.DisabledAt)
+
+	// отключённая подписка не получает доставок
+	n, err := dispatcher.Flush(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	require.NoError(t, uc.EnableWebhook(ctx, subID))
+	sub, err = uc.GetWebhook(ctx, subID)
+	require.NoError(t, err)
+	require.True(t, sub.Active)
+	require.Zero(t, sub.ConsecutiveFailures)
+}
+
+// TestWebhooks_ScopedToOwner: партнёр не видит, не удаляет и не включает чужие подписки;
+// адреса во внутренней сети отклоняются при создании
+func TestWebhooks_ScopedToOwner(t *testing.T) {
+	conn := mustConn(t)
+	repo := postgres_storage.New(conn, zap.NewNop())
+	cleanupTables(t, conn)
+
+	uc := usecase.New(nil, repo, zap.NewNop(), nil, nil, usecase.WithTransactor(repo),
+		usecase.WithWebhooks(repo, webhook.NewGuard(false)))
+	acme, other := partnerCtx("acme"), partnerCtx("other")
+
+	subID, err := uc.CreateWebhook(acme, &entity.WebhookSubscription{
+		URL: "https://93.184.216.34/hooks", Secret: webhookSecret, EventTypes: []string{entity.EventGameCreated},
+	})
+	require.NoError(t, err)
+
+	_, err = uc.CreateWebhook(acme, &entity.WebhookSubscription{
+		URL: "http://127.0.0.1:5432/", Secret: webhookSecret, EventTypes: []string{entity.EventGameCreated},
+	})
+	require.ErrorIs(t, err, entity.ErrInvalidWebhook)
+
+	mine, err := uc.ListWebhooks(acme)
+	require.NoError(t, err)
+	require.Len(t, mine, 1)
+	theirs, err := uc.ListWebhooks(other)
+	require.NoError(t, err)
+	require.Empty(t, theirs)
+
+	_, err = uc.GetWebhook(other, subID)
+	require.ErrorIs(t, err, entity.ErrWebhookNotFound)
+	require.ErrorIs(t, uc.EnableWebhook(other, subID), entity.ErrWebhookNotFound)
+	require.ErrorIs(t, uc.DeleteWebhook(other, subID), entity.ErrWebhookNotFound)
+	_, err = uc.ListWebhookAttempts(other, subID, 10, 0)
+	require.ErrorIs(t, err, entity.ErrWebhookNotFound)
+
+	require.NoError(t, uc.DeleteWebhook(acme, subID))
+}
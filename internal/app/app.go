@@ -2,96 +2,361 @@ package app
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
-	"time"
 
 	"github.com/RozmiDan/gameReviewHub/db"
+	"github.com/RozmiDan/gameReviewHub/internal/commentfilter"
 	"github.com/RozmiDan/gameReviewHub/internal/config"
+	middleware_ratelimit "github.com/RozmiDan/gameReviewHub/internal/controller/http/middleware/ratelimit"
 	httpserver "github.com/RozmiDan/gameReviewHub/internal/controller/http/server"
+	"github.com/RozmiDan/gameReviewHub/internal/controller/kafka/ratingupdates"
+	"github.com/RozmiDan/gameReviewHub/internal/fakerating"
+	"github.com/RozmiDan/gameReviewHub/internal/health"
+	"github.com/RozmiDan/gameReviewHub/internal/lifecycle"
+	"github.com/RozmiDan/gameReviewHub/internal/liverating"
+	"github.com/RozmiDan/gameReviewHub/internal/outbox"
 	rating "github.com/RozmiDan/gameReviewHub/internal/repo/grpcclient"
 	postgres_storage "github.com/RozmiDan/gameReviewHub/internal/repo/postgre"
 	redis_build "github.com/RozmiDan/gameReviewHub/internal/repo/redis"
 	"github.com/RozmiDan/gameReviewHub/internal/usecase"
+	"github.com/RozmiDan/gameReviewHub/internal/webhook"
 
 	"github.com/RozmiDan/gameReviewHub/pkg/kafka"
 	"github.com/RozmiDan/gameReviewHub/pkg/logger"
 	prom_metrics "github.com/RozmiDan/gameReviewHub/pkg/metrics"
 	"github.com/RozmiDan/gameReviewHub/pkg/postgres"
+	"github.com/RozmiDan/gameReviewHub/pkg/tracing"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
 
-func Run(cfg *config.Config) {
+// Run собирает сервис и работает до SIGINT/SIGTERM. Ошибку сборки или работы уже записал в лог;
+// вызывающему остаётся выйти с ненулевым кодом.
+func Run(cfg *config.Config) error {
 
-	logger := logger.NewLogger(cfg.Env)
+	logger, logLevel := logger.NewLogger(cfg.Env,
+		logger.Level(cfg.Logging.Level),
+		logger.Format(cfg.Logging.Format),
+		logger.Outputs(cfg.Logging.Outputs...),
+		logger.Sampling(cfg.Logging.Sampling.Initial, cfg.Logging.Sampling.Thereafter),
+		logger.Rotation(cfg.Logging.Rotation.MaxSizeMB, cfg.Logging.Rotation.MaxBackups,
+			cfg.Logging.Rotation.MaxAgeDays, cfg.Logging.Rotation.Compress),
+	)
+
+	defer logger.Sync()
 
 	logger.Info("App started")
 	logger.Debug("debug mode")
 
-	db.SetupPostgres(cfg, logger)
-	logger.Info("Migrations completed successfully\n")
+	// компоненты останавливаются в обратном порядке добавления: сервер → воркеры → продьюсеры → хранилища
+	lc := lifecycle.New(logger, lifecycle.StopTimeout(cfg.Shutdown.Timeout))
+	// при ошибке сборки закрывается всё, что уже успели открыть; после lc.Run — no-op
+	defer lc.Stop()
+
+	// трейсинг — до клиентов, чтобы их спаны сразу уходили в экспортёр
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.AppInfo.Name, logger,
+		tracing.Exporter(cfg.Tracing.Exporter),
+		tracing.OTLP(cfg.Tracing.Endpoint, cfg.Tracing.Insecure),
+		tracing.File(cfg.Tracing.File),
+		tracing.SampleRatio(cfg.Tracing.SampleRatio),
+		tracing.Service(cfg.AppInfo.Version, cfg.Env),
+	)
+	if err != nil {
+		logger.Error("Cant init tracing", zap.Error(err))
+		return err
+	}
+	// последним: спаны остановки остальных компонентов тоже должны уйти в экспортёр
+	lc.Add(lifecycle.Component{Name: "tracing", Stop: shutdownTracing})
+
+	if cfg.PostgreURL.AutoMigrate {
+		if err := db.SetupPostgres(context.Background(), cfg, logger); err != nil {
+			logger.Error("can't setup migrations", zap.Error(err))
+			return err
+		}
+		logger.Info("Migrations completed successfully")
+	} else {
+		logger.Info("auto-migrate disabled, schema is managed by cmd/migrate")
+	}
 
 	// repo
-	pg, err := postgres.New(cfg.PostgreURL.URL, postgres.MaxPoolSize(25))
+	pgOpts := []postgres.Option{
+		postgres.MaxPoolSize(int(cfg.PostgreURL.PgPoolMax)),
+		postgres.MinPoolSize(int(cfg.PostgreURL.PgPoolMin)),
+		postgres.ConnLifetime(cfg.PostgreURL.MaxConnLifetime, cfg.PostgreURL.MaxConnIdleTime),
+		postgres.HealthCheckPeriod(cfg.PostgreURL.HealthCheckPeriod),
+		postgres.QueryTracer(tracing.PgxTracer{}),
+	}
+	// после PgxTracer: к концу запроса в ctx уже есть его спан, и в лог попадает trace_id
+	if cfg.PostgreURL.SlowQueryThreshold > 0 {
+		pgOpts = append(pgOpts,
+			postgres.QueryTracer(postgres.NewSlowQueryLogger(cfg.PostgreURL.SlowQueryThreshold, logger)))
+	}
+	pg, err := postgres.New(cfg.PostgreURL.URL, pgOpts...)
 	if err != nil {
 		logger.Error("Cant open database", zap.Error(err))
-		os.Exit(1)
+		return err
 	}
-	defer pg.Close()
+	lc.Add(lifecycle.Component{Name: "postgres", Stop: lifecycle.Closer(func() error {
+		pg.Close()
+		return nil
+	})})
 
 	repo := postgres_storage.New(pg, logger)
 
-	// grpc
-	ratingService, err := rating.New(context.TODO(), logger, cfg.GrpcInfo.Address, cfg.GrpcInfo.Timeout)
-	if err != nil {
-		logger.Error("Cant connect to rating service")
-		os.Exit(1)
+	// инициализируем метрики (до клиентов, которые их пишут)
+	prom_metrics.Init()
+	prometheus.MustRegister(prom_metrics.NewPgxPoolCollector(pg.Pool))
+
+	// grpc: список адресов или один target (в том числе dns:///host:port)
+	ratingAddrs := cfg.GrpcInfo.Addresses
+	if len(ratingAddrs) == 0 {
+		ratingAddrs = []string{cfg.GrpcInfo.Address}
+	}
+	ratingOpts := []rating.Option{
+		rating.HealthCheck(cfg.GrpcInfo.HealthCheck, cfg.GrpcInfo.HealthService),
+		rating.CallTimeouts(cfg.GrpcInfo.CallTimeout, cfg.GrpcInfo.MethodTimeouts),
+		rating.Retry(cfg.GrpcInfo.Retry.MaxAttempts, cfg.GrpcInfo.Retry.Backoff, cfg.GrpcInfo.Retry.Jitter),
+		rating.Breaker(cfg.GrpcInfo.Breaker.FailureThreshold, cfg.GrpcInfo.Breaker.OpenTimeout,
+			cfg.GrpcInfo.Breaker.HalfOpenRequests),
+	}
+	if tlsCfg := cfg.GrpcInfo.TLS; tlsCfg.Enabled && !cfg.GrpcInfo.Fake {
+		ratingOpts = append(ratingOpts,
+			rating.TLS(tlsCfg.CAFile, tlsCfg.CertFile, tlsCfg.KeyFile, tlsCfg.ServerName))
 	}
 
-	// инициализируем метрики
-	prom_metrics.Init()
+	// встроенный фейковый rating service: gRPC через bufconn, оценки — из Kafka или напрямую
+	var fakeRating *fakerating.Service
+	if cfg.GrpcInfo.Fake {
+		fakeRating = fakerating.New(logger)
+		fakeSrv, dial := fakeRating.ServeBufconn()
+		lc.Add(lifecycle.Component{Name: "fake_rating_server", Stop: lifecycle.Closer(func() error {
+			fakeSrv.GracefulStop()
+			return nil
+		})})
+
+		ratingAddrs = []string{"passthrough:///fake-rating"}
+		ratingOpts = append(ratingOpts, rating.Dialer(dial))
+		logger.Warn("using embedded fake rating service")
+	}
+	ratingService, err := rating.New(context.TODO(), logger, ratingAddrs, cfg.GrpcInfo.Timeout, ratingOpts...)
+	if err != nil {
+		logger.Error("Cant connect to rating service", zap.Error(err))
+		return err
+	}
+	lc.Add(lifecycle.Component{Name: "rating_client", Stop: lifecycle.Closer(ratingService.Close)})
 
 	// kafka
-	kafkaProducer := kafka.NewProducer(&cfg.Kafka, logger)
-	//kafkaProducer := &RatingProducer{}
+	var ratingProducer usecase.RatingProducer
+	if fakeRating != nil && len(cfg.Kafka.Brokers) == 0 {
+		// без брокеров оценки сразу уходят в фейковый сервис
+		ratingProducer = fakeRating
+	} else {
+		// недоставленные оценки сохраняются в kafka_dead_letters, переотправка — cmd/replay
+		producer := kafka.NewProducer(&cfg.Kafka, logger, kafka.WithDeadLetters(repo))
+		ratingProducer = producer
+		// Close дожидается отправки буфера async-writer'а; недоставленное уходит в dead letters, поэтому Postgres закрывается позже
+		lc.Add(lifecycle.Component{Name: "kafka_producer", Stop: lifecycle.Closer(producer.Close),
+			StopTimeout: cfg.Shutdown.KafkaTimeout})
+		if fakeRating != nil {
+			lc.Add(lifecycle.Component{Name: "fake_rating_consumer", Run: func(ctx context.Context) error {
+				if err := fakeRating.Consume(ctx, cfg.Kafka.Brokers, cfg.Kafka.TopicRatings); err != nil && ctx.Err() == nil {
+					logger.Error("fake rating consumer stopped", zap.Error(err))
+				}
+				return nil
+			}})
+		}
+	}
 
 	// redis
 	redisClient := redis_build.NewRedisClient(cfg.Redis.RedisAddress, cfg.Redis.RedisPassword,
 		cfg.Redis.RedisDB, cfg.Redis.RedisTTL, logger)
+	lc.Add(lifecycle.Component{Name: "redis", Stop: lifecycle.Closer(redisClient.Close)})
 
 	// usecase
-	uc := usecase.New(ratingService, repo, logger, kafkaProducer, redisClient)
+	ucOpts := []usecase.Option{usecase.WithRatingSnapshots(repo), usecase.WithTransactor(repo),
+		usecase.WithModeration(repo), usecase.WithReports(repo, cfg.Comments.ReportThreshold), usecase.WithExport(repo)}
 
-	// server
-	server := httpserver.InitServer(cfg, logger, uc)
+	// фильтр комментариев: отказ с кодом правила или очередь модерации
+	if fc := cfg.Comments.Filter; fc.Enabled {
+		var rules []commentfilter.Rule
+		if fc.MaxLength > 0 {
+			rules = append(rules, commentfilter.MaxLength(fc.MaxLength))
+		}
+		if len(fc.BannedWords) > 0 {
+			rules = append(rules, commentfilter.BannedWords(fc.BannedWords,
+				commentfilter.ParseAction(fc.BannedWordsAction, commentfilter.ActionModerate)))
+		}
+		rules = append(rules, commentfilter.LinkLimit(fc.MaxLinks,
+			commentfilter.ParseAction(fc.LinksAction, commentfilter.ActionModerate)))
+		// повторы — последним: счётчик в Redis растёт только у прошедших остальные правила
+		if fc.RepeatWindow > 0 {
+			rules = append(rules, commentfilter.RepeatedText(redisClient, fc.RepeatWindow, fc.MaxRepeats,
+				commentfilter.ParseAction(fc.RepeatAction, commentfilter.ActionReject)))
+		}
+		ucOpts = append(ucOpts, usecase.WithCommentFilter(commentfilter.New(logger, rules...)))
+	}
 
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	// доменные события: usecase пишет их в outbox в транзакции с основной записью,
+	// relay переносит в Kafka (топик по агрегату)
+	if len(cfg.Kafka.Brokers) > 0 && cfg.Kafka.Outbox.Enabled {
+		ucOpts = append(ucOpts, usecase.WithEvents(repo))
+
+		eventProducer := kafka.NewEventProducer(&cfg.Kafka, logger)
+		lc.Add(lifecycle.Component{Name: "kafka_event_producer", Stop: lifecycle.Closer(eventProducer.Close),
+			StopTimeout: cfg.Shutdown.KafkaTimeout})
+
+		relay := outbox.New(repo, eventProducer, logger, cfg.Kafka.Outbox.PollInterval, cfg.Kafka.Outbox.BatchSize)
+		lc.Add(lifecycle.Component{Name: "outbox_relay", Run: lifecycle.Loop(relay.Run)})
+	}
 
-	go func() {
-		logger.Info("starting server", zap.String("port", cfg.HttpInfo.Port))
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Error("Server error", zap.Error(err))
-			os.Exit(1)
+	// вебхуки: доставки ставятся в очередь в той же транзакции, что и запись
+	if cfg.Webhooks.Enabled {
+		// одна проверка адресов и при создании подписки, и при каждом соединении диспетчера
+		guard := webhook.NewGuard(cfg.Webhooks.AllowPrivateDestinations)
+		if cfg.Webhooks.AllowPrivateDestinations {
+			logger.Warn("webhook destinations in private networks are allowed; do not use in production")
 		}
-	}()
+		ucOpts = append(ucOpts, usecase.WithWebhooks(repo, guard))
 
-	<-stop
-	logger.Info("Shutting down server...")
+		wh := cfg.Webhooks
+		dispatcher := webhook.New(repo, logger,
+			webhook.PollInterval(wh.PollInterval),
+			webhook.BatchSize(wh.BatchSize, wh.Concurrency),
+			webhook.RequestTimeout(wh.RequestTimeout),
+			webhook.Backoff(wh.MaxAttempts, wh.BackoffBase, wh.BackoffMax),
+			webhook.DisableAfter(wh.DisableAfter),
+			webhook.Destinations(guard),
+		)
+		lc.Add(lifecycle.Component{Name: "webhook_dispatcher", Run: lifecycle.Loop(dispatcher.Run)})
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	// живая лента комментариев: одна PSUBSCRIBE на реплику, fan-out SSE-клиентам в памяти
+	if cfg.Comments.StreamEnabled {
+		commentHub := redisClient.NewHub("comments:", cfg.Comments.SubscriberBuffer)
+		ucOpts = append(ucOpts, usecase.WithCommentFeed(redis_build.NewCommentFeed(commentHub), repo))
+		lc.Add(lifecycle.Component{Name: "comment_hub", Run: lifecycle.Loop(commentHub.Run)})
+	}
 
-	// Завершаем работу сервера
-	if err := server.Shutdown(ctx); err != nil {
-		logger.Error("Server shutdown error", zap.Error(err))
-	} else {
-		logger.Info("Server gracefully stopped")
+	// живые рейтинги: реплика, применившая обновление из Kafka, раздаёт его через Redis всем остальным
+	var serverOpts []httpserver.Option
+	if cfg.Ratings.Enabled {
+		// подписчик у хаба один (broadcaster), буфер с запасом на время перечитывания топа
+		ratingHub := redisClient.NewHub("ratings:", 1024)
+		ratingFeed := redis_build.NewRatingFeed(ratingHub)
+		ucOpts = append(ucOpts, usecase.WithRatingFeed(ratingFeed))
+
+		rs := cfg.Ratings
+		broadcaster := liverating.New(ratingService, ratingFeed, logger,
+			liverating.SendBuffer(rs.SendBuffer),
+			liverating.Limits(rs.MaxGames, rs.MaxTop),
+			liverating.TopRefresh(rs.TopRefresh),
+		)
+		serverOpts = append(serverOpts, httpserver.WithRatingStream(broadcaster))
+		lc.Add(lifecycle.Component{Name: "rating_hub", Run: lifecycle.Loop(ratingHub.Run)})
+		lc.Add(lifecycle.Component{Name: "rating_broadcaster", Run: lifecycle.Loop(broadcaster.Run)})
 	}
 
+	// rate limit: состояние в Redis, общее для реплик за nginx
+	if rl := cfg.RateLimit; rl.Enabled {
+		limitOpts := []middleware_ratelimit.Option{
+			middleware_ratelimit.TrustProxy(rl.TrustProxy),
+			middleware_ratelimit.FailOpen(rl.FailOpen),
+			middleware_ratelimit.PerIPFactor(rl.PerIPFactor),
+		}
+		for _, g := range []struct{ group, limit, by string }{
+			{"comments", rl.Comments, rl.CommentsBy},
+			{"ratings", rl.Ratings, rl.RatingsBy},
+			{"reports", rl.Reports, rl.ReportsBy},
+			{"writes", rl.Writes, rl.WritesBy},
+			{"reads", rl.Reads, rl.ReadsBy},
+			{"exports", rl.Exports, rl.ExportsBy},
+		} {
+			if g.limit == "off" {
+				continue
+			}
+			limit, err := middleware_ratelimit.ParseLimit(g.limit)
+			if err != nil {
+				logger.Error("invalid rate limit", zap.String("group", g.group), zap.Error(err))
+				return err
+			}
+			limitOpts = append(limitOpts, middleware_ratelimit.WithRule(g.group,
+				middleware_ratelimit.Rule{Limit: limit, By: g.by}))
+		}
+		serverOpts = append(serverOpts,
+			httpserver.WithRateLimiter(middleware_ratelimit.New(redisClient, logger, limitOpts...)))
+	}
+
+	uc := usecase.New(ratingService, repo, logger, ratingProducer, redisClient, ucOpts...)
+
+	// kafka consumer: обновления агрегатов рейтинга от rating service
+	if len(cfg.Kafka.Brokers) > 0 && cfg.Kafka.TopicRatingUpdates != "" {
+		consumer := kafka.NewConsumer(&cfg.Kafka, cfg.Kafka.TopicRatingUpdates, logger)
+		lc.Add(lifecycle.Component{Name: "rating_updates_consumer", Run: func(ctx context.Context) error {
+			// reader закрывается после выхода из цикла: коммит последнего оффсета успевает уйти
+			defer consumer.Close()
+			if err := consumer.Run(ctx, ratingupdates.NewRatingUpdatesHandler(logger, uc)); err != nil && ctx.Err() == nil {
+				logger.Error("rating updates consumer stopped", zap.Error(err))
+			}
+			return nil
+		}})
+	}
+
+	// readiness: зависимости из health.non_critical при падении дают degraded, а не 503
+	critical := func(name string) bool { return !slices.Contains(cfg.Health.NonCritical, name) }
+	healthOpts := []health.Option{
+		health.Timeout(cfg.Health.Timeout),
+		health.WithCheck("postgres", critical("postgres"), pg.Pool.Ping),
+		health.WithCheck("redis", critical("redis"), redisClient.Ping),
+		health.WithCheck("rating_service", critical("rating_service"), ratingService.Ping),
+	}
+	if len(cfg.Kafka.Brokers) > 0 {
+		healthOpts = append(healthOpts, health.WithCheck("kafka", critical("kafka"),
+			func(ctx context.Context) error { return kafka.Ping(ctx, cfg.Kafka.Brokers) }))
+	}
+	serverOpts = append(serverOpts, httpserver.WithReadiness(health.New(logger, healthOpts...)),
+		httpserver.WithLogLevel(logLevel))
+
+	// server — последним: останавливается первым, пока воркеры и продьюсеры ещё работают
+	server := httpserver.InitServer(cfg, logger, uc, serverOpts...)
+	var listener net.Listener
+	lc.Add(lifecycle.Component{
+		Name: "http_server",
+		// порт занимается синхронно: ошибка bind — ошибка запуска, а не паника в горутине
+		Start: func(context.Context) (err error) {
+			addr := server.Addr
+			if addr == "" {
+				addr = ":http"
+			}
+			listener, err = net.Listen("tcp", addr)
+			return err
+		},
+		Run: func(context.Context) error {
+			logger.Info("starting server", zap.String("port", cfg.HttpInfo.Port))
+			if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		// дожидается активных запросов; SSE и WebSocket закрываются через RegisterOnShutdown
+		Stop:        server.Shutdown,
+		StopTimeout: cfg.Shutdown.HTTPTimeout,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := lc.Run(ctx); err != nil {
+		logger.Error("service stopped with error", zap.Error(err))
+		return err
+	}
 	logger.Info("Finishing programm")
+	return nil
 }
 
 // type RatingProducer struct {
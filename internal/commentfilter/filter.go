@@ -0,0 +1,89 @@
+// Package commentfilter проверяет комментарий до записи: правила отклоняют его
+// или отправляют на модерацию (статус pending).
+package commentfilter
+
+import (
+	"context"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	prom_metrics "github.com/RozmiDan/gameReviewHub/pkg/metrics"
+	"go.uber.org/zap"
+)
+
+// Action — что делать с комментарием, на котором сработало правило
+type Action string
+
+const (
+	ActionReject   Action = "reject"
+	ActionModerate Action = "moderate"
+)
+
+// ParseAction разбирает значение из конфига; неизвестное — def
+func ParseAction(s string, def Action) Action {
+	switch Action(s) {
+	case ActionReject, ActionModerate:
+		return Action(s)
+	default:
+		return def
+	}
+}
+
+// Outcome — результат правила; пустой Action — комментарий прошёл
+type Outcome struct {
+	Action  Action
+	Code    string
+	Message string
+}
+
+// Rule — одно правило фильтра
+type Rule interface {
+	Name() string
+	Check(ctx context.Context, c entity.CommentDraft) (Outcome, error)
+}
+
+// Filter прогоняет комментарий через все правила по порядку.
+// Первый reject останавливает проверку; moderate копятся, комментарий уходит в очередь модерации.
+// Ошибка правила (например, Redis недоступен) не блокирует комментарий — правило пропускается.
+type Filter struct {
+	rules  []Rule
+	logger *zap.Logger
+}
+
+func New(logger *zap.Logger, rules ...Rule) *Filter {
+	return &Filter{
+		rules:  rules,
+		logger: logger.With(zap.String("component", "commentfilter")),
+	}
+}
+
+func (f *Filter) Check(ctx context.Context, c entity.CommentDraft) (entity.FilterVerdict, error) {
+	var verdict entity.FilterVerdict
+	for _, rule := range f.rules {
+		out, err := rule.Check(ctx, c)
+		if err != nil {
+			f.logger.Warn("filter rule failed, skipped", zap.String("rule", rule.Name()), zap.Error(err))
+			continue
+		}
+		if out.Action == "" {
+			continue
+		}
+		observe(rule.Name(), out.Action)
+
+		if out.Action == ActionReject {
+			return entity.FilterVerdict{}, &entity.CommentRejection{
+				Rule:    rule.Name(),
+				Code:    out.Code,
+				Message: out.Message,
+			}
+		}
+		verdict.Moderate = true
+		verdict.Reasons = append(verdict.Reasons, out.Code)
+	}
+	return verdict, nil
+}
+
+func observe(rule string, action Action) {
+	if prom_metrics.CommentsFiltered != nil {
+		prom_metrics.CommentsFiltered.WithLabelValues(rule, string(action)).Inc()
+	}
+}
@@ -0,0 +1,137 @@
+package commentfilter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func draft(text string) entity.CommentDraft {
+	return entity.CommentDraft{GameID: "game-1", UserID: "user-1", Text: text}
+}
+
+func TestBannedWords_Leetspeak(t *testing.T) {
+	rule := BannedWords([]string{"noob", "Scam"}, ActionReject)
+
+	for _, text := range []string{
+		"what a noob",
+		"N00B detected",
+		"total n.o.o.b",
+		"s c a m alert",
+		"5c4m!!!",
+		"nooooob",
+	} {
+		out, err := rule.Check(context.Background(), draft(text))
+		require.NoError(t, err)
+		require.Equal(t, ActionReject, out.Action, text)
+		require.Equal(t, "banned_words", out.Code)
+	}
+
+	for _, text := range []string{
+		"great game",
+		"scampi for dinner",
+		"no ob here",
+		"nob",
+	} {
+		out, err := rule.Check(context.Background(), draft(text))
+		require.NoError(t, err)
+		require.Empty(t, out.Action, text)
+	}
+}
+
+func TestLinkLimit(t *testing.T) {
+	rule := LinkLimit(1, ActionModerate)
+
+	out, _ := rule.Check(context.Background(), draft("see https://example.com/review"))
+	require.Empty(t, out.Action)
+
+	out, _ = rule.Check(context.Background(), draft("buy at www.keys.shop and cheap-keys.com or http://x.y/z"))
+	require.Equal(t, ActionModerate, out.Action)
+	require.Equal(t, "too_many_links", out.Code)
+
+	// точки в обычном тексте ссылками не считаются
+	out, _ = rule.Check(context.Background(), draft("v1.2 is fine. e.g. node.js, really."))
+	require.Empty(t, out.Action)
+}
+
+type fakeCounter struct {
+	counts map[string]int64
+	err    error
+}
+
+func (f *fakeCounter) CountCommentRepeat(ctx context.Context, userID, textHash string, window time.Duration) (int64, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	f.counts[userID+":"+textHash]++
+	return f.counts[userID+":"+textHash], nil
+}
+
+func TestRepeatedText(t *testing.T) {
+	counter := &fakeCounter{counts: map[string]int64{}}
+	rule := RepeatedText(counter, time.Minute, 2, ActionReject)
+	ctx := context.Background()
+
+	for _, text := range []string{"Free keys here", "free   KEYS here!"} {
+		out, err := rule.Check(ctx, draft(text))
+		require.NoError(t, err)
+		require.Empty(t, out.Action)
+	}
+	out, err := rule.Check(ctx, draft("FR33 keys here"))
+	require.NoError(t, err)
+	require.Equal(t, ActionReject, out.Action)
+	require.Equal(t, "repeated_text", out.Code)
+
+	// другой пользователь со своим счётчиком
+	other := draft("free keys here")
+	other.UserID = "user-2"
+	out, err = rule.Check(ctx, other)
+	require.NoError(t, err)
+	require.Empty(t, out.Action)
+}
+
+func TestMaxLength_CountsRunes(t *testing.T) {
+	rule := MaxLength(5)
+
+	out, _ := rule.Check(context.Background(), draft("привет"))
+	require.Equal(t, ActionReject, out.Action)
+	require.Equal(t, "comment_too_long", out.Code)
+
+	out, _ = rule.Check(context.Background(), draft("пока!"))
+	require.Empty(t, out.Action)
+}
+
+func TestFilter_Pipeline(t *testing.T) {
+	counter := &fakeCounter{counts: map[string]int64{}, err: errors.New("redis down")}
+	f := New(zap.NewNop(),
+		MaxLength(100),
+		BannedWords([]string{"scam"}, ActionModerate),
+		LinkLimit(0, ActionModerate),
+		RepeatedText(counter, time.Minute, 0, ActionReject),
+	)
+
+	// Redis недоступен — правило повторов пропускается, комментарий проходит
+	verdict, err := f.Check(context.Background(), draft("nice game"))
+	require.NoError(t, err)
+	require.False(t, verdict.Moderate)
+
+	// два мягких правила — на модерацию с обеими причинами
+	verdict, err = f.Check(context.Background(), draft("scam at scam.com"))
+	require.NoError(t, err)
+	require.True(t, verdict.Moderate)
+	require.Equal(t, []string{"banned_words", "too_many_links"}, verdict.Reasons)
+
+	// reject важнее moderate
+	_, err = f.Check(context.Background(), draft("scam "+strings.Repeat("a", 100)))
+	require.ErrorIs(t, err, entity.ErrCommentRejected)
+	var rej *entity.CommentRejection
+	require.True(t, errors.As(err, &rej))
+	require.Equal(t, "comment_too_long", rej.Code)
+	require.Equal(t, "max_length", rej.Rule)
+}
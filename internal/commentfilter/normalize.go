@@ -0,0 +1,88 @@
+package commentfilter
+
+import (
+	"strings"
+	"unicode"
+)
+
+// замены leetspeak: цифры и символы, которыми пишут буквы
+var leet = map[rune]rune{
+	'0': 'o',
+	'1': 'i',
+	'|': 'i',
+	'3': 'e',
+	'4': 'a',
+	'@': 'a',
+	'5': 's',
+	'$': 's',
+	'7': 't',
+	'+': 't',
+	'8': 'b',
+	'9': 'g',
+}
+
+// normalizeWords приводит текст к словам для сравнения со стоп-листом:
+// нижний регистр, leetspeak → буквы, разделители выбрасываются.
+// Подряд идущие однобуквенные слова склеиваются ("b.a.d", "b a d" → "bad").
+func normalizeWords(text string) []string {
+	var (
+		words   []string
+		cur     strings.Builder
+		singles strings.Builder
+	)
+	flushSingles := func() {
+		if singles.Len() > 1 {
+			words = append(words, singles.String())
+		}
+		singles.Reset()
+	}
+	flush := func() {
+		if cur.Len() == 0 {
+			return
+		}
+		w := cur.String()
+		cur.Reset()
+		if len([]rune(w)) == 1 {
+			singles.WriteString(w)
+			words = append(words, w)
+			return
+		}
+		flushSingles()
+		words = append(words, w)
+	}
+
+	for _, r := range strings.ToLower(text) {
+		if m, ok := leet[r]; ok {
+			r = m
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			cur.WriteRune(r)
+			continue
+		}
+		flush()
+	}
+	flush()
+	flushSingles()
+	return words
+}
+
+// squeeze убирает повторы букв: "baaad" → "bad"
+func squeeze(w string) string {
+	var (
+		b    strings.Builder
+		prev rune
+	)
+	for i, r := range w {
+		if i > 0 && r == prev {
+			continue
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	return b.String()
+}
+
+// normalizeText — для поиска повторов: регистр, leetspeak и пробелы не важны
+func normalizeText(text string) string {
+	return strings.Join(normalizeWords(text), " ")
+}
@@ -0,0 +1,148 @@
+package commentfilter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+)
+
+// ---------------- стоп-слова ----------------
+
+type bannedWords struct {
+	words    map[string]struct{}
+	squeezed map[string]struct{}
+	action   Action
+}
+
+// BannedWords — стоп-лист; слова и текст сравниваются после нормализации leetspeak,
+// так что "b4d", "B.A.D" и "baaad" ловятся словом "bad"
+func BannedWords(words []string, action Action) Rule {
+	r := &bannedWords{
+		words:    make(map[string]struct{}, len(words)),
+		squeezed: make(map[string]struct{}, len(words)),
+		action:   action,
+	}
+	for _, w := range words {
+		for _, n := range normalizeWords(w) {
+			r.words[n] = struct{}{}
+			r.squeezed[squeeze(n)] = struct{}{}
+		}
+	}
+	return r
+}
+
+func (r *bannedWords) Name() string { return "banned_words" }
+
+func (r *bannedWords) Check(_ context.Context, c entity.CommentDraft) (Outcome, error) {
+	for _, w := range normalizeWords(c.Text) {
+		if _, ok := r.words[w]; ok {
+			return r.hit(), nil
+		}
+		// растянутые буквы сверяем только у слов, где они есть, — иначе "as" совпадёт с "ass"
+		if s := squeeze(w); s != w {
+			if _, ok := r.squeezed[s]; ok {
+				return r.hit(), nil
+			}
+		}
+	}
+	return Outcome{}, nil
+}
+
+func (r *bannedWords) hit() Outcome {
+	return Outcome{Action: r.action, Code: "banned_words", Message: "comment contains prohibited words"}
+}
+
+// ---------------- ссылки ----------------
+
+var linkRe = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.(?:com|net|org|ru|io|ly|gg|me|xyz|info|biz|to|cc)\b`)
+
+type linkLimit struct {
+	max    int
+	action Action
+}
+
+// LinkLimit — не больше max ссылок в комментарии
+func LinkLimit(max int, action Action) Rule {
+	return &linkLimit{max: max, action: action}
+}
+
+func (r *linkLimit) Name() string { return "link_limit" }
+
+func (r *linkLimit) Check(_ context.Context, c entity.CommentDraft) (Outcome, error) {
+	if n := len(linkRe.FindAllStringIndex(c.Text, -1)); n > r.max {
+		return Outcome{
+			Action:  r.action,
+			Code:    "too_many_links",
+			Message: fmt.Sprintf("comment may contain at most %d links", r.max),
+		}, nil
+	}
+	return Outcome{}, nil
+}
+
+// ---------------- повторы ----------------
+
+// RepeatCounter считает, сколько раз пользователь отправил тот же текст за окно
+type RepeatCounter interface {
+	CountCommentRepeat(ctx context.Context, userID, textHash string, window time.Duration) (int64, error)
+}
+
+type repeatedText struct {
+	counter    RepeatCounter
+	window     time.Duration
+	maxRepeats int64
+	action     Action
+}
+
+// RepeatedText срабатывает, когда пользователь шлёт один и тот же текст (после нормализации)
+// больше maxRepeats раз за window — в любые игры
+func RepeatedText(counter RepeatCounter, window time.Duration, maxRepeats int, action Action) Rule {
+	return &repeatedText{counter: counter, window: window, maxRepeats: int64(maxRepeats), action: action}
+}
+
+func (r *repeatedText) Name() string { return "repeated_text" }
+
+func (r *repeatedText) Check(ctx context.Context, c entity.CommentDraft) (Outcome, error) {
+	sum := sha256.Sum256([]byte(normalizeText(c.Text)))
+	n, err := r.counter.CountCommentRepeat(ctx, c.UserID, hex.EncodeToString(sum[:16]), r.window)
+	if err != nil {
+		return Outcome{}, err
+	}
+	if n > r.maxRepeats {
+		return Outcome{
+			Action:  r.action,
+			Code:    "repeated_text",
+			Message: "the same comment was posted too many times, try again later",
+		}, nil
+	}
+	return Outcome{}, nil
+}
+
+// ---------------- длина ----------------
+
+type maxLength struct {
+	max int
+}
+
+// MaxLength — не больше max символов (не байт)
+func MaxLength(max int) Rule {
+	return &maxLength{max: max}
+}
+
+func (r *maxLength) Name() string { return "max_length" }
+
+func (r *maxLength) Check(_ context.Context, c entity.CommentDraft) (Outcome, error) {
+	if utf8.RuneCountInString(c.Text) > r.max {
+		return Outcome{
+			Action:  ActionReject,
+			Code:    "comment_too_long",
+			Message: fmt.Sprintf("comment must be at most %d characters", r.max),
+		}, nil
+	}
+	return Outcome{}, nil
+}
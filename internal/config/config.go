@@ -17,6 +17,15 @@ type (
 		GrpcInfo   grpcStruct  `yaml:"grpc"`
 		Kafka      KafkaConfig `yaml:"kafka"`
 		Redis      RedisConfig `yaml:"redis"`
+		Webhooks   webhooks    `yaml:"webhooks"`
+		Comments   comments    `yaml:"comments"`
+		Ratings    ratings     `yaml:"ratings_stream"`
+		Admin      admin       `yaml:"admin"`
+		RateLimit  rateLimit   `yaml:"rate_limit"`
+		Health     healthCheck `yaml:"health"`
+		Tracing    tracing     `yaml:"tracing"`
+		Logging    logging     `yaml:"logging"`
+		Shutdown   shutdown    `yaml:"shutdown"`
 	}
 
 	appStruct struct {
@@ -25,8 +34,40 @@ type (
 	}
 
 	grpcStruct struct {
-		Address string        `yaml:"address" env-default:"50051"`
-		Timeout time.Duration `yaml:"timeout" env-default:"1s"`
+		Address        string                   `yaml:"address" env-default:"50051"`
+		Addresses      []string                 `yaml:"addresses" env-separator:","`
+		HealthCheck    bool                     `yaml:"health_check" env-default:"true"`
+		HealthService  string                   `yaml:"health_service" env-default:""`
+		Timeout        time.Duration            `yaml:"timeout" env-default:"1s"`
+		CallTimeout    time.Duration            `yaml:"call_timeout" env-default:"1s"`
+		MethodTimeouts map[string]time.Duration `yaml:"method_timeouts"`
+		Retry          grpcRetry                `yaml:"retry"`
+		Breaker        grpcBreaker              `yaml:"breaker"`
+		TLS            grpcTLS                  `yaml:"tls"`
+		Fake           bool                     `yaml:"fake" env:"FAKE_RATING" env-default:"false"`
+	}
+
+	// grpcTLS — TLS/mTLS до rating service; файлы перечитываются при изменении
+	grpcTLS struct {
+		Enabled    bool   `yaml:"enabled" env:"GRPC_TLS_ENABLED" env-default:"false"`
+		CAFile     string `yaml:"ca_file" env:"GRPC_TLS_CA_FILE"`
+		CertFile   string `yaml:"cert_file" env:"GRPC_TLS_CERT_FILE"`
+		KeyFile    string `yaml:"key_file" env:"GRPC_TLS_KEY_FILE"`
+		ServerName string `yaml:"server_name" env:"GRPC_TLS_SERVER_NAME"`
+	}
+
+	// grpcRetry — повторы вызовов при codes.Unavailable
+	grpcRetry struct {
+		MaxAttempts uint          `yaml:"max_attempts" env-default:"3"`
+		Backoff     time.Duration `yaml:"backoff" env-default:"50ms"`
+		Jitter      float64       `yaml:"jitter" env-default:"0.2"`
+	}
+
+	// grpcBreaker — параметры circuit breaker'а
+	grpcBreaker struct {
+		FailureThreshold uint32        `yaml:"failure_threshold" env-default:"5"`
+		OpenTimeout      time.Duration `yaml:"open_timeout" env-default:"10s"`
+		HalfOpenRequests uint32        `yaml:"half_open_requests" env-default:"1"`
 	}
 
 	httpStruct struct {
@@ -43,14 +84,173 @@ type (
 		User      string `yaml:"user" env-required:"true"`
 		Password  string `yaml:"password" env-required:"true"`
 		PgPoolMax uint16 `yaml:"pg_pool_max" env-required:"true"`
+		PgPoolMin uint16 `yaml:"pg_pool_min" env:"PG_POOL_MIN" env-default:"0"`
+		// 0 — значения pgxpool по умолчанию (1h, 30m, 1m)
+		MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime" env:"PG_MAX_CONN_LIFETIME" env-default:"1h"`
+		MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time" env:"PG_MAX_CONN_IDLE_TIME" env-default:"30m"`
+		HealthCheckPeriod time.Duration `yaml:"health_check_period" env:"PG_HEALTH_CHECK_PERIOD" env-default:"1m"`
+		// запросы дольше порога пишутся в лог с SQL и длительностью; 0 — выключено
+		SlowQueryThreshold time.Duration `yaml:"slow_query_threshold" env:"PG_SLOW_QUERY_THRESHOLD" env-default:"200ms"`
+		// миграции при старте (под advisory lock); выключается, когда схему накатывает cmd/migrate перед деплоем
+		AutoMigrate bool `yaml:"auto_migrate" env:"PG_AUTO_MIGRATE" env-default:"true"`
 	}
 
 	KafkaConfig struct {
-		Brokers      []string      `yaml:"brokers"`
-		TopicRatings string        `yaml:"topic_ratings"`
-		DialTimeout  time.Duration `yaml:"dial_timeout"`
-		WriteTimeout time.Duration `yaml:"write_timeout"`
+		Brokers            []string      `yaml:"brokers"`
+		TopicRatings       string        `yaml:"topic_ratings"`
+		TopicRatingUpdates string        `yaml:"topic_rating_updates" env-default:"rating-updates"`
+		TopicGameEvents    string        `yaml:"topic_game_events" env-default:"game-events"`
+		TopicCommentEvents string        `yaml:"topic_comment_events" env-default:"comment-events"`
+		ConsumerGroup      string        `yaml:"consumer_group" env-default:"main-service"`
+		RatingEncoding     string        `yaml:"rating_encoding" env:"KAFKA_RATING_ENCODING" env-default:"json"`
+		DialTimeout        time.Duration `yaml:"dial_timeout" env-default:"5s"`
+		WriteTimeout       time.Duration `yaml:"write_timeout" env-default:"10s"`
+		Producer           kafkaProducer `yaml:"producer"`
+		Outbox             kafkaOutbox   `yaml:"outbox"`
+	}
+
+	// kafkaOutbox — relay доменных событий из таблицы event_outbox в Kafka
+	kafkaOutbox struct {
+		Enabled      bool          `yaml:"enabled" env:"KAFKA_OUTBOX_ENABLED" env-default:"true"`
+		PollInterval time.Duration `yaml:"poll_interval" env-default:"1s"`
+		BatchSize    int           `yaml:"batch_size" env-default:"100"`
+	}
+
+	// kafka-go не умеет идемпотентного продьюсера: повторы + event-id в заголовке,
+	// по которому консьюмеры отбрасывают дубли
+	kafkaProducer struct {
+		Acks            string        `yaml:"acks" env:"KAFKA_PRODUCER_ACKS" env-default:"all"`                  // none | one | all
+		Compression     string        `yaml:"compression" env:"KAFKA_PRODUCER_COMPRESSION" env-default:"snappy"` // none | gzip | snappy | lz4 | zstd
+		Async           bool          `yaml:"async" env:"KAFKA_PRODUCER_ASYNC" env-default:"true"`
+		BatchSize       int           `yaml:"batch_size" env-default:"100"`
+		BatchBytes      int64         `yaml:"batch_bytes" env-default:"1048576"`
+		BatchTimeout    time.Duration `yaml:"batch_timeout" env-default:"10ms"`
+		MaxAttempts     int           `yaml:"max_attempts" env-default:"5"`
+		RetryBackoffMin time.Duration `yaml:"retry_backoff_min" env-default:"100ms"`
+		RetryBackoffMax time.Duration `yaml:"retry_backoff_max" env-default:"1s"`
+	}
+	// webhooks — подписки партнёров и фоновая доставка
+	webhooks struct {
+		Enabled        bool          `yaml:"enabled" env:"WEBHOOKS_ENABLED" env-default:"true"`
+		PollInterval   time.Duration `yaml:"poll_interval" env-default:"1s"`
+		BatchSize      int           `yaml:"batch_size" env-default:"50"`
+		Concurrency    int           `yaml:"concurrency" env-default:"4"`
+		RequestTimeout time.Duration `yaml:"request_timeout" env-default:"5s"`
+		MaxAttempts    int           `yaml:"max_attempts" env-default:"8"`
+		BackoffBase    time.Duration `yaml:"backoff_base" env-default:"10s"`
+		BackoffMax     time.Duration `yaml:"backoff_max" env-default:"1h"`
+		DisableAfter   int           `yaml:"disable_after" env-default:"15"`
+		// партнёры: имя → Bearer-токен для /webhooks; пусто — API подписок недоступен никому
+		Partners map[string]string `yaml:"partners" env:"WEBHOOK_PARTNERS" env-separator:","`
+		// получатели во внутренней сети (127.0.0.1, 10.x, compose-сервисы) — только для локальной разработки
+		AllowPrivateDestinations bool `yaml:"allow_private_destinations" env:"WEBHOOKS_ALLOW_PRIVATE_DESTINATIONS" env-default:"false"`
+	}
+
+	// comments — SSE-лента новых комментариев, fan-out между репликами через Redis Pub/Sub
+	comments struct {
+		StreamEnabled    bool          `yaml:"stream_enabled" env:"COMMENTS_STREAM_ENABLED" env-default:"true"`
+		StreamHeartbeat  time.Duration `yaml:"stream_heartbeat" env-default:"15s"`
+		SubscriberBuffer int           `yaml:"subscriber_buffer" env-default:"32"`
+		ReportThreshold  int           `yaml:"report_threshold" env:"COMMENTS_REPORT_THRESHOLD" env-default:"5"` // жалоб до автоскрытия; 0 — не скрывать
+		Filter           commentFilter `yaml:"filter"`
+	}
+
+	// commentFilter — правила фильтра комментариев; action: reject — отказ, moderate — в очередь модерации
+	commentFilter struct {
+		Enabled           bool          `yaml:"enabled" env:"COMMENTS_FILTER_ENABLED" env-default:"true"`
+		MaxLength         int           `yaml:"max_length" env-default:"1000"`
+		BannedWords       []string      `yaml:"banned_words" env:"COMMENTS_BANNED_WORDS" env-separator:","`
+		BannedWordsAction string        `yaml:"banned_words_action" env-default:"moderate"`
+		MaxLinks          int           `yaml:"max_links" env-default:"2"`
+		LinksAction       string        `yaml:"links_action" env-default:"moderate"`
+		RepeatWindow      time.Duration `yaml:"repeat_window" env-default:"10m"`
+		MaxRepeats        int           `yaml:"max_repeats" env-default:"2"`
+		RepeatAction      string        `yaml:"repeat_action" env-default:"reject"`
+	}
+
+	// ratings — WebSocket с обновлениями рейтингов для главной страницы
+	ratings struct {
+		Enabled        bool          `yaml:"enabled" env:"RATINGS_STREAM_ENABLED" env-default:"true"`
+		SendBuffer     int           `yaml:"send_buffer" env-default:"64"`
+		MaxGames       int           `yaml:"max_games" env-default:"50"`
+		MaxTop         int           `yaml:"max_top" env-default:"100"`
+		TopRefresh     time.Duration `yaml:"top_refresh" env-default:"30s"`
+		PingInterval   time.Duration `yaml:"ping_interval" env-default:"30s"`
+		WriteTimeout   time.Duration `yaml:"write_timeout" env-default:"5s"`
+		AllowedOrigins []string      `yaml:"allowed_origins" env:"RATINGS_STREAM_ALLOWED_ORIGINS" env-separator:","`
 	}
+
+	// admin — модераторы: имя → Bearer-токен; пусто — админские маршруты недоступны никому
+	admin struct {
+		Moderators map[string]string `yaml:"moderators" env:"ADMIN_MODERATORS" env-separator:","`
+	}
+
+	// rateLimit — лимиты запросов в Redis, общие для всех реплик; формат "N/период".
+	// "off" — группа не ограничивается. by: user | api_key | ip
+	rateLimit struct {
+		Enabled     bool   `yaml:"enabled" env:"RATE_LIMIT_ENABLED" env-default:"true"`
+		TrustProxy  bool   `yaml:"trust_proxy" env:"RATE_LIMIT_TRUST_PROXY" env-default:"true"`  // IP из X-Forwarded-For (за nginx)
+		FailOpen    bool   `yaml:"fail_open" env-default:"true"`                                 // Redis недоступен — пропускать
+		PerIPFactor int    `yaml:"per_ip_factor" env:"RATE_LIMIT_PER_IP_FACTOR" env-default:"5"` // лимит на IP = лимит пользователя/ключа × N
+		Comments    string `yaml:"comments" env:"RATE_LIMIT_COMMENTS" env-default:"10/1m"`
+		CommentsBy  string `yaml:"comments_by" env-default:"user"`
+		Ratings     string `yaml:"ratings" env:"RATE_LIMIT_RATINGS" env-default:"20/1m"`
+		RatingsBy   string `yaml:"ratings_by" env-default:"user"`
+		Reports     string `yaml:"reports" env:"RATE_LIMIT_REPORTS" env-default:"10/1h"`
+		ReportsBy   string `yaml:"reports_by" env-default:"user"`
+		Writes      string `yaml:"writes" env:"RATE_LIMIT_WRITES" env-default:"30/1m"` // POST /games, управление вебхуками
+		WritesBy    string `yaml:"writes_by" env-default:"api_key"`
+		Reads       string `yaml:"reads" env:"RATE_LIMIT_READS" env-default:"300/1m"`
+		ReadsBy     string `yaml:"reads_by" env-default:"ip"`
+		Exports     string `yaml:"exports" env:"RATE_LIMIT_EXPORTS" env-default:"10/1h"` // выгрузки каталога и комментариев
+		ExportsBy   string `yaml:"exports_by" env-default:"api_key"`
+	}
+
+	// healthCheck — /readyz: таймаут одной проверки и зависимости, без которых реплика остаётся ready
+	healthCheck struct {
+		Timeout     time.Duration `yaml:"timeout" env:"HEALTH_TIMEOUT" env-default:"1s"`
+		NonCritical []string      `yaml:"non_critical" env:"HEALTH_NON_CRITICAL" env-separator:"," env-default:"redis,kafka"` // postgres | redis | kafka | rating_service
+	}
+
+	// tracing — OpenTelemetry; exporter: none | otlp | stdout | file
+	tracing struct {
+		Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"none"`
+		Endpoint    string  `yaml:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT" env-default:"localhost:4317"` // OTLP/gRPC
+		Insecure    bool    `yaml:"insecure" env:"TRACING_INSECURE" env-default:"true"`
+		File        string  `yaml:"file" env:"TRACING_FILE" env-default:"logs/traces.json"`
+		SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
+	}
+
+	// logging — куда и как пишутся логи; уровень потом меняется через PUT /admin/log-level
+	logging struct {
+		Level    string      `yaml:"level" env:"LOG_LEVEL"`                      // debug | info | warn | error; пусто — debug для local, иначе info
+		Format   string      `yaml:"format" env:"LOG_FORMAT" env-default:"json"` // json | console
+		Outputs  []string    `yaml:"outputs" env:"LOG_OUTPUTS" env-separator:"," env-default:"stdout,./logs/go.log"`
+		Sampling logSampling `yaml:"sampling"`
+		Rotation logRotation `yaml:"rotation"`
+	}
+
+	// logSampling — в секунду первые Initial одинаковых сообщений, дальше каждое Thereafter-е; Initial: 0 — выключено
+	logSampling struct {
+		Initial    int `yaml:"initial" env:"LOG_SAMPLING_INITIAL" env-default:"100"`
+		Thereafter int `yaml:"thereafter" env:"LOG_SAMPLING_THEREAFTER" env-default:"100"`
+	}
+
+	// logRotation — ротация файловых выходов; MaxSizeMB: 0 — без ротации
+	logRotation struct {
+		MaxSizeMB  int  `yaml:"max_size_mb" env:"LOG_ROTATION_MAX_SIZE_MB" env-default:"100"`
+		MaxBackups int  `yaml:"max_backups" env:"LOG_ROTATION_MAX_BACKUPS" env-default:"5"`
+		MaxAgeDays int  `yaml:"max_age_days" env:"LOG_ROTATION_MAX_AGE_DAYS" env-default:"7"`
+		Compress   bool `yaml:"compress" env:"LOG_ROTATION_COMPRESS" env-default:"false"`
+	}
+
+	// shutdown — таймауты остановки по SIGTERM; в сумме должны укладываться в terminationGracePeriod
+	shutdown struct {
+		HTTPTimeout  time.Duration `yaml:"http_timeout" env:"SHUTDOWN_HTTP_TIMEOUT" env-default:"5s"`    // дождаться активных запросов
+		KafkaTimeout time.Duration `yaml:"kafka_timeout" env:"SHUTDOWN_KAFKA_TIMEOUT" env-default:"10s"` // отправить буфер продьюсеров
+		Timeout      time.Duration `yaml:"timeout" env:"SHUTDOWN_TIMEOUT" env-default:"5s"`              // остальные компоненты
+	}
+
 	RedisConfig struct {
 		RedisAddress  string `yaml:"addr_redis" env-default:"6379"`
 		RedisPassword string `yaml:"pass_redis" env-default:""`
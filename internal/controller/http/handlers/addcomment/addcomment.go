@@ -18,20 +18,24 @@ import (
 // POST /games/{game_id}/comments
 
 type CommentPoster interface {
-	AddComment(ctx context.Context, gameID, userID, text string) (string, error)
+	AddComment(ctx context.Context, gameID, userID, text string) (*entity.Comment, error)
 }
 
 // AddCommentHandler добавляет новый комментарий к игре.
 // @Summary     Постинг комментария
-// @Description Добавляет комментарий пользователя к указанной игре.
+// @Description Добавляет комментарий пользователя к указанной игре. Комментарий проверяется фильтром:
+// @Description при отказе возвращается 422 с кодом правила (banned_words, too_many_links, repeated_text, comment_too_long),
+// @Description сомнительный комментарий сохраняется со статусом pending (ответ 202) и появится после проверки модератором.
 // @Tags        comments
 // @Accept      json
 // @Produce     json
 // @Param       game_id   path     string             true  "UUID игры"
 // @Param       body      body     PostCommentRequest true  "Тело запроса с полем user_id и text"
 // @Success     200       {object} AddCommentResponse  "ID созданного комментария"
+// @Success     202       {object} AddCommentResponse  "Комментарий отправлен на модерацию"
 // @Failure     400       {object} ErrorResponse        "Некорректные входные данные"
 // @Failure     404       {object} ErrorResponse        "Игра не найдена"
+// @Failure     422       {object} ErrorResponse        "Комментарий отклонён фильтром"
 // @Failure     504       {object} ErrorResponse        "Таймаут запроса"
 // @Failure     500       {object} ErrorResponse        "Внутренняя ошибка сервера"
 // @Router      /games/{game_id}/comments [post]
@@ -89,18 +93,28 @@ func NewAddCommentHandler(baseLogger *zap.Logger, uc CommentPoster) http.Handler
 			})
 			return
 		}
-		if len(payload.Text) == 0 || len(payload.Text) > 1000 {
-			logger.Warn("invalid text length", zap.Int("text_size", len(payload.Text)))
+		// длину (в символах, а не байтах) проверяет правило max_length фильтра комментариев
+		if len(payload.Text) == 0 {
+			logger.Warn("empty comment text")
 			render.Status(r, http.StatusBadRequest)
 			render.JSON(w, r, ErrorResponse{
-				Error: APIError{"invalid_text", "comment size must be between 0 and 1000"},
+				Error: APIError{"invalid_text", "comment text must not be empty"},
 			})
 			return
 		}
 
 		// 6) Основная бизнес-логика
-		commentID, err := uc.AddComment(ctx, gameID, payload.UserID, payload.Text)
+		comment, err := uc.AddComment(ctx, gameID, payload.UserID, payload.Text)
+		var rejection *entity.CommentRejection
 		switch {
+		case errors.As(err, &rejection):
+			logger.Info("comment rejected", zap.String("rule", rejection.Rule), zap.String("user_id", payload.UserID))
+			render.Status(r, http.StatusUnprocessableEntity)
+			render.JSON(w, r, ErrorResponse{
+				Error: APIError{rejection.Code, rejection.Message},
+			})
+			return
+
 		case errors.Is(err, entity.ErrGameNotFound):
 			logger.Info("game not found", zap.String("game_id", gameID))
 			render.Status(r, http.StatusNotFound)
@@ -137,10 +151,15 @@ func NewAddCommentHandler(baseLogger *zap.Logger, uc CommentPoster) http.Handler
 			// если err == nil, продолжаем
 		}
 
-		// 7) Отдаем ID нового комментария
-		render.Status(r, http.StatusOK)
+		// 7) Отдаем ID нового комментария; pending — принят, но ещё не опубликован
+		status := http.StatusOK
+		if comment.Status == entity.CommentPending {
+			status = http.StatusAccepted
+		}
+		render.Status(r, status)
 		render.JSON(w, r, AddCommentResponse{
-			ID: commentID,
+			ID:     comment.ID,
+			Status: comment.Status,
 		})
 	}
 }
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	testGame = "11111111-1111-1111-1111-111111111111"
+	testUser = "22222222-2222-2222-2222-222222222222"
+)
+
+// fakePoster запоминает текст; err — ответ usecase
+type fakePoster struct {
+	text string
+	err  error
+}
+
+func (f *fakePoster) AddComment(ctx context.Context, gameID, userID, text string) (*entity.Comment, error) {
+	f.text = text
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &entity.Comment{ID: "c1", Status: entity.CommentVisible}, nil
+}
+
+func postComment(t *testing.T, uc CommentPoster, text string) (*httptest.ResponseRecorder, ErrorResponse) {
+	t.Helper()
+	r := chi.NewRouter()
+	r.Post("/games/{game_id}/comments", NewAddCommentHandler(zap.NewNop(), uc))
+
+	body, err := json.Marshal(PostCommentRequest{UserID: testUser, Text: text})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/games/"+testGame+"/comments", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	var resp ErrorResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	return rec, resp
+}
+
+func TestAddComment_LengthLeftToFilter(t *testing.T) {
+	// 900 кириллических символов — 1800 байт: длину считает фильтр, а не хендлер
+	text := strings.Repeat("ж", 900)
+	uc := &fakePoster{}
+	rec, _ := postComment(t, uc, text)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, text, uc.text)
+
+	// отказ правила max_length доходит до клиента как есть
+	uc = &fakePoster{err: &entity.CommentRejection{Rule: "max_length", Code: "comment_too_long", Message: "too long"}}
+	rec, resp := postComment(t, uc, strings.Repeat("ж", 1001))
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	require.Equal(t, "comment_too_long", resp.Error.Code)
+}
+
+func TestAddComment_EmptyText(t *testing.T) {
+	uc := &fakePoster{}
+	rec, resp := postComment(t, uc, "")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, "invalid_text", resp.Error.Code)
+	require.Empty(t, uc.text)
+}
@@ -7,7 +7,8 @@ type PostCommentRequest struct {
 }
 
 type AddCommentResponse struct {
-    ID string `json:"id"`
+    ID     string `json:"id"`
+    Status string `json:"status"` // visible | pending
 }
 
 // APIError — единая структура описания ошибки
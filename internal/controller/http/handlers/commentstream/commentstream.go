@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// GET /games/{game_id}/comments/stream
+
+// через сколько браузер переподключится после обрыва
+const _retryMillis = 3000
+
+type CommentStreamer interface {
+	StreamComments(ctx context.Context, gameID, lastEventID string) (<-chan entity.Comment, error)
+}
+
+// CommentStreamHandler отдаёт новые комментарии игры через Server-Sent Events.
+// @Summary     Живая лента комментариев
+// @Description SSE-поток: каждое событие comment содержит комментарий в JSON, id события — id комментария.
+// @Description При переподключении браузер присылает Last-Event-ID, и пропущенные комментарии досылаются.
+// @Description Для первого подключения тот же курсор можно передать в query last_event_id.
+// @Tags        comments
+// @Produce     text/event-stream
+// @Param       game_id        path   string  true  "UUID игры"
+// @Param       Last-Event-ID  header string  false "id последнего полученного комментария"
+// @Param       last_event_id  query  string  false "то же, что Last-Event-ID"
+// @Success     200  {string}  string        "Поток событий"
+// @Failure     400  {object}  ErrorResponse "Неверный game_id"
+// @Failure     404  {object}  ErrorResponse "Игра не найдена"
+// @Failure     503  {object}  ErrorResponse "Лента отключена"
+// @Failure     500  {object}  ErrorResponse "Внутренняя ошибка сервера"
+// @Router      /games/{game_id}/comments/stream [get]
+func NewCommentStreamHandler(baseLogger *zap.Logger, uc CommentStreamer, heartbeat time.Duration,
+	shutdown <-chan struct{}) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 1) request_id; без таймаута — поток живёт, пока клиент подключён
+		reqID := middleware.GetReqID(r.Context())
+		ctx := context.WithValue(r.Context(), entity.RequestIDKey{}, reqID)
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		// 2) оборачиваем логгер
+		logger := baseLogger.With(zap.String("handler", "CommentStreamHandler"), zap.String("request_id", reqID))
+
+		// 3) валидируем game_id и курсор
+		gameID := chi.URLParam(r, "game_id")
+		if _, err := uuid.Parse(gameID); err != nil {
+			logger.Warn("invalid game_id", zap.String("game_id", gameID), zap.Error(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, ErrorResponse{
+				Error: APIError{"invalid_game_id", "game_id must be a valid UUID"},
+			})
+			return
+		}
+
+		lastEventID := r.Header.Get("Last-Event-ID")
+		if lastEventID == "" {
+			lastEventID = r.URL.Query().Get("last_event_id")
+		}
+		if lastEventID != "" {
+			if _, err := uuid.Parse(lastEventID); err != nil {
+				logger.Warn("invalid Last-Event-ID, ignored", zap.String("last_event_id", lastEventID))
+				lastEventID = ""
+			}
+		}
+
+		// 4) подписка
+		comments, err := uc.StreamComments(ctx, gameID, lastEventID)
+		if err != nil {
+			switch {
+			case errors.Is(err, entity.ErrGameNotFound):
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, ErrorResponse{
+					Error: APIError{"game_not_found", "game not found"},
+				})
+			case errors.Is(err, entity.ErrStreamDisabled):
+				render.Status(r, http.StatusServiceUnavailable)
+				render.JSON(w, r, ErrorResponse{
+					Error: APIError{"stream_disabled", "comment stream is disabled"},
+				})
+			default:
+				logger.Error("cannot start comment stream", zap.Error(err))
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, ErrorResponse{
+					Error: APIError{"internal_error", "could not start comment stream"},
+				})
+			}
+			return
+		}
+
+		// 5) WriteTimeout сервера рассчитан на обычные запросы — для потока снимаем дедлайн
+		rc := http.NewResponseController(w)
+		if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+			logger.Warn("cannot reset write deadline, stream may be cut by server timeout", zap.Error(err))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		// nginx не должен буферизовать поток
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		if _, err := fmt.Fprintf(w, "retry: %d\n\n", _retryMillis); err != nil {
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			logger.Error("response writer does not support flushing", zap.Error(err))
+			return
+		}
+		logger.Info("comment stream opened", zap.String("game_id", gameID), zap.String("last_event_id", lastEventID))
+
+		// 6) пишем события; heartbeat держит соединение через прокси и замечает ушедших клиентов
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("client disconnected")
+				return
+
+			case <-shutdown:
+				logger.Info("server shutting down, closing stream")
+				return
+
+			case <-ticker.C:
+				if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+					return
+				}
+
+			case c, ok := <-comments:
+				if !ok {
+					// отстали или догрузка не удалась — клиент переподключится с Last-Event-ID
+					logger.Info("comment stream closed by server")
+					return
+				}
+				if err := writeEvent(w, c); err != nil {
+					logger.Info("failed to write event", zap.Error(err))
+					return
+				}
+			}
+
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, c entity.Comment) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: comment\ndata: %s\n\n", c.ID, data)
+	return err
+}
@@ -0,0 +1,14 @@
+package handlers
+
+// --------------- ответы с ошибкой ---------------
+
+// APIError — структура описания ошибки
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse — обёртка для не-200 ответов
+type ErrorResponse struct {
+	Error APIError `json:"error"`
+}
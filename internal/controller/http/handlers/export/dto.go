@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+)
+
+// ExportGame — строка выгрузки GET /games/export (и NDJSON-объект, и строка CSV).
+// Рейтинг null (в CSV — пустые ячейки) — неизвестен: у игры нет снапшота рейтинга
+type ExportGame struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Genre         string   `json:"genre"`
+	Creator       string   `json:"creator"`
+	Description   string   `json:"description"`
+	ReleaseDate   string   `json:"release_date"`
+	AverageRating *float64 `json:"average_rating"` // null — неизвестен
+	RatingsCount  *int64   `json:"ratings_count"`  // null — неизвестен
+}
+
+var gameColumns = []string{"id", "name", "genre", "creator", "description", "release_date", "average_rating", "ratings_count"}
+
+func newExportGame(g entity.ExportedGame) ExportGame {
+	row := ExportGame{
+		ID:          g.Game.ID,
+		Name:        g.Game.Name,
+		Genre:       g.Game.Genre,
+		Creator:     g.Game.Creator,
+		Description: g.Game.Description,
+		ReleaseDate: g.Game.ReleaseDate.Format("2006-01-02"),
+	}
+	if g.Rating != nil {
+		row.AverageRating = &g.Rating.AverageRating
+		row.RatingsCount = &g.Rating.RatingsCount
+	}
+	return row
+}
+
+func (g ExportGame) csvRecord() []string {
+	var avg, count string
+	if g.AverageRating != nil && g.RatingsCount != nil {
+		avg = strconv.FormatFloat(*g.AverageRating, 'f', -1, 64)
+		count = strconv.FormatInt(*g.RatingsCount, 10)
+	}
+	return []string{g.ID, g.Name, g.Genre, g.Creator, g.Description, g.ReleaseDate, avg, count}
+}
+
+// ExportComment — строка выгрузки GET /games/{game_id}/comments/export
+type ExportComment struct {
+	ID        string `json:"id"`
+	GameID    string `json:"game_id"`
+	UserID    string `json:"user_id"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"created_at"`
+}
+
+var commentColumns = []string{"id", "game_id", "user_id", "text", "created_at"}
+
+func newExportComment(c entity.Comment) ExportComment {
+	return ExportComment{
+		ID:        c.ID,
+		GameID:    c.GameID,
+		UserID:    c.UserID,
+		Text:      c.Text,
+		CreatedAt: c.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func (c ExportComment) csvRecord() []string {
+	return []string{c.ID, c.GameID, c.UserID, c.Text, c.CreatedAt}
+}
+
+// --------------- ответы с ошибкой ---------------
+
+// APIError — структура описания ошибки
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse — обёртка для не-200 ответов
+type ErrorResponse struct {
+	Error APIError `json:"error"`
+}
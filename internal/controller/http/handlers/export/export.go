@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Выгрузки для партнёров: каталог целиком или комментарии игры, CSV или NDJSON.
+// Данные идут из курсора Postgres батчами прямо в ответ, в памяти — только текущий батч.
+// Отмена запроса (клиент закрыл соединение) останавливает чтение из БД.
+
+type GamesExporter interface {
+	ExportGames(ctx context.Context, limit, offset int32, fn func([]entity.ExportedGame) error) error
+}
+
+type CommentsExporter interface {
+	ExportComments(ctx context.Context, gameID string, fn func([]entity.Comment) error) error
+}
+
+// NewExportGamesHandler выгружает каталог с текущими рейтингами.
+// @Summary     Выгрузка каталога игр
+// @Description Потоковая выгрузка всех игр (по id) с рейтингами из снапшотов (их пишет консьюмер rating-updates);
+// @Description без снапшота рейтинг неизвестен: null, в CSV — пустые ячейки.
+// @Description Формат — параметр format или расширение пути: /games/export.csv. Без limit выгружается весь каталог.
+// @Description Итог выгрузки — в трейлере X-Export-Status: complete или failed (оборвана после начала).
+// @Tags        export
+// @Produce     text/csv
+// @Produce     application/x-ndjson
+// @Param       format  query     string  false  "csv или ndjson"  default(ndjson)
+// @Param       limit   query     int     false  "Максимальное число игр"
+// @Param       offset  query     int     false  "Смещение"  default(0)
+// @Success     200     {array}   ExportGame     "Строки выгрузки"
+// @Failure     400     {object}  ErrorResponse  "Неверные параметры запроса"
+// @Failure     500     {object}  ErrorResponse  "Внутренняя ошибка сервера"
+// @Failure     503     {object}  ErrorResponse  "Выгрузки выключены"
+// @Router      /games/export [get]
+func NewExportGamesHandler(baseLogger *zap.Logger, uc GamesExporter, writeTimeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 1) request_id; таймаута нет — выгрузка идёт, пока клиент читает
+		reqID := middleware.GetReqID(r.Context())
+		ctx := context.WithValue(r.Context(), entity.RequestIDKey{}, reqID)
+
+		// 2) оборачиваем логгер
+		logger := baseLogger.With(zap.String("handler", "ExportGamesHandler"), zap.String("request_id", reqID))
+
+		// 3) формат и пагинация
+		format, ok := parseFormat(w, r, logger)
+		if !ok {
+			return
+		}
+		limit, offset, ok := parsePaging(w, r, logger)
+		if !ok {
+			return
+		}
+
+		// 4) выгрузка
+		stream := &exportStream{
+			w:            w,
+			rc:           http.NewResponseController(w),
+			format:       format,
+			filename:     fmt.Sprintf("games-%s.%s", time.Now().UTC().Format("20060102"), format),
+			columns:      gameColumns,
+			writeTimeout: writeTimeout,
+			logger:       logger,
+		}
+		err := uc.ExportGames(ctx, limit, offset, func(games []entity.ExportedGame) error {
+			rows := make([]exportRow, len(games))
+			for i, g := range games {
+				rows[i] = newExportGame(g)
+			}
+			return stream.write(rows)
+		})
+
+		// 5) итог
+		finish(w, r, stream, err, logger)
+	}
+}
+
+// NewExportCommentsHandler выгружает видимые комментарии игры.
+// @Summary     Выгрузка комментариев игры
+// @Description Потоковая выгрузка видимых комментариев игры по времени создания. Формат — параметр format
+// @Description или расширение пути. Итог — в трейлере X-Export-Status.
+// @Tags        export
+// @Produce     text/csv
+// @Produce     application/x-ndjson
+// @Param       game_id  path      string  true   "UUID игры"
+// @Param       format   query     string  false  "csv или ndjson"  default(ndjson)
+// @Success     200      {array}   ExportComment  "Строки выгрузки"
+// @Failure     400      {object}  ErrorResponse  "Неверные параметры запроса"
+// @Failure     404      {object}  ErrorResponse  "Игра не найдена"
+// @Failure     500      {object}  ErrorResponse  "Внутренняя ошибка сервера"
+// @Failure     503      {object}  ErrorResponse  "Выгрузки выключены"
+// @Router      /games/{game_id}/comments/export [get]
+func NewExportCommentsHandler(baseLogger *zap.Logger, uc CommentsExporter, writeTimeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 1) request_id
+		reqID := middleware.GetReqID(r.Context())
+		ctx := context.WithValue(r.Context(), entity.RequestIDKey{}, reqID)
+
+		// 2) оборачиваем логгер
+		logger := baseLogger.With(zap.String("handler", "ExportCommentsHandler"), zap.String("request_id", reqID))
+
+		// 3) валидируем game_id и формат
+		gameID := chi.URLParam(r, "game_id")
+		if _, err := uuid.Parse(gameID); err != nil {
+			logger.Warn("invalid game_id", zap.String("game_id", gameID), zap.Error(err))
+			writeError(w, r, http.StatusBadRequest, "invalid_game_id", "game_id must be a valid UUID")
+			return
+		}
+		format, ok := parseFormat(w, r, logger)
+		if !ok {
+			return
+		}
+
+		// 4) выгрузка
+		stream := &exportStream{
+			w:            w,
+			rc:           http.NewResponseController(w),
+			format:       format,
+			filename:     fmt.Sprintf("comments-%s.%s", gameID, format),
+			columns:      commentColumns,
+			writeTimeout: writeTimeout,
+			logger:       logger,
+		}
+		err := uc.ExportComments(ctx, gameID, func(comments []entity.Comment) error {
+			rows := make([]exportRow, len(comments))
+			for i, c := range comments {
+				rows[i] = newExportComment(c)
+			}
+			return stream.write(rows)
+		})
+
+		// 5) итог
+		finish(w, r, stream, err, logger)
+	}
+}
+
+func finish(w http.ResponseWriter, r *http.Request, stream *exportStream, err error, logger *zap.Logger) {
+	switch {
+	case err == nil:
+		stream.finish()
+	case stream.started():
+		stream.fail(err)
+	case r.Context().Err() != nil:
+		logger.Info("client disconnected before export started")
+	case errors.Is(err, entity.ErrGameNotFound):
+		writeError(w, r, http.StatusNotFound, "game_not_found", "game not found")
+	case errors.Is(err, entity.ErrExportDisabled):
+		writeError(w, r, http.StatusServiceUnavailable, "export_disabled", "export is disabled")
+	default:
+		logger.Error("export failed", zap.Error(err))
+		writeError(w, r, http.StatusInternalServerError, "internal_error", "could not export data")
+	}
+}
+
+// parseFormat: ?format= или расширение пути (/games/export.csv, его снимает middleware.URLFormat)
+func parseFormat(w http.ResponseWriter, r *http.Request, logger *zap.Logger) (string, bool) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format, _ = r.Context().Value(middleware.URLFormatCtxKey).(string)
+	}
+	if format == "" {
+		return formatNDJSON, true
+	}
+	if _, ok := contentTypes[format]; !ok {
+		logger.Warn("invalid format", zap.String("format", format))
+		writeError(w, r, http.StatusBadRequest, "invalid_format", "format must be csv or ndjson")
+		return "", false
+	}
+	return format, true
+}
+
+// parsePaging — те же limit/offset, что у GET /games, но без limit выгружается всё
+func parsePaging(w http.ResponseWriter, r *http.Request, logger *zap.Logger) (limit, offset int32, ok bool) {
+	q := r.URL.Query()
+
+	if s := q.Get("limit"); s != "" {
+		v, err := strconv.ParseInt(s, 10, 32)
+		if err != nil || v <= 0 {
+			logger.Warn("invalid limit param", zap.String("limit", s))
+			writeError(w, r, http.StatusBadRequest, "invalid_limit", "limit must be a positive integer")
+			return 0, 0, false
+		}
+		limit = int32(v)
+	}
+	if s := q.Get("offset"); s != "" {
+		v, err := strconv.ParseInt(s, 10, 32)
+		if err != nil || v < 0 {
+			logger.Warn("invalid offset param", zap.String("offset", s))
+			writeError(w, r, http.StatusBadRequest, "invalid_offset", "offset must be a non-negative integer")
+			return 0, 0, false
+		}
+		offset = int32(v)
+	}
+	return limit, offset, true
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, code, msg string) {
+	render.Status(r, status)
+	render.JSON(w, r, ErrorResponse{Error: APIError{code, msg}})
+}
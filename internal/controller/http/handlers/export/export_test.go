@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testGame = "11111111-1111-1111-1111-111111111111"
+
+// fakeExporter отдаёт батчи по очереди; failAfter — ошибка после стольких батчей
+type fakeExporter struct {
+	games     [][]entity.ExportedGame
+	comments  [][]entity.Comment
+	err       error
+	failAfter int
+
+	limit, offset int32
+}
+
+func (f *fakeExporter) ExportGames(ctx context.Context, limit, offset int32, fn func([]entity.ExportedGame) error) error {
+	f.limit, f.offset = limit, offset
+	for i, batch := range f.games {
+		if f.err != nil && i == f.failAfter {
+			return f.err
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+	return f.err
+}
+
+func (f *fakeExporter) ExportComments(ctx context.Context, gameID string, fn func([]entity.Comment) error) error {
+	for i, batch := range f.comments {
+		if f.err != nil && i == f.failAfter {
+			return f.err
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+	return f.err
+}
+
+func newTestServer(t *testing.T, uc *fakeExporter) string {
+	t.Helper()
+	r := chi.NewRouter()
+	r.Use(middleware.URLFormat)
+	r.Get("/games/export", NewExportGamesHandler(zap.NewNop(), uc, time.Second))
+	r.Get("/games/{game_id}/comments/export", NewExportCommentsHandler(zap.NewNop(), uc, time.Second))
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// get читает тело целиком: трейлеры доступны только после него
+func get(t *testing.T, url string) (*http.Response, []byte) {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, body
+}
+
+// testGames: с рейтингом, без оценок и с неизвестным рейтингом
+func testGames() []entity.ExportedGame {
+	release := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	return []entity.ExportedGame{
+		{Game: entity.Game{ID: testGame, Name: "Doom, Eternal", Genre: "FPS", Creator: "id", Description: "line1\nline2", ReleaseDate: release},
+			Rating: &entity.GameRating{AverageRating: 8.5, RatingsCount: 2}},
+		{Game: entity.Game{ID: "22222222-2222-2222-2222-222222222222", Name: "Quake", Genre: "FPS", Creator: "id", ReleaseDate: release},
+			Rating: &entity.GameRating{}},
+		{Game: entity.Game{ID: "33333333-3333-3333-3333-333333333333", Name: "Heretic", Genre: "FPS", Creator: "Raven", ReleaseDate: release}},
+	}
+}
+
+func TestExportGames_CSV(t *testing.T) {
+	games := testGames()
+	uc := &fakeExporter{games: [][]entity.ExportedGame{games[:1], games[1:]}}
+	url := newTestServer(t, uc)
+
+	// формат из расширения пути
+	resp, body := get(t, url+"/games/export.csv?limit=10&offset=5")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	require.Contains(t, resp.Header.Get("Content-Disposition"), "attachment")
+	require.Contains(t, resp.Header.Get("Content-Disposition"), ".csv")
+	require.Equal(t, _statusComplete, resp.Trailer.Get(_statusTrailer))
+	require.Equal(t, int32(10), uc.limit)
+	require.Equal(t, int32(5), uc.offset)
+
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	require.Equal(t, gameColumns, records[0])
+	require.Equal(t, []string{testGame, "Doom, Eternal", "FPS", "id", "line1\nline2", "2020-05-01", "8.5", "2"}, records[1])
+	// без оценок — нули, неизвестный рейтинг — пустые ячейки
+	require.Equal(t, []string{"0", "0"}, records[2][6:])
+	require.Equal(t, []string{"", ""}, records[3][6:])
+}
+
+func TestExportGames_NDJSONByDefault(t *testing.T) {
+	uc := &fakeExporter{games: [][]entity.ExportedGame{testGames()}}
+	url := newTestServer(t, uc)
+
+	resp, body := get(t, url+"/games/export")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	require.Equal(t, int32(0), uc.limit)
+
+	sc := bufio.NewScanner(bytes.NewReader(body))
+	var rows []ExportGame
+	for sc.Scan() {
+		var g ExportGame
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &g))
+		rows = append(rows, g)
+	}
+	require.Len(t, rows, 3)
+	require.Equal(t, "Doom, Eternal", rows[0].Name)
+	require.Equal(t, 8.5, *rows[0].AverageRating)
+	require.Zero(t, *rows[1].RatingsCount)
+	require.Nil(t, rows[2].AverageRating)
+	require.Nil(t, rows[2].RatingsCount)
+	require.Contains(t, string(body), `"average_rating":null`)
+}
+
+func TestExportGames_Empty(t *testing.T) {
+	url := newTestServer(t, &fakeExporter{})
+
+	resp, body := get(t, url+"/games/export?format=csv")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, _statusComplete, resp.Trailer.Get(_statusTrailer))
+
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{gameColumns}, records)
+}
+
+func TestExportGames_InvalidParams(t *testing.T) {
+	url := newTestServer(t, &fakeExporter{})
+
+	for _, q := range []string{"format=xml", "limit=abc", "limit=0", "offset=-1"} {
+		resp, _ := get(t, url+"/games/export?"+q)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, q)
+	}
+	resp, _ := get(t, url+"/games/export.xml")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestExportGames_ErrorBeforeStart(t *testing.T) {
+	url := newTestServer(t, &fakeExporter{err: entity.ErrInternal})
+	resp, body := get(t, url+"/games/export")
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	require.Contains(t, string(body), "internal_error")
+
+	url = newTestServer(t, &fakeExporter{err: entity.ErrExportDisabled})
+	resp, _ = get(t, url+"/games/export")
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestExportGames_FailedMidway(t *testing.T) {
+	games := testGames()
+	uc := &fakeExporter{games: [][]entity.ExportedGame{games[:1], games[1:]}, err: errors.New("cursor lost"), failAfter: 1}
+	url := newTestServer(t, uc)
+
+	resp, body := get(t, url+"/games/export?format=csv")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, _statusFailed, resp.Trailer.Get(_statusTrailer))
+
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+}
+
+func TestExportComments(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	uc := &fakeExporter{comments: [][]entity.Comment{{
+		{ID: "c1", GameID: testGame, UserID: "u1", Text: "hi", CreatedAt: created},
+	}}}
+	url := newTestServer(t, uc)
+
+	resp, body := get(t, url+"/games/"+testGame+"/comments/export.csv")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, resp.Header.Get("Content-Disposition"), "comments-"+testGame+".csv")
+	require.Equal(t, _statusComplete, resp.Trailer.Get(_statusTrailer))
+
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{commentColumns, {"c1", testGame, "u1", "hi", "2024-01-02T03:04:05Z"}}, records)
+}
+
+func TestExportComments_Errors(t *testing.T) {
+	url := newTestServer(t, &fakeExporter{err: entity.ErrGameNotFound})
+
+	resp, body := get(t, url+"/games/"+testGame+"/comments/export")
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.Contains(t, string(body), "game_not_found")
+
+	resp, _ = get(t, url+"/games/not-a-uuid/comments/export")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
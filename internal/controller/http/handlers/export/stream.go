@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// форматы выгрузки
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+var contentTypes = map[string]string{
+	formatCSV:    "text/csv; charset=utf-8",
+	formatNDJSON: "application/x-ndjson",
+}
+
+// трейлер с итогом: заголовки и статус 200 уходят до первой строки, поэтому оборванную
+// посреди выгрузку (сбой БД) клиент отличает от полной только по нему
+const (
+	_statusTrailer  = "X-Export-Status"
+	_statusComplete = "complete"
+	_statusFailed   = "failed"
+)
+
+type exportRow interface {
+	csvRecord() []string
+}
+
+type encoder interface {
+	write(row exportRow) error
+	// flush отдаёт накопленное в ResponseWriter
+	flush() error
+}
+
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) write(row exportRow) error {
+	return e.w.Write(row.csvRecord())
+}
+
+func (e *csvEncoder) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type ndjsonEncoder struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func (e *ndjsonEncoder) write(row exportRow) error {
+	// Encode дописывает \n после объекта
+	return e.enc.Encode(row)
+}
+
+func (e *ndjsonEncoder) flush() error {
+	return e.buf.Flush()
+}
+
+func newEncoder(w io.Writer, format string, columns []string) (encoder, error) {
+	if format == formatCSV {
+		cw := csv.NewWriter(w)
+		return &csvEncoder{cw}, cw.Write(columns)
+	}
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	return &ndjsonEncoder{buf, enc}, nil
+}
+
+// exportStream пишет выгрузку батчами. Заголовки уходят вместе с первым батчем:
+// ошибка до него ещё может стать обычным JSON-ответом с кодом ошибки.
+type exportStream struct {
+	w            http.ResponseWriter
+	rc           *http.ResponseController
+	format       string
+	filename     string
+	columns      []string
+	writeTimeout time.Duration
+	logger       *zap.Logger
+
+	enc  encoder
+	rows int
+}
+
+func (s *exportStream) started() bool {
+	return s.enc != nil
+}
+
+func (s *exportStream) start() error {
+	h := s.w.Header()
+	h.Set("Content-Type", contentTypes[s.format])
+	h.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": s.filename}))
+	h.Set("Cache-Control", "no-store")
+	// nginx не должен копить выгрузку у себя целиком
+	h.Set("X-Accel-Buffering", "no")
+	h.Set("Trailer", _statusTrailer)
+	s.w.WriteHeader(http.StatusOK)
+
+	var err error
+	s.enc, err = newEncoder(s.w, s.format, s.columns)
+	return err
+}
+
+// write отдаёт батч клиенту. WriteTimeout сервера рассчитан на обычные запросы, поэтому дедлайн
+// продлевается на каждый батч: медленный, но живой клиент дочитает, а зависший не держит курсор дольше таймаута.
+func (s *exportStream) write(rows []exportRow) error {
+	if err := s.rc.SetWriteDeadline(time.Now().Add(s.writeTimeout)); err != nil && s.rows == 0 {
+		s.logger.Warn("cannot extend write deadline, export may be cut by server timeout", zap.Error(err))
+	}
+	if !s.started() {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range rows {
+		if err := s.enc.write(row); err != nil {
+			return err
+		}
+	}
+	if err := s.enc.flush(); err != nil {
+		return err
+	}
+	s.rows += len(rows)
+	// не каждый ResponseWriter умеет Flush; тогда данные уйдут по мере заполнения буфера
+	if err := s.rc.Flush(); err != nil && s.rows == len(rows) {
+		s.logger.Warn("response writer does not support flushing", zap.Error(err))
+	}
+	return nil
+}
+
+// finish после успешной выгрузки: пустая выгрузка — это заголовки (и строка колонок CSV) без данных
+func (s *exportStream) finish() {
+	if !s.started() {
+		if err := s.write(nil); err != nil {
+			s.logger.Info("failed to write empty export", zap.Error(err))
+			return
+		}
+	}
+	s.w.Header().Set(_statusTrailer, _statusComplete)
+	s.logger.Info("export completed", zap.Int("rows", s.rows))
+}
+
+// fail — ошибка после начала выгрузки: статус уже ушёл, остаётся трейлер
+func (s *exportStream) fail(err error) {
+	s.w.Header().Set(_statusTrailer, _statusFailed)
+	if errors.Is(err, context.Canceled) {
+		s.logger.Info("client disconnected during export", zap.Int("rows", s.rows))
+		return
+	}
+	s.logger.Warn("export interrupted", zap.Int("rows", s.rows), zap.Error(err))
+}
@@ -0,0 +1,6 @@
+package handlers
+
+// LivenessResponse — ответ /healthz
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
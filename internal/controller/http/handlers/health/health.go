@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/RozmiDan/gameReviewHub/internal/health"
+	"github.com/go-chi/render"
+)
+
+// ReadinessChecker — опрос зависимостей
+type ReadinessChecker interface {
+	Check(ctx context.Context) health.Report
+}
+
+// NewLivenessHandler — процесс жив и обслуживает HTTP; зависимости не проверяются,
+// иначе падение Postgres приводило бы к рестарту всех реплик.
+// @Summary     Liveness
+// @Description Процесс жив. Зависимости не проверяет.
+// @Tags        health
+// @Produce     json
+// @Success     200  {object}  LivenessResponse
+// @Router      /healthz [get]
+func NewLivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, LivenessResponse{Status: health.StatusOK})
+	}
+}
+
+// NewReadinessHandler — можно ли слать трафик на реплику.
+// @Summary     Readiness
+// @Description Проверяет Postgres, Redis, Kafka и rating service с таймаутами. Падение некритичной зависимости даёт status=degraded и 200.
+// @Tags        health
+// @Produce     json
+// @Success     200  {object}  health.Report  "Готов (ok или degraded)"
+// @Failure     503  {object}  health.Report  "Недоступна критичная зависимость"
+// @Router      /readyz [get]
+func NewReadinessHandler(checker ReadinessChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rep := checker.Check(r.Context())
+
+		status := http.StatusOK
+		if !rep.Ready {
+			status = http.StatusServiceUnavailable
+		}
+		// балансировщик должен видеть свежее состояние
+		w.Header().Set("Cache-Control", "no-store")
+		render.Status(r, status)
+		render.JSON(w, r, rep)
+	}
+}
@@ -0,0 +1,22 @@
+package handlers
+
+// LogLevelRequest — тело PUT /admin/log-level
+type LogLevelRequest struct {
+	Level string `json:"level" example:"debug"`
+}
+
+// LogLevelResponse — текущий уровень логирования
+type LogLevelResponse struct {
+	Level string `json:"level" example:"info"`
+}
+
+// APIError — структура описания ошибки
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse — обёртка для не-200 ответов
+type ErrorResponse struct {
+	Error APIError `json:"error"`
+}
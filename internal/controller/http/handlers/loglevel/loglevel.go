@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	jsondecoder "github.com/RozmiDan/gameReviewHub/pkg/json_decoder"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// /admin/log-level — уровень логов меняется на лету, без передеплоя; доступ только по токену модератора
+
+// LevelController — zap.AtomicLevel из logger.NewLogger
+type LevelController interface {
+	Level() zapcore.Level
+	SetLevel(zapcore.Level)
+}
+
+// NewGetLogLevelHandler отдаёт текущий уровень логирования.
+// @Summary     Уровень логирования
+// @Tags        admin
+// @Produce     json
+// @Param       Authorization  header  string  true  "Bearer-токен модератора"
+// @Success     200  {object} LogLevelResponse
+// @Failure     401  {object} ErrorResponse "Нужен токен модератора"
+// @Router      /admin/log-level [get]
+func NewGetLogLevelHandler(level LevelController) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, LogLevelResponse{Level: level.Level().String()})
+	}
+}
+
+// NewSetLogLevelHandler меняет уровень логирования всего процесса.
+// @Summary     Сменить уровень логирования
+// @Description Действует до следующей смены или рестарта; после рестарта уровень снова берётся из конфига.
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Param       Authorization  header  string           true  "Bearer-токен модератора"
+// @Param       body           body    LogLevelRequest  true  "Новый уровень: debug, info, warn, error"
+// @Success     200  {object} LogLevelResponse "Уровень после смены"
+// @Failure     400  {object} ErrorResponse    "Некорректный запрос или неизвестный уровень"
+// @Failure     401  {object} ErrorResponse    "Нужен токен модератора"
+// @Router      /admin/log-level [put]
+func NewSetLogLevelHandler(baseLogger *zap.Logger, level LevelController) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 1) request_id и модератор — в журнал смены уровня
+		moderator, _ := r.Context().Value(entity.ModeratorKey{}).(string)
+		logger := baseLogger.With(zap.String("handler", "SetLogLevelHandler"),
+			zap.String("request_id", middleware.GetReqID(r.Context())), zap.String("moderator", moderator))
+
+		// 2) тело запроса
+		var payload LogLevelRequest
+		if err := jsondecoder.DecodeJSONBody(w, r, &payload); err != nil {
+			var mr *jsondecoder.MalformedRequest
+			if errors.As(err, &mr) {
+				logger.Warn("malformed request body", zap.Error(err))
+				writeError(w, r, mr.Status, "invalid_json", mr.Msg)
+				return
+			}
+			logger.Error("failed to decode JSON", zap.Error(err))
+			writeError(w, r, http.StatusBadRequest, "invalid_json", "cannot parse request body")
+			return
+		}
+
+		// 3) валидация: пустой уровень ParseLevel принимает за info — без поля молча сбросили бы уровень;
+		// panic и fatal через API не выставляются — это выключило бы логи целиком
+		if strings.TrimSpace(payload.Level) == "" {
+			writeError(w, r, http.StatusBadRequest, "invalid_level", "level is required")
+			return
+		}
+		next, err := zapcore.ParseLevel(payload.Level)
+		if err != nil || next > zapcore.ErrorLevel {
+			writeError(w, r, http.StatusBadRequest, "invalid_level", "level must be debug, info, warn or error")
+			return
+		}
+
+		// 4) смена; запись — не ниже warn и не ниже нового уровня, иначе при повышении до error она потерялась бы
+		prev := level.Level()
+		level.SetLevel(next)
+		if ce := logger.Check(max(next, zapcore.WarnLevel), "log level changed"); ce != nil {
+			ce.Write(zap.Stringer("from", prev), zap.Stringer("to", next))
+		}
+
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, LogLevelResponse{Level: next.String()})
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, code, msg string) {
+	render.Status(r, status)
+	render.JSON(w, r, ErrorResponse{Error: APIError{code, msg}})
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	level := zap.NewAtomicLevelAt(zap.InfoLevel)
+	core, logs := observer.New(level)
+	h := NewSetLogLevelHandler(zap.New(core), level)
+
+	put := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), entity.ModeratorKey{}, "alice"))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := put(`{"level":"debug"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"level":"debug"}`, rec.Body.String())
+	require.Equal(t, zap.DebugLevel, level.Level())
+
+	// повышение до error: запись о смене всё равно видна
+	rec = put(`{"level":"error"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, zap.ErrorLevel, level.Level())
+
+	changes := logs.FilterMessage("log level changed").All()
+	require.Len(t, changes, 2)
+	require.Equal(t, "alice", changes[1].ContextMap()["moderator"])
+	require.Equal(t, "debug", changes[1].ContextMap()["from"])
+	require.Equal(t, "error", changes[1].ContextMap()["to"])
+
+	for _, body := range []string{`{"level":"verbose"}`, `{"level":"fatal"}`, `{"level":"debug","x":1}`, `nope`} {
+		rec = put(body)
+		require.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+	require.Equal(t, zap.ErrorLevel, level.Level())
+
+	// без уровня не сбрасываемся на info
+	for _, body := range []string{`{}`, `{"level":""}`, `{"level":"  "}`} {
+		rec = put(body)
+		require.Equal(t, http.StatusBadRequest, rec.Code, body)
+		require.Contains(t, rec.Body.String(), "invalid_level", body)
+	}
+	require.Equal(t, zap.ErrorLevel, level.Level())
+}
+
+func TestGetLogLevel(t *testing.T) {
+	level := zap.NewAtomicLevelAt(zap.WarnLevel)
+
+	rec := httptest.NewRecorder()
+	NewGetLogLevelHandler(level).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/log-level", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.JSONEq(t, `{"level":"warn"}`, rec.Body.String())
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import "github.com/RozmiDan/gameReviewHub/internal/entity"
+
+// ModerateRequest — тело POST /admin/comments/{comment_id}/{action}; для hide reason обязателен
+type ModerateRequest struct {
+	Reason string `json:"reason"`
+}
+
+type Pagination struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+	Count  int   `json:"count"`
+}
+
+type ListPendingResponse struct {
+	Data []entity.Comment `json:"data"`
+	Meta *Pagination      `json:"meta,omitempty"`
+}
+
+type ListReportsResponse struct {
+	Data []entity.ReportedComment `json:"data"`
+	Meta *Pagination              `json:"meta,omitempty"`
+}
+
+type AuditResponse struct {
+	Data []entity.ModerationAudit `json:"data"`
+}
+
+// APIError — структура описания ошибки
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse — обёртка для не-200 ответов
+type ErrorResponse struct {
+	Error APIError `json:"error"`
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	jsondecoder "github.com/RozmiDan/gameReviewHub/pkg/json_decoder"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// /admin/comments — очередь модерации; доступ только по токену модератора
+
+type Moderator interface {
+	ListPendingComments(ctx context.Context, limit, offset int32) ([]entity.Comment, error)
+	ModerateComment(ctx context.Context, commentID, action, reason string) (*entity.Comment, error)
+	GetModerationAudit(ctx context.Context, commentID string) ([]entity.ModerationAudit, error)
+	ListReportedComments(ctx context.Context, status string, limit, offset int32) ([]entity.ReportedComment, error)
+}
+
+// NewListPendingHandler отдаёт комментарии, ожидающие проверки.
+// @Summary     Очередь модерации
+// @Description Комментарии со статусом pending, старые первыми.
+// @Tags        moderation
+// @Produce     json
+// @Param       Authorization  header  string  true  "Bearer-токен модератора"
+// @Param       limit   query    int  false  "Размер страницы (по умолчанию 20)"
+// @Param       offset  query    int  false  "Номер страницы (с 0)"
+// @Success     200     {object} ListPendingResponse
+// @Failure     400     {object} ErrorResponse "Некорректные параметры"
+// @Failure     401     {object} ErrorResponse "Нужен токен модератора"
+// @Failure     500     {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router      /admin/comments/pending [get]
+func NewListPendingHandler(baseLogger *zap.Logger, uc Moderator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 1) request_id и таймаут
+		ctx, logger, cancel := requestScope(r, baseLogger, "ListPendingHandler")
+		defer cancel()
+
+		// 2) пагинация
+		limit, offset, ok := paging(w, r)
+		if !ok {
+			return
+		}
+
+		// 3) бизнес-логика
+		comments, err := uc.ListPendingComments(ctx, limit, offset)
+		if err != nil {
+			writeUsecaseError(w, r, logger, err)
+			return
+		}
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, ListPendingResponse{
+			Data: comments,
+			Meta: &Pagination{Limit: limit, Offset: offset, Count: len(comments)},
+		})
+	}
+}
+
+// NewListReportsHandler отдаёт комментарии с жалобами, больше жалоб — выше.
+// @Summary     Жалобы на комментарии
+// @Description Сводка по каждому комментарию: число жалоб, разбивка по причинам, время последней жалобы.
+// @Description Комментарии, скрытые автоматически по жалобам, имеют статус pending.
+// @Tags        moderation
+// @Produce     json
+// @Param       Authorization  header  string  true   "Bearer-токен модератора"
+// @Param       status         query   string  false  "Только с этим статусом"  Enums(visible, pending, hidden)
+// @Param       limit          query   int     false  "Размер страницы (по умолчанию 20)"
+// @Param       offset         query   int     false  "Номер страницы (с 0)"
+// @Success     200  {object} ListReportsResponse
+// @Failure     400  {object} ErrorResponse "Некорректные параметры"
+// @Failure     401  {object} ErrorResponse "Нужен токен модератора"
+// @Failure     500  {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router      /admin/comments/reports [get]
+func NewListReportsHandler(baseLogger *zap.Logger, uc Moderator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, logger, cancel := requestScope(r, baseLogger, "ListReportsHandler")
+		defer cancel()
+
+		limit, offset, ok := paging(w, r)
+		if !ok {
+			return
+		}
+		status := r.URL.Query().Get("status")
+		switch status {
+		case "", entity.CommentVisible, entity.CommentPending, entity.CommentHidden:
+		default:
+			writeError(w, r, http.StatusBadRequest, "invalid_status", "status must be visible, pending or hidden")
+			return
+		}
+
+		reported, err := uc.ListReportedComments(ctx, status, limit, offset)
+		if err != nil {
+			writeUsecaseError(w, r, logger, err)
+			return
+		}
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, ListReportsResponse{
+			Data: reported,
+			Meta: &Pagination{Limit: limit, Offset: offset, Count: len(reported)},
+		})
+	}
+}
+
+// NewModerateHandler применяет действие модератора к комментарию.
+// @Summary     Модерация комментария
+// @Description approve: pending → visible; hide: visible или pending → hidden, reason обязателен; restore: hidden → visible.
+// @Description Модератор и причина пишутся в комментарий и в журнал.
+// @Tags        moderation
+// @Accept      json
+// @Produce     json
+// @Param       Authorization  header  string  true  "Bearer-токен модератора"
+// @Param       comment_id  path     string           true   "UUID комментария"
+// @Param       action      path     string           true   "Действие"  Enums(approve, hide, restore)
+// @Param       body        body     ModerateRequest  false  "Причина"
+// @Success     200         {object} entity.Comment   "Комментарий после модерации"
+// @Failure     400         {object} ErrorResponse    "Некорректный запрос"
+// @Failure     401         {object} ErrorResponse    "Нужен токен модератора"
+// @Failure     404         {object} ErrorResponse    "Комментарий не найден"
+// @Failure     409         {object} ErrorResponse    "Действие недопустимо для текущего статуса"
+// @Failure     500         {object} ErrorResponse    "Внутренняя ошибка сервера"
+// @Router      /admin/comments/{comment_id}/{action} [post]
+func NewModerateHandler(baseLogger *zap.Logger, uc Moderator, action string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 1) request_id и таймаут
+		ctx, logger, cancel := requestScope(r, baseLogger, "ModerateHandler")
+		defer cancel()
+		logger = logger.With(zap.String("action", action))
+
+		// 2) comment_id и необязательное тело
+		id, ok := commentID(w, r, logger)
+		if !ok {
+			return
+		}
+		var payload ModerateRequest
+		if r.ContentLength != 0 {
+			if err := jsondecoder.DecodeJSONBody(w, r, &payload); err != nil {
+				var mr *jsondecoder.MalformedRequest
+				if errors.As(err, &mr) {
+					logger.Warn("malformed request body", zap.Error(err))
+					writeError(w, r, mr.Status, "invalid_json", mr.Msg)
+					return
+				}
+				logger.Error("failed to decode JSON", zap.Error(err))
+				writeError(w, r, http.StatusBadRequest, "invalid_json", "cannot parse request body")
+				return
+			}
+		}
+
+		// 3) бизнес-логика
+		comment, err := uc.ModerateComment(ctx, id, action, payload.Reason)
+		if err != nil {
+			writeUsecaseError(w, r, logger, err)
+			return
+		}
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, comment)
+	}
+}
+
+// NewAuditHandler отдаёт журнал модерации комментария.
+// @Summary     Журнал модерации комментария
+// @Tags        moderation
+// @Produce     json
+// @Param       Authorization  header  string  true  "Bearer-токен модератора"
+// @Param       comment_id  path     string  true  "UUID комментария"
+// @Success     200         {object} AuditResponse
+// @Failure     400         {object} ErrorResponse "Некорректный comment_id"
+// @Failure     401         {object} ErrorResponse "Нужен токен модератора"
+// @Failure     500         {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router      /admin/comments/{comment_id}/audit [get]
+func NewAuditHandler(baseLogger *zap.Logger, uc Moderator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, logger, cancel := requestScope(r, baseLogger, "AuditHandler")
+		defer cancel()
+
+		id, ok := commentID(w, r, logger)
+		if !ok {
+			return
+		}
+		audit, err := uc.GetModerationAudit(ctx, id)
+		if err != nil {
+			writeUsecaseError(w, r, logger, err)
+			return
+		}
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, AuditResponse{Data: audit})
+	}
+}
+
+// requestScope — request_id в ctx, таймаут и логгер обработчика
+func requestScope(r *http.Request, baseLogger *zap.Logger, handler string) (context.Context, *zap.Logger, context.CancelFunc) {
+	reqID := middleware.GetReqID(r.Context())
+	ctx := context.WithValue(r.Context(), entity.RequestIDKey{}, reqID)
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	moderator, _ := ctx.Value(entity.ModeratorKey{}).(string)
+	logger := baseLogger.With(zap.String("handler", handler), zap.String("request_id", reqID),
+		zap.String("moderator", moderator))
+	return ctx, logger, cancel
+}
+
+// paging — limit (1..100, по умолчанию 20) и номер страницы offset
+func paging(w http.ResponseWriter, r *http.Request) (int32, int32, bool) {
+	limit, offset := int32(20), int32(0)
+	q := r.URL.Query()
+	if s := q.Get("limit"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v <= 0 || v > 100 {
+			writeError(w, r, http.StatusBadRequest, "invalid_limit", "limit must be between 1 and 100")
+			return 0, 0, false
+		}
+		limit = int32(v)
+	}
+	if s := q.Get("offset"); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil || v < 0 {
+			writeError(w, r, http.StatusBadRequest, "invalid_offset", "offset must be >= 0")
+			return 0, 0, false
+		}
+		offset = int32(v)
+	}
+	return limit, offset, true
+}
+
+func commentID(w http.ResponseWriter, r *http.Request, logger *zap.Logger) (string, bool) {
+	id := chi.URLParam(r, "comment_id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Warn("invalid comment_id", zap.String("comment_id", id))
+		writeError(w, r, http.StatusBadRequest, "invalid_comment_id", "comment_id must be a valid UUID")
+		return "", false
+	}
+	return id, true
+}
+
+func writeUsecaseError(w http.ResponseWriter, r *http.Request, logger *zap.Logger, err error) {
+	switch {
+	case errors.Is(err, entity.ErrModeratorRequired):
+		writeError(w, r, http.StatusUnauthorized, "unauthorized", "moderator token required")
+	case errors.Is(err, entity.ErrModerationReason):
+		writeError(w, r, http.StatusBadRequest, "reason_required", "reason is required to hide a comment")
+	case errors.Is(err, entity.ErrCommentNotFound):
+		writeError(w, r, http.StatusNotFound, "comment_not_found", "comment not found")
+	case errors.Is(err, entity.ErrInvalidModeration):
+		logger.Info("moderation rejected", zap.Error(err))
+		writeError(w, r, http.StatusConflict, "invalid_transition", err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		logger.Error("timeout exceeded", zap.Error(err))
+		writeError(w, r, http.StatusGatewayTimeout, "timeout_exceeded", "request took longer than 2 seconds")
+	default:
+		logger.Error("moderation request failed", zap.Error(err))
+		writeError(w, r, http.StatusInternalServerError, "internal_error", "internal server error")
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, code, msg string) {
+	render.Status(r, status)
+	render.JSON(w, r, ErrorResponse{Error: APIError{code, msg}})
+}
@@ -0,0 +1,40 @@
+package handlers
+
+// Сообщения клиента
+const actionSubscribe = "subscribe"
+
+// Типы служебных сообщений сервера (обновления рейтинга — liverating.Event с type "rating")
+const (
+	typeSubscribed = "subscribed"
+	typeError      = "error"
+)
+
+// ClientMessage — команда клиента: {"action":"subscribe","game_ids":[...]} или {"action":"subscribe","top":10}.
+// Новая подписка заменяет прежнюю.
+type ClientMessage struct {
+	Action  string   `json:"action"`
+	GameIDs []string `json:"game_ids,omitempty"`
+	Top     int      `json:"top,omitempty"`
+}
+
+// ServerMessage — подтверждение подписки или ошибка
+type ServerMessage struct {
+	Type    string   `json:"type"`
+	GameIDs []string `json:"game_ids,omitempty"`
+	Top     int      `json:"top,omitempty"`
+	Code    string   `json:"code,omitempty"`
+	Message string   `json:"message,omitempty"`
+}
+
+// --------------- ответы с ошибкой ---------------
+
+// APIError — структура описания ошибки
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse — обёртка для не-200 ответов
+type ErrorResponse struct {
+	Error APIError `json:"error"`
+}
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/RozmiDan/gameReviewHub/internal/liverating"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+// GET /ws/ratings?game_ids=&top=
+
+// команды клиента маленькие — больше не читаем
+const _maxMessageSize = 4096
+
+type RatingHub interface {
+	Register() *liverating.Client
+	Unregister(c *liverating.Client)
+	Subscribe(c *liverating.Client, sub liverating.Subscription) error
+}
+
+// Config — параметры соединения
+type Config struct {
+	PingInterval time.Duration
+	WriteTimeout time.Duration
+	// AllowedOrigins — с каких Origin можно подключаться; пусто — только с того же хоста, "*" — с любого
+	AllowedOrigins []string
+}
+
+// RatingStreamHandler — WebSocket с обновлениями рейтингов.
+// @Summary     Живые обновления рейтингов
+// @Description WebSocket. Подписка — в query (game_ids через запятую или top) либо сообщением
+// @Description {"action":"subscribe","game_ids":[...]} / {"action":"subscribe","top":10}; новая подписка заменяет прежнюю.
+// @Description Сервер шлёт {"type":"rating","game_id":...,"average_rating":...,"ratings_count":...,"delta":...,"rank":...}.
+// @Description Клиент, который не успевает читать, отключается с кодом 1013 — после переподключения стоит перечитать GET /games.
+// @Tags        games
+// @Param       game_ids  query  string  false  "UUID игр через запятую"
+// @Param       top       query  int     false  "Подписка на первые N игр рейтинга"
+// @Success     101  {string}  string        "Switching Protocols"
+// @Failure     400  {object}  ErrorResponse "Неверная подписка"
+// @Router      /ws/ratings [get]
+func NewRatingStreamHandler(baseLogger *zap.Logger, hub RatingHub, cfg Config, shutdown <-chan struct{}) http.HandlerFunc {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     checkOrigin(cfg.AllowedOrigins),
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 1) request_id
+		reqID := middleware.GetReqID(r.Context())
+
+		// 2) оборачиваем логгер
+		logger := baseLogger.With(zap.String("handler", "RatingStreamHandler"), zap.String("request_id", reqID))
+
+		// 3) начальная подписка из query — ошибки отдаём обычным ответом, до upgrade
+		sub, err := subscriptionFromQuery(r)
+		if err != nil {
+			writeBadRequest(w, r, logger, err)
+			return
+		}
+
+		client := hub.Register()
+		defer hub.Unregister(client)
+
+		if sub != nil {
+			if err := hub.Subscribe(client, *sub); err != nil {
+				writeBadRequest(w, r, logger, err)
+				return
+			}
+		}
+
+		// 4) upgrade; серверные таймауты на hijacked-соединение не действуют, дедлайны ставим сами
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// Upgrade уже ответил клиенту ошибкой
+			logger.Warn("websocket upgrade failed", zap.Error(err))
+			return
+		}
+		defer conn.Close()
+		logger.Info("rating stream opened")
+
+		replies := make(chan ServerMessage, 4)
+		if sub != nil {
+			replies <- ServerMessage{Type: typeSubscribed, GameIDs: sub.GameIDs, Top: sub.Top}
+		}
+
+		readDone := make(chan struct{})
+		go readLoop(conn, hub, client, cfg.PingInterval, replies, readDone, logger)
+
+		// 5) единственный писатель в соединение
+		ticker := time.NewTicker(cfg.PingInterval)
+		defer ticker.Stop()
+
+		write := func(msgType int, data []byte) bool {
+			conn.SetWriteDeadline(time.Now().Add(cfg.WriteTimeout))
+			if err := conn.WriteMessage(msgType, data); err != nil {
+				logger.Info("websocket write failed", zap.Error(err))
+				return false
+			}
+			return true
+		}
+
+		for {
+			select {
+			case msg, ok := <-client.Send():
+				if !ok {
+					code, text := closeReason(client.Err())
+					logger.Info("rating stream closed by server", zap.String("reason", text))
+					closeConn(conn, code, text, cfg.WriteTimeout)
+					return
+				}
+				if !write(websocket.TextMessage, msg) {
+					return
+				}
+
+			case reply := <-replies:
+				b, _ := json.Marshal(reply)
+				if !write(websocket.TextMessage, b) {
+					return
+				}
+
+			case <-ticker.C:
+				if !write(websocket.PingMessage, nil) {
+					return
+				}
+
+			case <-readDone:
+				logger.Info("client disconnected")
+				return
+
+			case <-shutdown:
+				closeConn(conn, websocket.CloseGoingAway, "server shutting down", cfg.WriteTimeout)
+				return
+			}
+		}
+	}
+}
+
+// readLoop читает команды клиента и отвечает на pong; без pong дольше двух интервалов — соединение мёртвое
+func readLoop(conn *websocket.Conn, hub RatingHub, client *liverating.Client, pingInterval time.Duration,
+	replies chan<- ServerMessage, done chan<- struct{}, logger *zap.Logger) {
+
+	defer close(done)
+
+	pongWait := 2 * pingInterval
+	conn.SetReadLimit(_maxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	reply := func(m ServerMessage) {
+		// клиент шлёт команды быстрее, чем читает ответы, — ответы не копим
+		select {
+		case replies <- m:
+		default:
+		}
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				logger.Info("websocket read failed", zap.Error(err))
+			}
+			return
+		}
+
+		var msg ClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			reply(ServerMessage{Type: typeError, Code: "invalid_message", Message: "message must be JSON"})
+			continue
+		}
+		if msg.Action != actionSubscribe {
+			reply(ServerMessage{Type: typeError, Code: "unknown_action", Message: "supported actions: subscribe"})
+			continue
+		}
+
+		sub := liverating.Subscription{GameIDs: msg.GameIDs, Top: msg.Top}
+		if err := hub.Subscribe(client, sub); err != nil {
+			reply(ServerMessage{Type: typeError, Code: "invalid_subscription", Message: err.Error()})
+			continue
+		}
+		reply(ServerMessage{Type: typeSubscribed, GameIDs: sub.GameIDs, Top: sub.Top})
+	}
+}
+
+// subscriptionFromQuery: ?game_ids=a,b или ?top=N; без параметров — nil, подписка придёт сообщением
+func subscriptionFromQuery(r *http.Request) (*liverating.Subscription, error) {
+	q := r.URL.Query()
+	sub := &liverating.Subscription{}
+
+	if s := q.Get("game_ids"); s != "" {
+		for _, id := range strings.Split(s, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				sub.GameIDs = append(sub.GameIDs, id)
+			}
+		}
+	}
+	if s := q.Get("top"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: top must be an integer", entity.ErrInvalidSubscription)
+		}
+		sub.Top = n
+	}
+
+	if len(sub.GameIDs) == 0 && sub.Top == 0 {
+		return nil, nil
+	}
+	return sub, nil
+}
+
+func writeBadRequest(w http.ResponseWriter, r *http.Request, logger *zap.Logger, err error) {
+	logger.Warn("invalid rating subscription", zap.Error(err))
+	render.Status(r, http.StatusBadRequest)
+	render.JSON(w, r, ErrorResponse{
+		Error: APIError{"invalid_subscription", err.Error()},
+	})
+}
+
+func closeReason(err error) (int, string) {
+	switch {
+	case errors.Is(err, liverating.ErrSlowClient):
+		return websocket.CloseTryAgainLater, "slow consumer"
+	default:
+		return websocket.CloseGoingAway, "stream stopped"
+	}
+}
+
+func closeConn(conn *websocket.Conn, code int, text string, timeout time.Duration) {
+	msg := websocket.FormatCloseMessage(code, text)
+	_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(timeout))
+}
+
+func checkOrigin(allowed []string) func(r *http.Request) bool {
+	if len(allowed) == 0 {
+		// как в gorilla по умолчанию: Origin совпадает с Host
+		return nil
+	}
+	set := make(map[string]struct{}, len(allowed))
+	for _, o := range allowed {
+		if o == "*" {
+			return func(*http.Request) bool { return true }
+		}
+		set[strings.ToLower(strings.TrimRight(o, "/"))] = struct{}{}
+	}
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		_, ok := set[strings.ToLower(origin)]
+		return ok
+	}
+}
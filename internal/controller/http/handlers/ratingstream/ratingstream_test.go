@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/RozmiDan/gameReviewHub/internal/liverating"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testGame = "11111111-1111-1111-1111-111111111111"
+
+type emptyTop struct{}
+
+func (emptyTop) GetTopGames(ctx context.Context, limit, offset int32) ([]entity.GameRating, error) {
+	return nil, nil
+}
+
+type noUpdates struct{}
+
+func (noUpdates) SubscribeRatingUpdates(ctx context.Context) <-chan entity.RatingUpdate {
+	return nil
+}
+
+func newTestServer(t *testing.T, b *liverating.Broadcaster) string {
+	t.Helper()
+	h := NewRatingStreamHandler(zap.NewNop(), b, Config{PingInterval: time.Second, WriteTimeout: time.Second}, nil)
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func readJSON(t *testing.T, conn *websocket.Conn, v any) {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	require.NoError(t, conn.ReadJSON(v))
+}
+
+func TestRatingStream_SubscribeAndReceive(t *testing.T) {
+	b := liverating.New(emptyTop{}, noUpdates{}, zap.NewNop())
+	url := newTestServer(t, b)
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteJSON(ClientMessage{Action: "subscribe", Top: 0}))
+	var msg ServerMessage
+	readJSON(t, conn, &msg)
+	require.Equal(t, typeError, msg.Type)
+	require.Equal(t, "invalid_subscription", msg.Code)
+
+	require.NoError(t, conn.WriteJSON(ClientMessage{Action: "subscribe", GameIDs: []string{testGame}}))
+	readJSON(t, conn, &msg)
+	require.Equal(t, typeSubscribed, msg.Type)
+
+	b.Broadcast(context.Background(), entity.RatingUpdate{GameID: testGame, AverageRating: 7.5, RatingsCount: 2})
+	var evt liverating.Event
+	readJSON(t, conn, &evt)
+	require.Equal(t, liverating.EventRating, evt.Type)
+	require.Equal(t, testGame, evt.GameID)
+	require.Equal(t, int64(2), evt.RatingsCount)
+}
+
+func TestRatingStream_QuerySubscription(t *testing.T) {
+	b := liverating.New(emptyTop{}, noUpdates{}, zap.NewNop())
+	url := newTestServer(t, b)
+
+	_, resp, err := websocket.DefaultDialer.Dial(url+"?top=abc", nil)
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	_, resp, err = websocket.DefaultDialer.Dial(url+"?game_ids=not-a-uuid", nil)
+	require.Error(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?game_ids="+testGame, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var msg ServerMessage
+	readJSON(t, conn, &msg)
+	require.Equal(t, typeSubscribed, msg.Type)
+	require.Equal(t, []string{testGame}, msg.GameIDs)
+}
+
+func TestRatingStream_SlowClientClosedWithTryAgainLater(t *testing.T) {
+	b := liverating.New(emptyTop{}, noUpdates{}, zap.NewNop(), liverating.SendBuffer(1))
+	url := newTestServer(t, b)
+
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?game_ids="+testGame, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var msg ServerMessage
+	readJSON(t, conn, &msg)
+
+	// очередь из одного сообщения: два подряд без чтения — клиент отключается
+	upd := entity.RatingUpdate{GameID: testGame, AverageRating: 5}
+	b.Broadcast(context.Background(), upd)
+	b.Broadcast(context.Background(), upd)
+	b.Broadcast(context.Background(), upd)
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		_, _, err = conn.ReadMessage()
+		if err != nil {
+			break
+		}
+	}
+	require.True(t, websocket.IsCloseError(err, websocket.CloseTryAgainLater), err)
+}
@@ -0,0 +1,19 @@
+package handlers
+
+// ReportCommentRequest — тело POST /games/{game_id}/comments/{comment_id}/reports
+type ReportCommentRequest struct {
+	UserID  string `json:"user_id"`
+	Reason  string `json:"reason"` // spam | harassment | spoiler | other
+	Details string `json:"details,omitempty"`
+}
+
+// APIError — структура описания ошибки
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse — обёртка для не-200 ответов
+type ErrorResponse struct {
+	Error APIError `json:"error"`
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	jsondecoder "github.com/RozmiDan/gameReviewHub/pkg/json_decoder"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// POST /games/{game_id}/comments/{comment_id}/reports
+
+type CommentReporter interface {
+	ReportComment(ctx context.Context, rep entity.CommentReport) error
+}
+
+// ReportCommentHandler принимает жалобу пользователя на комментарий.
+// @Summary     Жалоба на комментарий
+// @Description Одна жалоба от пользователя на комментарий. Набравший достаточно жалоб комментарий
+// @Description скрывается до проверки модератором.
+// @Tags        comments
+// @Accept      json
+// @Produce     json
+// @Param       game_id     path     string                true  "UUID игры"
+// @Param       comment_id  path     string                true  "UUID комментария"
+// @Param       body        body     ReportCommentRequest  true  "user_id, причина (spam, harassment, spoiler, other) и пояснение"
+// @Success     204
+// @Failure     400         {object} ErrorResponse "Некорректные входные данные"
+// @Failure     404         {object} ErrorResponse "Комментарий не найден"
+// @Failure     409         {object} ErrorResponse "Пользователь уже жаловался на этот комментарий"
+// @Failure     504         {object} ErrorResponse "Таймаут запроса"
+// @Failure     500         {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Router      /games/{game_id}/comments/{comment_id}/reports [post]
+func NewReportCommentHandler(baseLogger *zap.Logger, uc CommentReporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 1) request_id и таймаут
+		reqID := middleware.GetReqID(r.Context())
+		ctx := context.WithValue(r.Context(), entity.RequestIDKey{}, reqID)
+		ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
+
+		// 2) оборачиваем логгер
+		logger := baseLogger.With(zap.String("handler", "ReportCommentHandler"), zap.String("request_id", reqID))
+
+		// 3) валидируем id из URL
+		gameID := chi.URLParam(r, "game_id")
+		if _, err := uuid.Parse(gameID); err != nil {
+			logger.Warn("invalid game_id", zap.String("game_id", gameID), zap.Error(err))
+			writeError(w, r, http.StatusBadRequest, "invalid_game_id", "game_id must be a valid UUID")
+			return
+		}
+		commentID := chi.URLParam(r, "comment_id")
+		if _, err := uuid.Parse(commentID); err != nil {
+			logger.Warn("invalid comment_id", zap.String("comment_id", commentID), zap.Error(err))
+			writeError(w, r, http.StatusBadRequest, "invalid_comment_id", "comment_id must be a valid UUID")
+			return
+		}
+
+		// 4) декодируем тело
+		var payload ReportCommentRequest
+		if err := jsondecoder.DecodeJSONBody(w, r, &payload); err != nil {
+			var mr *jsondecoder.MalformedRequest
+			if errors.As(err, &mr) {
+				logger.Warn("malformed request body", zap.Error(err))
+				writeError(w, r, mr.Status, "invalid_json", mr.Msg)
+				return
+			}
+			logger.Error("failed to decode JSON", zap.Error(err))
+			writeError(w, r, http.StatusBadRequest, "invalid_json", "cannot parse request body")
+			return
+		}
+		if _, err := uuid.Parse(payload.UserID); err != nil {
+			logger.Warn("invalid user_id", zap.String("user_id", payload.UserID), zap.Error(err))
+			writeError(w, r, http.StatusBadRequest, "invalid_user_id", "user_id is not a valid UUID")
+			return
+		}
+
+		// 5) бизнес-логика (причина и длина пояснения проверяются в usecase)
+		err := uc.ReportComment(ctx, entity.CommentReport{
+			CommentID: commentID,
+			GameID:    gameID,
+			UserID:    payload.UserID,
+			Reason:    payload.Reason,
+			Details:   payload.Details,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, entity.ErrInvalidReport):
+				writeError(w, r, http.StatusBadRequest, "invalid_report", err.Error())
+			case errors.Is(err, entity.ErrCommentNotFound):
+				writeError(w, r, http.StatusNotFound, "comment_not_found", "comment not found")
+			case errors.Is(err, entity.ErrDuplicateReport):
+				writeError(w, r, http.StatusConflict, "already_reported", "you have already reported this comment")
+			case errors.Is(ctx.Err(), context.DeadlineExceeded):
+				logger.Error("timeout reporting comment", zap.Error(err))
+				writeError(w, r, http.StatusGatewayTimeout, "timeout_exceeded", "request took longer than 2 seconds")
+			default:
+				logger.Error("failed to report comment", zap.Error(err))
+				writeError(w, r, http.StatusInternalServerError, "internal_error", "internal server error")
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, code, msg string) {
+	render.Status(r, status)
+	render.JSON(w, r, ErrorResponse{Error: APIError{code, msg}})
+}
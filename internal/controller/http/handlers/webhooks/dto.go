@@ -0,0 +1,40 @@
+package handlers
+
+import "github.com/RozmiDan/gameReviewHub/internal/entity"
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+	GameID     string   `json:"game_id,omitempty"`
+}
+
+type CreateWebhookResponse struct {
+	ID string `json:"id"`
+}
+
+type ListWebhooksResponse struct {
+	Data []entity.WebhookSubscription `json:"data"`
+}
+
+type Pagination struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+	Count  int   `json:"count"`
+}
+
+type ListDeliveriesResponse struct {
+	Data []entity.WebhookAttempt `json:"data"`
+	Meta *Pagination             `json:"meta,omitempty"`
+}
+
+// APIError — структура описания ошибки
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse — обёртка для не-200 ответов
+type ErrorResponse struct {
+	Error APIError `json:"error"`
+}
@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	jsondecoder "github.com/RozmiDan/gameReviewHub/pkg/json_decoder"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// /webhooks — подписки партнёров на доменные события
+
+type WebhookManager interface {
+	CreateWebhook(ctx context.Context, sub *entity.WebhookSubscription) (string, error)
+	ListWebhooks(ctx context.Context) ([]entity.WebhookSubscription, error)
+	GetWebhook(ctx context.Context, id string) (*entity.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	EnableWebhook(ctx context.Context, id string) error
+	ListWebhookAttempts(ctx context.Context, id string, limit, offset int32) ([]entity.WebhookAttempt, error)
+}
+
+// NewCreateWebhookHandler создаёт подписку.
+// @Summary     Создание подписки на вебхуки
+// @Description Подписка на события (GameCreated, CommentAdded, CommentDeleted — комментарий скрыт модерацией), опционально по одной игре.
+// @Description Нужен токен партнёра (Authorization: Bearer); подписки видны и управляются только их владельцем.
+// @Description URL должен вести на публичный адрес: loopback, частные сети и metadata облака отклоняются.
+// @Description Запросы подписываются HMAC-SHA256 секретом: X-GameHub-Signature = "sha256=" + hex(hmac(secret, timestamp + "." + body)).
+// @Tags        webhooks
+// @Accept      json
+// @Produce     json
+// @Param       body  body     CreateWebhookRequest  true  "URL, секрет, типы событий и game_id"
+// @Success     201   {object} CreateWebhookResponse "Подписка создана"
+// @Failure     400   {object} ErrorResponse         "Некорректный запрос"
+// @Failure     404   {object} ErrorResponse         "Игра не найдена"
+// @Failure     500   {object} ErrorResponse         "Внутренняя ошибка сервера"
+// @Failure     401   {object} ErrorResponse         "Нужен токен партнёра"
+// @Router      /webhooks [post]
+func NewCreateWebhookHandler(baseLogger *zap.Logger, uc WebhookManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// 1) request_id и таймаут
+		ctx, logger, cancel := requestScope(r, baseLogger, "CreateWebhookHandler")
+		defer cancel()
+
+		// 2) декодируем тело
+		var payload CreateWebhookRequest
+		if err := jsondecoder.DecodeJSONBody(w, r, &payload); err != nil {
+			var mr *jsondecoder.MalformedRequest
+			if errors.As(err, &mr) {
+				logger.Warn("malformed request body", zap.Error(err))
+				writeError(w, r, mr.Status, mr.Msg, mr.Msg)
+				return
+			}
+			logger.Error("failed to decode JSON", zap.Error(err))
+			writeError(w, r, http.StatusBadRequest, "invalid_json", "cannot parse request body")
+			return
+		}
+
+		// 3) бизнес-логика (валидация полей — в usecase)
+		id, err := uc.CreateWebhook(ctx, &entity.WebhookSubscription{
+			URL:        payload.URL,
+			Secret:     payload.Secret,
+			EventTypes: payload.EventTypes,
+			GameID:     payload.GameID,
+		})
+		if err != nil {
+			writeUsecaseError(w, r, logger, err)
+			return
+		}
+
+		w.Header().Set("Location", "/webhooks/"+id)
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, CreateWebhookResponse{ID: id})
+	}
+}
+
+// NewListWebhooksHandler возвращает подписки партнёра.
+// @Summary     Список подписок на вебхуки
+// @Tags        webhooks
+// @Produce     json
+// @Success     200  {object} ListWebhooksResponse
+// @Failure     500  {object} ErrorResponse "Внутренняя ошибка сервера"
+// @Failure     401  {object} ErrorResponse "Нужен токен партнёра"
+// @Router      /webhooks [get]
+func NewListWebhooksHandler(baseLogger *zap.Logger, uc WebhookManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, logger, cancel := requestScope(r, baseLogger, "ListWebhooksHandler")
+		defer cancel()
+
+		subs, err := uc.ListWebhooks(ctx)
+		if err != nil {
+			writeUsecaseError(w, r, logger, err)
+			return
+		}
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, ListWebhooksResponse{Data: subs})
+	}
+}
+
+// NewGetWebhookHandler возвращает подписку.
+// @Summary     Подписка на вебхуки
+// @Tags        webhooks
+// @Produce     json
+// @Param       webhook_id  path     string  true  "UUID подписки"
+// @Success     200         {object} entity.WebhookSubscription
+// @Failure     400         {object} ErrorResponse "Некорректный webhook_id"
+// @Failure     404         {object} ErrorResponse "Подписка не найдена"
+// @Failure     401         {object} ErrorResponse "Нужен токен партнёра"
+// @Router      /webhooks/{webhook_id} [get]
+func NewGetWebhookHandler(baseLogger *zap.Logger, uc WebhookManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, logger, cancel := requestScope(r, baseLogger, "GetWebhookHandler")
+		defer cancel()
+
+		id, ok := webhookID(w, r, logger)
+		if !ok {
+			return
+		}
+		sub, err := uc.GetWebhook(ctx, id)
+		if err != nil {
+			writeUsecaseError(w, r, logger, err)
+			return
+		}
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, sub)
+	}
+}
+
+// NewDeleteWebhookHandler удаляет подписку вместе с очередью и журналом доставок.
+// @Summary     Удаление подписки
+// @Tags        webhooks
+// @Param       webhook_id  path  string  true  "UUID подписки"
+// @Success     204
+// @Failure     404  {object} ErrorResponse "Подписка не найдена"
+// @Failure     401  {object} ErrorResponse "Нужен токен партнёра"
+// @Router      /webhooks/{webhook_id} [delete]
+func NewDeleteWebhookHandler(baseLogger *zap.Logger, uc WebhookManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, logger, cancel := requestScope(r, baseLogger, "DeleteWebhookHandler")
+		defer cancel()
+
+		id, ok := webhookID(w, r, logger)
+		if !ok {
+			return
+		}
+		if err := uc.DeleteWebhook(ctx, id); err != nil {
+			writeUsecaseError(w, r, logger, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// NewEnableWebhookHandler включает подписку после автоматического отключения.
+// @Summary     Повторное включение подписки
+// @Tags        webhooks
+// @Param       webhook_id  path  string  true  "UUID подписки"
+// @Success     204
+// @Failure     404  {object} ErrorResponse "Подписка не найдена"
+// @Failure     401  {object} ErrorResponse "Нужен токен партнёра"
+// @Router      /webhooks/{webhook_id}/enable [post]
+func NewEnableWebhookHandler(baseLogger *zap.Logger, uc WebhookManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, logger, cancel := requestScope(r, baseLogger, "EnableWebhookHandler")
+		defer cancel()
+
+		id, ok := webhookID(w, r, logger)
+		if !ok {
+			return
+		}
+		if err := uc.EnableWebhook(ctx, id); err != nil {
+			writeUsecaseError(w, r, logger, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// NewListDeliveriesHandler отдаёт журнал попыток доставки, новые сверху.
+// @Summary     Журнал доставок вебхука
+// @Tags        webhooks
+// @Produce     json
+// @Param       webhook_id  path     string  true   "UUID подписки"
+// @Param       limit       query    int     false  "Размер страницы (по умолчанию 20)"
+// @Param       offset      query    int     false  "Номер страницы (с 0)"
+// @Success     200         {object} ListDeliveriesResponse
+// @Failure     400         {object} ErrorResponse "Некорректные параметры"
+// @Failure     404         {object} ErrorResponse "Подписка не найдена"
+// @Failure     401         {object} ErrorResponse "Нужен токен партнёра"
+// @Router      /webhooks/{webhook_id}/deliveries [get]
+func NewListDeliveriesHandler(baseLogger *zap.Logger, uc WebhookManager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, logger, cancel := requestScope(r, baseLogger, "ListDeliveriesHandler")
+		defer cancel()
+
+		id, ok := webhookID(w, r, logger)
+		if !ok {
+			return
+		}
+
+		limit, offset := int32(20), int32(0)
+		q := r.URL.Query()
+		if s := q.Get("limit"); s != "" {
+			v, err := strconv.Atoi(s)
+			if err != nil || v <= 0 || v > 100 {
+				writeError(w, r, http.StatusBadRequest, "invalid_limit", "limit must be between 1 and 100")
+				return
+			}
+			limit = int32(v)
+		}
+		if s := q.Get("offset"); s != "" {
+			v, err := strconv.Atoi(s)
+			if err != nil || v < 0 {
+				writeError(w, r, http.StatusBadRequest, "invalid_offset", "offset must be >= 0")
+				return
+			}
+			offset = int32(v)
+		}
+
+		attempts, err := uc.ListWebhookAttempts(ctx, id, limit, offset)
+		if err != nil {
+			writeUsecaseError(w, r, logger, err)
+			return
+		}
+		render.Status(r, http.StatusOK)
+		render.JSON(w, r, ListDeliveriesResponse{
+			Data: attempts,
+			Meta: &Pagination{Limit: limit, Offset: offset, Count: len(attempts)},
+		})
+	}
+}
+
+// requestScope — request_id в ctx, таймаут и логгер обработчика
+func requestScope(r *http.Request, baseLogger *zap.Logger, handler string) (context.Context, *zap.Logger, context.CancelFunc) {
+	reqID := middleware.GetReqID(r.Context())
+	ctx := context.WithValue(r.Context(), entity.RequestIDKey{}, reqID)
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	logger := baseLogger.With(zap.String("handler", handler), zap.String("request_id", reqID))
+	return ctx, logger, cancel
+}
+
+func webhookID(w http.ResponseWriter, r *http.Request, logger *zap.Logger) (string, bool) {
+	id := chi.URLParam(r, "webhook_id")
+	if _, err := uuid.Parse(id); err != nil {
+		logger.Warn("invalid webhook_id", zap.String("webhook_id", id))
+		writeError(w, r, http.StatusBadRequest, "invalid_webhook_id", "webhook_id must be a valid UUID")
+		return "", false
+	}
+	return id, true
+}
+
+func writeUsecaseError(w http.ResponseWriter, r *http.Request, logger *zap.Logger, err error) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidWebhook):
+		logger.Info("invalid webhook", zap.Error(err))
+		writeError(w, r, http.StatusBadRequest, "invalid_webhook", err.Error())
+	case errors.Is(err, entity.ErrPartnerRequired):
+		writeError(w, r, http.StatusUnauthorized, "unauthorized", "partner token required")
+	case errors.Is(err, entity.ErrWebhookNotFound):
+		writeError(w, r, http.StatusNotFound, "not_found", "webhook not found")
+	case errors.Is(err, entity.ErrGameNotFound):
+		writeError(w, r, http.StatusNotFound, "not_found", "game not found")
+	case errors.Is(err, context.DeadlineExceeded):
+		logger.Error("timeout exceeded", zap.Error(err))
+		writeError(w, r, http.StatusGatewayTimeout, "timeout_exceeded", "request took longer than 2 seconds")
+	default:
+		logger.Error("webhook request failed", zap.Error(err))
+		writeError(w, r, http.StatusInternalServerError, "internal_error", "internal server error")
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, code, msg string) {
+	render.Status(r, status)
+	render.JSON(w, r, ErrorResponse{Error: APIError{code, msg}})
+}
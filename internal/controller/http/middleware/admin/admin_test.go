@@ -0,0 +1,90 @@
+package middleware_admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestIdentify(t *testing.T) {
+	for name, tc := range map[string]struct {
+		auth string
+		want string
+	}{
+		"valid":        {"Bearer secret-b", "bob"},
+		"case":         {"bearer secret-a", "alice"},
+		"wrong token":  {"Bearer nope", ""},
+		"empty token":  {"Bearer ", ""},
+		"other scheme": {"Basic secret-a", ""},
+		"no header":    {"", ""},
+	} {
+		var seen string
+		inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen, _ = r.Context().Value(entity.ModeratorKey{}).(string)
+		})
+		h := Identify(zap.NewNop(), map[string]string{"alice": "secret-a", "bob": "secret-b", "empty": ""})(inner)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tc.auth != "" {
+			req.Header.Set("Authorization", tc.auth)
+		}
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		require.Equal(t, tc.want, seen, name)
+	}
+}
+
+func TestRequire(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	h := Identify(zap.NewNop(), map[string]string{"alice": "secret-a"})(Require(ok))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Contains(t, rec.Body.String(), "unauthorized")
+	require.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer secret-a")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestRequirePartner(t *testing.T) {
+	var seen string
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = r.Context().Value(entity.PartnerKey{}).(string)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	// модераторский токен партнёрское API не открывает
+	h := Identify(zap.NewNop(), map[string]string{"alice": "secret-a"})(
+		RequirePartner(zap.NewNop(), map[string]string{"acme": "partner-1"})(inner))
+
+	for name, tc := range map[string]struct {
+		auth string
+		code int
+	}{
+		"no header":       {"", http.StatusUnauthorized},
+		"wrong token":     {"Bearer nope", http.StatusUnauthorized},
+		"moderator token": {"Bearer secret-a", http.StatusUnauthorized},
+		"partner":         {"Bearer partner-1", http.StatusNoContent},
+	} {
+		seen = ""
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tc.auth != "" {
+			req.Header.Set("Authorization", tc.auth)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		require.Equal(t, tc.code, rec.Code, name)
+		if tc.code == http.StatusNoContent {
+			require.Equal(t, "acme", seen)
+		}
+	}
+}
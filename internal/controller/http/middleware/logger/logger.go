@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/RozmiDan/gameReviewHub/pkg/tracing"
 	"github.com/go-chi/chi/middleware"
 	"go.uber.org/zap"
 )
@@ -20,6 +21,9 @@ func MyLogger(log *zap.Logger) func(next http.Handler) http.Handler {
 				zap.String("user_agent", r.UserAgent()),
 				zap.String("request_id", middleware.GetReqID(r.Context())),
 			)
+			if traceID := tracing.TraceID(r.Context()); traceID != "" {
+				curLog = curLog.With(zap.String("trace_id", traceID))
+			}
 			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
 			t1 := time.Now()
 
@@ -3,15 +3,18 @@ package middleware_metrics
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	prom_metrics "github.com/RozmiDan/gameReviewHub/pkg/metrics"
+	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
-	"github.com/prometheus/client_golang/prometheus"
 )
 
+// запросы мимо всех маршрутов (404 на случайные пути) — одной серией
+const unmatchedRoute = "unmatched"
+
 func PrometheusMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		//path := r.URL.Path
 		method := r.Method
 
 		rw := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
@@ -19,17 +22,30 @@ func PrometheusMiddleware(next http.Handler) http.Handler {
 		prom_metrics.HTTPInFlight.WithLabelValues(method).Inc()
 		defer prom_metrics.HTTPInFlight.WithLabelValues(method).Dec()
 
-		histTimer := prometheus.NewTimer(prom_metrics.HTTPDuration.WithLabelValues(method))
-		defer func() {
-			histTimer.ObserveDuration()
-		}()
-
-		// timer := prometheus.NewTimer(prom_metrics.HTTPDuration.WithLabelValues(method, path))
-		// defer timer.ObserveDuration()
-
+		start := time.Now()
 		next.ServeHTTP(rw, r)
 
-		status := strconv.Itoa(rw.Status())
-		prom_metrics.HTTPRequests.WithLabelValues(method, status).Inc()
+		// шаблон маршрута известен только после роутинга
+		route := routePattern(r)
+		prom_metrics.HTTPDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+
+		status := rw.Status()
+		if status == 0 {
+			// хендлер ничего не записал — net/http ответит 200
+			status = http.StatusOK
+		}
+		prom_metrics.HTTPRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
 	})
 }
+
+func routePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return unmatchedRoute
+	}
+	route := rctx.RoutePattern()
+	if route == "" {
+		return unmatchedRoute
+	}
+	return route
+}
@@ -0,0 +1,41 @@
+package middleware_metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	prom_metrics "github.com/RozmiDan/gameReviewHub/pkg/metrics"
+	"github.com/go-chi/chi"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusMiddleware_RouteLabel(t *testing.T) {
+	prom_metrics.Init()
+
+	router := chi.NewRouter()
+	router.Use(PrometheusMiddleware)
+	router.Get("/games/{game_id}", func(w http.ResponseWriter, r *http.Request) {})
+	router.Post("/games/{game_id}/comments", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	for _, path := range []string{"/games/1", "/games/2", "/games/3"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/games/1/comments", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/no/such/path", nil))
+
+	// разные id — одна серия
+	require.Equal(t, 3.0, testutil.ToFloat64(
+		prom_metrics.HTTPRequests.WithLabelValues(http.MethodGet, "/games/{game_id}", "200")))
+	require.Equal(t, 1.0, testutil.ToFloat64(
+		prom_metrics.HTTPRequests.WithLabelValues(http.MethodPost, "/games/{game_id}/comments", "201")))
+	require.Equal(t, 1.0, testutil.ToFloat64(
+		prom_metrics.HTTPRequests.WithLabelValues(http.MethodGet, unmatchedRoute, "404")))
+
+	// сырые пути в метки не попадают
+	require.Equal(t, 3, testutil.CollectAndCount(prom_metrics.HTTPRequests))
+	require.Equal(t, 3, testutil.CollectAndCount(prom_metrics.HTTPDuration))
+}
@@ -0,0 +1,307 @@
+package middleware_ratelimit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	prom_metrics "github.com/RozmiDan/gameReviewHub/pkg/metrics"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"go.uber.org/zap"
+)
+
+// кем считается клиент. user_id из тела и X-API-Key никто не проверяет — клиент может менять их
+// на каждом запросе, поэтому по ним лимит только дробится, а сверху всегда действует лимит на IP
+// (см. PerIPFactor). Проверенный партнёр (entity.PartnerKey от RequirePartner) — единственный
+// принципал, которому верим без IP-лимита.
+const (
+	ByUser   = "user"    // user_id из JSON-тела; без него — API-ключ, затем IP
+	ByAPIKey = "api_key" // партнёр из ctx, затем X-API-Key; без них — IP
+	ByIP     = "ip"
+)
+
+// лимит на IP для групп с непроверенным принципалом — во столько раз больше лимита принципала:
+// пользователям за одним NAT хватает, а перебор user_id с одного адреса упирается в него
+const defaultPerIPFactor = 5
+
+// тело больше этого не читаем в поисках user_id — лимит считается по запасному признаку
+const maxPeekBody = 1 << 20
+
+// Store — общее для всех реплик хранилище лимитов (Redis)
+type Store interface {
+	AllowRate(ctx context.Context, key string, limit entity.RateLimit) (entity.RateLimitResult, error)
+}
+
+// Rule — лимит группы маршрутов
+type Rule struct {
+	Limit entity.RateLimit
+	By    string
+}
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type errorResponse struct {
+	Error apiError `json:"error"`
+}
+
+// Limiter раздаёт middleware по группам маршрутов
+type Limiter struct {
+	store      Store
+	logger     *zap.Logger
+	rules      map[string]Rule
+	trustProxy bool
+	failOpen   bool
+	ipFactor   int
+}
+
+type Option func(*Limiter)
+
+// WithRule задаёт лимит группы; группа без правила не ограничивается
+func WithRule(group string, rule Rule) Option {
+	return func(l *Limiter) {
+		if rule.Limit.Requests > 0 && rule.Limit.Period > 0 {
+			l.rules[group] = rule
+		}
+	}
+}
+
+// TrustProxy — брать IP клиента из X-Forwarded-For / X-Real-IP (сервис за nginx)
+func TrustProxy(trust bool) Option {
+	return func(l *Limiter) {
+		l.trustProxy = trust
+	}
+}
+
+// FailOpen — пропускать запросы, если хранилище лимитов недоступно
+func FailOpen(open bool) Option {
+	return func(l *Limiter) {
+		l.failOpen = open
+	}
+}
+
+// PerIPFactor — во сколько раз лимит на IP больше лимита пользователя/ключа в той же группе
+func PerIPFactor(n int) Option {
+	return func(l *Limiter) {
+		if n > 0 {
+			l.ipFactor = n
+		}
+	}
+}
+
+func New(store Store, logger *zap.Logger, opts ...Option) *Limiter {
+	l := &Limiter{
+		store:    store,
+		logger:   logger.With(zap.String("component", "middleware/ratelimit")),
+		rules:    make(map[string]Rule),
+		failOpen: true,
+		ipFactor: defaultPerIPFactor,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	for group, rule := range l.rules {
+		l.logger.Info("rate limit configured",
+			zap.String("group", group),
+			zap.Int("requests", rule.Limit.Requests),
+			zap.Duration("period", rule.Limit.Period),
+			zap.String("by", rule.By),
+		)
+	}
+	return l
+}
+
+// Group — middleware для группы маршрутов. Вешать через r.With на конечные маршруты:
+// так в метрику попадает полный шаблон маршрута.
+func (l *Limiter) Group(group string) func(next http.Handler) http.Handler {
+	rule, ok := l.rules[group]
+	if !ok {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	ipLimit := entity.RateLimit{Requests: rule.Limit.Requests * l.ipFactor, Period: rule.Limit.Period}
+	if rule.By == ByIP {
+		ipLimit = rule.Limit
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// 1) кто пришёл: лимит на IP — всегда, на принципала — если он не сам IP
+			ipKey := group + ":ip:" + l.clientIP(r)
+			principal, verified := l.principal(r, rule.By)
+			buckets := make([]bucket, 0, 2)
+			if !verified {
+				buckets = append(buckets, bucket{ipKey, ipLimit})
+			}
+			if key := group + ":" + principal; key != ipKey {
+				buckets = append(buckets, bucket{key, rule.Limit})
+			}
+
+			// 2) списываем запрос: первый исчерпанный лимит — отказ, иначе в заголовки — самый строгий
+			var res entity.RateLimitResult
+			var limit entity.RateLimit
+			for i, b := range buckets {
+				bres, err := l.store.AllowRate(r.Context(), b.key, b.limit)
+				if err != nil {
+					logger := l.logger.With(zap.String("group", group), zap.Error(err))
+					if reqID, _ := r.Context().Value(entity.RequestIDKey{}).(string); reqID != "" {
+						logger = logger.With(zap.String("request_id", reqID))
+					}
+					if l.failOpen {
+						logger.Warn("rate limit store unavailable, request allowed")
+						next.ServeHTTP(w, r)
+						return
+					}
+					logger.Error("rate limit store unavailable, request rejected")
+					render.Status(r, http.StatusServiceUnavailable)
+					render.JSON(w, r, errorResponse{
+						Error: apiError{"unavailable", "service temporarily unavailable"},
+					})
+					return
+				}
+				if i == 0 || !bres.Allowed || bres.Remaining < res.Remaining {
+					res, limit = bres, b.limit
+				}
+				if !bres.Allowed {
+					break
+				}
+			}
+
+			// 3) заголовки — и на успешных ответах, чтобы клиент видел остаток
+			h := w.Header()
+			h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.ResetAfter)))
+			h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Requests, ceilSeconds(limit.Period)))
+
+			if res.Allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// 4) отказ
+			if prom_metrics.RateLimitRejected != nil {
+				prom_metrics.RateLimitRejected.WithLabelValues(group, routePattern(r)).Inc()
+			}
+			h.Set("Retry-After", strconv.Itoa(max(ceilSeconds(res.RetryAfter), 1)))
+			render.Status(r, http.StatusTooManyRequests)
+			render.JSON(w, r, errorResponse{
+				Error: apiError{"rate_limited", "too many requests, retry later"},
+			})
+		})
+	}
+}
+
+type bucket struct {
+	key   string
+	limit entity.RateLimit
+}
+
+// principal — ключ клиента в группе; verified — принципал подтверждён аутентификацией
+func (l *Limiter) principal(r *http.Request, by string) (string, bool) {
+	switch by {
+	case ByUser:
+		if id := peekUserID(r); id != "" {
+			return "user:" + id, false
+		}
+		fallthrough
+	case ByAPIKey:
+		if name, _ := r.Context().Value(entity.PartnerKey{}).(string); name != "" {
+			return "partner:" + name, true
+		}
+		if k := r.Header.Get("X-API-Key"); k != "" {
+			// сам ключ в Redis не кладём
+			sum := sha256.Sum256([]byte(k))
+			return "key:" + hex.EncodeToString(sum[:8]), false
+		}
+	}
+	return "ip:" + l.clientIP(r), false
+}
+
+// peekUserID достаёт user_id из JSON-тела и возвращает тело на место для хендлера
+func peekUserID(r *http.Request) string {
+	if r.Body == nil || r.Body == http.NoBody {
+		return ""
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPeekBody+1))
+	r.Body = readCloser{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+	if err != nil || len(body) > maxPeekBody {
+		return ""
+	}
+	var v struct {
+		UserID string `json:"user_id"`
+	}
+	if json.Unmarshal(body, &v) != nil {
+		return ""
+	}
+	return strings.TrimSpace(v.UserID)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func (l *Limiter) clientIP(r *http.Request) string {
+	if l.trustProxy {
+		// nginx дописывает адрес клиента в конец X-Forwarded-For, а начало цепочки клиент может подделать —
+		// поэтому берём последний адрес
+		if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+			parts := strings.Split(xff, ",")
+			if ip := strings.TrimSpace(parts[len(parts)-1]); ip != "" {
+				return ip
+			}
+		}
+		if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); ip != "" {
+			return ip
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if p := rctx.RoutePattern(); p != "" {
+			return p
+		}
+	}
+	return "unknown"
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// ParseLimit разбирает "N/период": "10/1m", "100/1h", "5/30s"
+func ParseLimit(s string) (entity.RateLimit, error) {
+	n, period, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return entity.RateLimit{}, fmt.Errorf("rate limit %q: want N/period", s)
+	}
+	requests, err := strconv.Atoi(strings.TrimSpace(n))
+	if err != nil || requests <= 0 {
+		return entity.RateLimit{}, fmt.Errorf("rate limit %q: bad request count", s)
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || d <= 0 {
+		return entity.RateLimit{}, fmt.Errorf("rate limit %q: bad period", s)
+	}
+	return entity.RateLimit{Requests: requests, Period: d}, nil
+}
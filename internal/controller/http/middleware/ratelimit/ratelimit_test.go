@@ -0,0 +1,198 @@
+package middleware_ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeStore — фиксированное окно без времени: первые Requests запросов по ключу проходят
+type fakeStore struct {
+	hits map[string]int
+	keys []string
+	err  error
+}
+
+func (f *fakeStore) AllowRate(ctx context.Context, key string, limit entity.RateLimit) (entity.RateLimitResult, error) {
+	if f.err != nil {
+		return entity.RateLimitResult{}, f.err
+	}
+	f.keys = append(f.keys, key)
+	f.hits[key]++
+	n := f.hits[key]
+	if n > limit.Requests {
+		return entity.RateLimitResult{Limit: limit.Requests, RetryAfter: 1500 * time.Millisecond, ResetAfter: limit.Period}, nil
+	}
+	return entity.RateLimitResult{Allowed: true, Limit: limit.Requests, Remaining: limit.Requests - n, ResetAfter: limit.Period}, nil
+}
+
+func newLimiter(store Store, opts ...Option) *Limiter {
+	opts = append([]Option{
+		WithRule("comments", Rule{Limit: entity.RateLimit{Requests: 2, Period: time.Minute}, By: ByUser}),
+		WithRule("reads", Rule{Limit: entity.RateLimit{Requests: 100, Period: time.Minute}, By: ByIP}),
+	}, opts...)
+	return New(store, zap.NewNop(), opts...)
+}
+
+func post(h http.Handler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/games/g1/comments", strings.NewReader(body))
+	req.RemoteAddr = "10.0.0.1:5555"
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGroup_LimitsAndHeaders(t *testing.T) {
+	store := &fakeStore{hits: map[string]int{}}
+	var bodies []string
+	h := newLimiter(store).Group("comments")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	body := `{"user_id":"u1","text":"hi"}`
+	rec := post(h, body)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "1", rec.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "60", rec.Header().Get("RateLimit-Reset"))
+	require.Equal(t, "2;w=60", rec.Header().Get("RateLimit-Policy"))
+	// хендлер получил тело целиком
+	require.Equal(t, []string{body}, bodies)
+
+	require.Equal(t, http.StatusCreated, post(h, body).Code)
+
+	rec = post(h, body)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "2", rec.Header().Get("Retry-After"))
+	require.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	require.Contains(t, rec.Body.String(), "rate_limited")
+
+	// у другого пользователя свой лимит, хотя IP тот же
+	require.Equal(t, http.StatusCreated, post(h, `{"user_id":"u2"}`).Code)
+	require.Equal(t, 3, store.hits["comments:user:u1"])
+	require.Equal(t, 1, store.hits["comments:user:u2"])
+	// общий лимит на IP списывается с каждого запроса
+	require.Equal(t, 4, store.hits["comments:ip:10.0.0.1"])
+}
+
+func TestGroup_RotatingUserIDLimitedByIP(t *testing.T) {
+	store := &fakeStore{hits: map[string]int{}}
+	h := newLimiter(store, PerIPFactor(3)).Group("comments")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	// новый user_id на каждый запрос — свой лимит пользователя не кончается, но IP один: 2*3 запроса
+	for i := 0; i < 6; i++ {
+		rec := post(h, fmt.Sprintf(`{"user_id":"bot-%d"}`, i))
+		require.Equal(t, http.StatusCreated, rec.Code, i)
+	}
+	rec := post(h, `{"user_id":"bot-6"}`)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "6", rec.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "6;w=60", rec.Header().Get("RateLimit-Policy"))
+	// отказ по IP не расходует лимит пользователя
+	require.Zero(t, store.hits["comments:user:bot-6"])
+}
+
+func TestGroup_VerifiedPartnerNotLimitedByIP(t *testing.T) {
+	store := &fakeStore{hits: map[string]int{}}
+	l := newLimiter(store, PerIPFactor(1),
+		WithRule("writes", Rule{Limit: entity.RateLimit{Requests: 2, Period: time.Minute}, By: ByAPIKey}))
+	h := l.Group("writes")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	send := func(partner string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", nil)
+		req.RemoteAddr = "10.0.0.1:5555"
+		req = req.WithContext(context.WithValue(req.Context(), entity.PartnerKey{}, partner))
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// два партнёра за одним адресом считаются раздельно
+	for _, p := range []string{"acme", "acme", "globex", "globex"} {
+		require.Equal(t, http.StatusNoContent, send(p))
+	}
+	require.Equal(t, http.StatusTooManyRequests, send("acme"))
+	require.Zero(t, store.hits["writes:ip:10.0.0.1"])
+}
+
+func TestPrincipal(t *testing.T) {
+	l := newLimiter(&fakeStore{hits: map[string]int{}}, TrustProxy(true))
+	principal := func(r *http.Request, by string) string {
+		p, verified := l.principal(r, by)
+		require.False(t, verified)
+		return p
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`not json`))
+	req.RemoteAddr = "10.0.0.1:5555"
+	req.Header.Set("X-Forwarded-For", "1.1.1.1, 203.0.113.7")
+	require.Equal(t, "ip:203.0.113.7", principal(req, ByUser))
+
+	req.Header.Set("X-API-Key", "partner-secret")
+	key := principal(req, ByUser)
+	require.True(t, strings.HasPrefix(key, "key:"))
+	require.NotContains(t, key, "partner-secret")
+	require.Equal(t, key, principal(req, ByAPIKey))
+	require.Equal(t, "ip:203.0.113.7", principal(req, ByIP))
+
+	// без TrustProxy заголовкам не верим
+	l = newLimiter(&fakeStore{hits: map[string]int{}})
+	require.Equal(t, "ip:10.0.0.1", principal(req, ByIP))
+
+	// партнёр из RequirePartner важнее заголовка
+	req = req.WithContext(context.WithValue(req.Context(), entity.PartnerKey{}, "acme"))
+	p, verified := l.principal(req, ByAPIKey)
+	require.Equal(t, "partner:acme", p)
+	require.True(t, verified)
+}
+
+func TestGroup_StoreDown(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	store := &fakeStore{err: errors.New("redis down")}
+
+	rec := post(newLimiter(store).Group("comments")(ok), `{"user_id":"u1"}`)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Empty(t, rec.Header().Get("RateLimit-Limit"))
+
+	rec = post(newLimiter(store, FailOpen(false)).Group("comments")(ok), `{"user_id":"u1"}`)
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestGroup_WithoutRule(t *testing.T) {
+	store := &fakeStore{hits: map[string]int{}}
+	h := newLimiter(store).Group("ratings")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	for i := 0; i < 5; i++ {
+		require.Equal(t, http.StatusNoContent, post(h, `{"user_id":"u1"}`).Code)
+	}
+	require.Empty(t, store.keys)
+}
+
+func TestParseLimit(t *testing.T) {
+	l, err := ParseLimit("10/1m")
+	require.NoError(t, err)
+	require.Equal(t, entity.RateLimit{Requests: 10, Period: time.Minute}, l)
+
+	for _, s := range []string{"", "10", "0/1m", "x/1m", "10/", "10/abc", "10/-1s"} {
+		_, err := ParseLimit(s)
+		require.Error(t, err, s)
+	}
+}
@@ -0,0 +1,46 @@
+package httpserver
+
+import (
+	health "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/health"
+	loglevel "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/loglevel"
+	ratingstream "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/ratingstream"
+	middleware_ratelimit "github.com/RozmiDan/gameReviewHub/internal/controller/http/middleware/ratelimit"
+)
+
+// Option — необязательные зависимости сервера, не входящие в usecase
+type Option func(*options)
+
+type options struct {
+	ratings ratingstream.RatingHub
+	limiter *middleware_ratelimit.Limiter
+	ready   health.ReadinessChecker
+	level   loglevel.LevelController
+}
+
+// WithRatingStream включает WebSocket /ws/ratings
+func WithRatingStream(hub ratingstream.RatingHub) Option {
+	return func(o *options) {
+		o.ratings = hub
+	}
+}
+
+// WithRateLimiter включает лимиты запросов по группам маршрутов
+func WithRateLimiter(l *middleware_ratelimit.Limiter) Option {
+	return func(o *options) {
+		o.limiter = l
+	}
+}
+
+// WithReadiness включает /readyz с проверкой зависимостей
+func WithReadiness(checker health.ReadinessChecker) Option {
+	return func(o *options) {
+		o.ready = checker
+	}
+}
+
+// WithLogLevel включает /admin/log-level для смены уровня логов на лету
+func WithLogLevel(level loglevel.LevelController) Option {
+	return func(o *options) {
+		o.level = level
+	}
+}
@@ -10,13 +10,23 @@ import (
 
 	_ "github.com/RozmiDan/gameReviewHub/docs"
 	addcomment "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/addcomment"
+	commentstream "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/commentstream"
 	creategametopic "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/creategametopic"
+	export "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/export"
 	gametopic "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/gametopic"
+	health "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/health"
 	listcomments "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/listcomments"
+	loglevel "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/loglevel"
 	mainpage "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/mainpage"
+	moderation "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/moderation"
 	postrating "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/postrating"
+	ratingstream "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/ratingstream"
+	reportcomment "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/reportcomment"
+	webhooks "github.com/RozmiDan/gameReviewHub/internal/controller/http/handlers/webhooks"
+	middleware_admin "github.com/RozmiDan/gameReviewHub/internal/controller/http/middleware/admin"
 	middleware_logger "github.com/RozmiDan/gameReviewHub/internal/controller/http/middleware/logger"
 	middleware_metrics "github.com/RozmiDan/gameReviewHub/internal/controller/http/middleware/metrics"
+	middleware_tracing "github.com/RozmiDan/gameReviewHub/internal/controller/http/middleware/tracing"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
@@ -32,19 +42,46 @@ type GameUseCase interface {
 	PostRating(ctx context.Context, gameID, userID string, rating int32) error
 
 	GetListComments(ctx context.Context, gameID string, limit, offset int32) ([]entity.Comment, error)
-	AddComment(ctx context.Context, gameID, userID, text string) (string, error)
+	AddComment(ctx context.Context, gameID, userID, text string) (*entity.Comment, error)
+	StreamComments(ctx context.Context, gameID, lastEventID string) (<-chan entity.Comment, error)
+	ReportComment(ctx context.Context, rep entity.CommentReport) error
+
+	export.GamesExporter
+	export.CommentsExporter
+
+	webhooks.WebhookManager
+	moderation.Moderator
 }
 
-func InitServer(cnfg *config.Config, logger *zap.Logger, uc GameUseCase) *http.Server {
+func InitServer(cnfg *config.Config, logger *zap.Logger, uc GameUseCase, opts ...Option) *http.Server {
 	logger = logger.With(zap.String("layer", "mainController"))
 
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	// лимиты вешаются на конечные маршруты: в метрику отказов попадает полный шаблон маршрута
+	limit := func(group string) func(http.Handler) http.Handler {
+		if o.limiter == nil {
+			return func(next http.Handler) http.Handler { return next }
+		}
+		return o.limiter.Group(group)
+	}
+
+	// закрывается в Shutdown: долгоживущие потоки (SSE, WebSocket) сами по себе не завершатся
+	streamsDone := make(chan struct{})
+
 	router := chi.NewRouter()
 
 	router.Use(middleware.RequestID)
 	router.Use(middleware.Recoverer)
+	router.Use(middleware_tracing.Trace)
 	router.Use(middleware.URLFormat)
 	router.Use(middleware_metrics.PrometheusMiddleware)
 	router.Use(middleware_logger.MyLogger(logger))
+	// модератор в ctx: ему GET /games/{game_id}/comments отдаёт и скрытые комментарии
+	router.Use(middleware_admin.Identify(logger, cnfg.Admin.Moderators))
 
 	// router.Use(cors.Handler(cors.Options{
 	// 	AllowedOrigins:   []string{"*"},
@@ -57,30 +94,103 @@ func InitServer(cnfg *config.Config, logger *zap.Logger, uc GameUseCase) *http.S
 	router.Get("/swagger/*", httpSwagger.WrapHandler)
 	router.Handle("/metrics", promhttp.Handler())
 
+	// пробы для nginx и compose; без лимитов
+	router.Get("/healthz", health.NewLivenessHandler())
+	if o.ready != nil {
+		router.Get("/readyz", health.NewReadinessHandler(o.ready))
+	}
+
 	router.Route("/games", func(r chi.Router) {
 		// GET  /games?limit=&offset=
-		r.Get("/", mainpage.NewMainpageHandler(logger, uc))
+		r.With(limit("reads")).Get("/", mainpage.NewMainpageHandler(logger, uc))
 
 		// 2) POST /games   — создаём новую игру
-		r.Post("/", creategametopic.NewCreateGameHandler(logger, uc))
+		r.With(limit("writes")).Post("/", creategametopic.NewCreateGameHandler(logger, uc))
+
+		// GET  /games/export?format=csv|ndjson&limit=&offset= — выгрузка каталога потоком
+		r.With(limit("exports")).Get("/export", export.NewExportGamesHandler(logger, uc, cnfg.HttpInfo.Timeout))
 
 		// для game_id
 		r.Route("/{game_id}", func(r chi.Router) {
 			// GET   /games/{game_id}
-			r.Get("/", gametopic.NewGameTopicHandler(logger, uc))
+			r.With(limit("reads")).Get("/", gametopic.NewGameTopicHandler(logger, uc))
 
 			// POST  /games/{game_id}/rating
-			r.Post("/rating", postrating.NewRatingPostHandler(logger, uc))
+			r.With(limit("ratings")).Post("/rating", postrating.NewRatingPostHandler(logger, uc))
 
 			r.Route("/comments", func(r chi.Router) {
 				// GET  /games/{game_id}/comments?limit=&offset=
-				r.Get("/", listcomments.NewListCommentsHandler(logger, uc))
+				r.With(limit("reads")).Get("/", listcomments.NewListCommentsHandler(logger, uc))
 				// POST /games/{game_id}/comments
-				r.Post("/", addcomment.NewAddCommentHandler(logger, uc))
+				r.With(limit("comments")).Post("/", addcomment.NewAddCommentHandler(logger, uc))
+				// GET  /games/{game_id}/comments/stream — SSE
+				if cnfg.Comments.StreamEnabled {
+					r.With(limit("reads")).Get("/stream", commentstream.NewCommentStreamHandler(logger, uc,
+						cnfg.Comments.StreamHeartbeat, streamsDone))
+				}
+				// GET  /games/{game_id}/comments/export?format=csv|ndjson
+				r.With(limit("exports")).Get("/export", export.NewExportCommentsHandler(logger, uc, cnfg.HttpInfo.Timeout))
+				// POST /games/{game_id}/comments/{comment_id}/reports
+				r.With(limit("reports")).Post("/{comment_id}/reports", reportcomment.NewReportCommentHandler(logger, uc))
 			})
 		})
 	})
 
+	// WebSocket с обновлениями рейтингов: GET /ws/ratings?game_ids=&top=
+	if cnfg.Ratings.Enabled && o.ratings != nil {
+		router.With(limit("reads")).Get("/ws/ratings", ratingstream.NewRatingStreamHandler(logger, o.ratings, ratingstream.Config{
+			PingInterval:   cnfg.Ratings.PingInterval,
+			WriteTimeout:   cnfg.Ratings.WriteTimeout,
+			AllowedOrigins: cnfg.Ratings.AllowedOrigins,
+		}, streamsDone))
+	}
+
+	if cnfg.Webhooks.Enabled {
+		router.Route("/webhooks", func(r chi.Router) {
+			// партнёрское API: только по токену партнёра, каждый видит лишь свои подписки
+			r.Use(middleware_admin.RequirePartner(logger, cnfg.Webhooks.Partners))
+			r.With(limit("writes")).Get("/", webhooks.NewListWebhooksHandler(logger, uc))
+			r.With(limit("writes")).Post("/", webhooks.NewCreateWebhookHandler(logger, uc))
+
+			r.Route("/{webhook_id}", func(r chi.Router) {
+				r.With(limit("writes")).Get("/", webhooks.NewGetWebhookHandler(logger, uc))
+				r.With(limit("writes")).Delete("/", webhooks.NewDeleteWebhookHandler(logger, uc))
+				// POST /webhooks/{webhook_id}/enable — включить после автоотключения
+				r.With(limit("writes")).Post("/enable", webhooks.NewEnableWebhookHandler(logger, uc))
+				// GET  /webhooks/{webhook_id}/deliveries?limit=&offset=
+				r.With(limit("writes")).Get("/deliveries", webhooks.NewListDeliveriesHandler(logger, uc))
+			})
+		})
+	}
+
+	router.Route("/admin/comments", func(r chi.Router) {
+		r.Use(middleware_admin.Require)
+
+		// GET  /admin/comments/pending?limit=&offset=
+		r.Get("/pending", moderation.NewListPendingHandler(logger, uc))
+		// GET  /admin/comments/reports?status=&limit=&offset=
+		r.Get("/reports", moderation.NewListReportsHandler(logger, uc))
+
+		r.Route("/{comment_id}", func(r chi.Router) {
+			// POST /admin/comments/{comment_id}/approve|hide|restore
+			r.Post("/approve", moderation.NewModerateHandler(logger, uc, entity.ModerationApprove))
+			r.Post("/hide", moderation.NewModerateHandler(logger, uc, entity.ModerationHide))
+			r.Post("/restore", moderation.NewModerateHandler(logger, uc, entity.ModerationRestore))
+			// GET  /admin/comments/{comment_id}/audit
+			r.Get("/audit", moderation.NewAuditHandler(logger, uc))
+		})
+	})
+
+	if o.level != nil {
+		router.Route("/admin/log-level", func(r chi.Router) {
+			r.Use(middleware_admin.Require)
+
+			// GET /admin/log-level, PUT /admin/log-level {"level":"debug"}
+			r.Get("/", loglevel.NewGetLogLevelHandler(o.level))
+			r.Put("/", loglevel.NewSetLogLevelHandler(logger, o.level))
+		})
+	}
+
 	server := &http.Server{
 		Addr:         cnfg.HttpInfo.Port,
 		Handler:      router,
@@ -88,6 +198,7 @@ func InitServer(cnfg *config.Config, logger *zap.Logger, uc GameUseCase) *http.S
 		WriteTimeout: cnfg.HttpInfo.Timeout,
 		IdleTimeout:  cnfg.HttpInfo.IdleTimeout,
 	}
+	server.RegisterOnShutdown(func() { close(streamsDone) })
 
 	return server
 }
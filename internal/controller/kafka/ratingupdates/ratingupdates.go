@@ -0,0 +1,53 @@
+package ratingupdates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	gamekafka "github.com/RozmiDan/gameReviewHub/pkg/kafka"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// топик rating-updates, публикует rating service
+
+type RatingUpdateApplier interface {
+	ApplyRatingUpdate(ctx context.Context, upd entity.RatingUpdate) error
+}
+
+// NewRatingUpdatesHandler разбирает событие и передаёт его в usecase.
+// Битые сообщения логируются и пропускаются: повтор их не исправит.
+func NewRatingUpdatesHandler(baseLogger *zap.Logger, uc RatingUpdateApplier) func(ctx context.Context, msg kafka.Message) error {
+	return func(ctx context.Context, msg kafka.Message) error {
+		// 1) request_id для сквозных логов — из заголовка продьюсера, иначе координаты сообщения
+		reqID := gamekafka.HeaderValue(msg, gamekafka.HeaderRequestID)
+		if reqID == "" {
+			reqID = fmt.Sprintf("kafka:%s/%d/%d", msg.Topic, msg.Partition, msg.Offset)
+		}
+		ctx = context.WithValue(ctx, entity.RequestIDKey{}, reqID)
+
+		// 2) оборачиваем логгер
+		logger := baseLogger.With(zap.String("handler", "RatingUpdatesHandler"), zap.String("request_id", reqID))
+
+		// 3) декодируем и валидируем
+		var upd entity.RatingUpdate
+		if err := json.Unmarshal(msg.Value, &upd); err != nil {
+			logger.Warn("malformed rating update, skipping", zap.Error(err))
+			return nil
+		}
+		if _, err := uuid.Parse(upd.GameID); err != nil {
+			logger.Warn("invalid game_id in rating update, skipping", zap.String("game_id", upd.GameID))
+			return nil
+		}
+		if upd.UpdatedAt.IsZero() || upd.RatingsCount < 0 {
+			logger.Warn("incomplete rating update, skipping", zap.String("game_id", upd.GameID))
+			return nil
+		}
+
+		// 4) бизнес-логика; ошибка → консьюмер повторит сообщение
+		return uc.ApplyRatingUpdate(ctx, upd)
+	}
+}
@@ -0,0 +1,122 @@
+// Package deadletter переотправляет сообщения из dead-letter store в Kafka.
+package deadletter
+
+import (
+	"context"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"go.uber.org/zap"
+)
+
+const _pageSize = 100
+
+type Store interface {
+	ListDeadLetters(ctx context.Context, pendingOnly bool, afterID int64, limit int) ([]entity.DeadLetter, error)
+	GetDeadLetter(ctx context.Context, id int64) (*entity.DeadLetter, error)
+	MarkDeadLetterAttempt(ctx context.Context, id int64, replayErr error) error
+}
+
+type Publisher interface {
+	Republish(ctx context.Context, dl entity.DeadLetter) error
+}
+
+// Result — итог переотправки одного сообщения
+type Result struct {
+	Letter entity.DeadLetter
+	Err    error
+}
+
+type Replayer struct {
+	store  Store
+	pub    Publisher
+	logger *zap.Logger
+	// минимальный интервал между отправками; 0 — без ограничения
+	interval time.Duration
+	dryRun   bool
+}
+
+// New: ratePerSec <= 0 отключает ограничение скорости; dryRun только перечисляет сообщения
+func New(store Store, pub Publisher, logger *zap.Logger, ratePerSec float64, dryRun bool) *Replayer {
+	var interval time.Duration
+	if ratePerSec > 0 {
+		interval = time.Duration(float64(time.Second) / ratePerSec)
+	}
+	return &Replayer{
+		store:    store,
+		pub:      pub,
+		logger:   logger.With(zap.String("component", "dead-letter-replayer")),
+		interval: interval,
+		dryRun:   dryRun,
+	}
+}
+
+// ReplayOne переотправляет сообщение по id, даже если оно уже переотправлялось
+func (r *Replayer) ReplayOne(ctx context.Context, id int64) error {
+	dl, err := r.store.GetDeadLetter(ctx, id)
+	if err != nil {
+		return err
+	}
+	return r.replay(ctx, *dl)
+}
+
+// ReplayPending переотправляет все ещё не переотправленные сообщения по порядку id.
+// onResult вызывается после каждого сообщения (для прогресса в CLI).
+func (r *Replayer) ReplayPending(ctx context.Context, onResult func(Result)) (ok, failed int, err error) {
+	var ticker *time.Ticker
+	if r.interval > 0 {
+		ticker = time.NewTicker(r.interval)
+		defer ticker.Stop()
+	}
+
+	var afterID int64
+	for {
+		page, err := r.store.ListDeadLetters(ctx, true, afterID, _pageSize)
+		if err != nil {
+			return ok, failed, err
+		}
+		if len(page) == 0 {
+			return ok, failed, nil
+		}
+
+		for _, dl := range page {
+			afterID = dl.ID
+			if ticker != nil {
+				select {
+				case <-ctx.Done():
+					return ok, failed, ctx.Err()
+				case <-ticker.C:
+				}
+			}
+
+			replayErr := r.replay(ctx, dl)
+			if replayErr != nil {
+				failed++
+			} else {
+				ok++
+			}
+			if onResult != nil {
+				onResult(Result{Letter: dl, Err: replayErr})
+			}
+			if ctx.Err() != nil {
+				return ok, failed, ctx.Err()
+			}
+		}
+	}
+}
+
+func (r *Replayer) replay(ctx context.Context, dl entity.DeadLetter) error {
+	if r.dryRun {
+		return nil
+	}
+
+	pubErr := r.pub.Republish(ctx, dl)
+	if pubErr != nil {
+		r.logger.Warn("republish failed", zap.Int64("id", dl.ID), zap.Error(pubErr))
+	}
+	// попытку фиксируем в любом случае; если не удалось — сообщение останется pending
+	if err := r.store.MarkDeadLetterAttempt(ctx, dl.ID, pubErr); err != nil {
+		return err
+	}
+	return pubErr
+}
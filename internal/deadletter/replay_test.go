@@ -0,0 +1,115 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeStore struct {
+	letters  []entity.DeadLetter
+	attempts map[int64][]error
+}
+
+func newFakeStore(n int) *fakeStore {
+	s := &fakeStore{attempts: map[int64][]error{}}
+	for i := 1; i <= n; i++ {
+		s.letters = append(s.letters, entity.DeadLetter{ID: int64(i), Topic: "ratings"})
+	}
+	return s
+}
+
+func (f *fakeStore) ListDeadLetters(ctx context.Context, pendingOnly bool, afterID int64, limit int) ([]entity.DeadLetter, error) {
+	var out []entity.DeadLetter
+	for _, dl := range f.letters {
+		if dl.ID > afterID && (!pendingOnly || dl.ReplayedAt == nil) && len(out) < limit {
+			out = append(out, dl)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) GetDeadLetter(ctx context.Context, id int64) (*entity.DeadLetter, error) {
+	for _, dl := range f.letters {
+		if dl.ID == id {
+			return &dl, nil
+		}
+	}
+	return nil, entity.ErrDeadLetterNotFound
+}
+
+func (f *fakeStore) MarkDeadLetterAttempt(ctx context.Context, id int64, replayErr error) error {
+	f.attempts[id] = append(f.attempts[id], replayErr)
+	if replayErr == nil {
+		now := time.Now()
+		f.letters[id-1].ReplayedAt = &now
+	}
+	return nil
+}
+
+type fakePublisher struct {
+	fail map[int64]bool
+	sent []int64
+}
+
+func (f *fakePublisher) Republish(ctx context.Context, dl entity.DeadLetter) error {
+	if f.fail[dl.ID] {
+		return errors.New("broker down")
+	}
+	f.sent = append(f.sent, dl.ID)
+	return nil
+}
+
+func TestReplayPending(t *testing.T) {
+	store := newFakeStore(250)
+	pub := &fakePublisher{fail: map[int64]bool{7: true}}
+
+	var seen int
+	ok, failed, err := New(store, pub, zap.NewNop(), 0, false).
+		ReplayPending(context.Background(), func(Result) { seen++ })
+	require.NoError(t, err)
+	require.Equal(t, 249, ok)
+	require.Equal(t, 1, failed)
+	require.Equal(t, 250, seen)
+
+	// упавшее сообщение осталось pending, ошибка записана
+	require.Nil(t, store.letters[6].ReplayedAt)
+	require.Error(t, store.attempts[7][0])
+
+	// повторный прогон берёт только его
+	pub.fail = nil
+	ok, failed, err = New(store, pub, zap.NewNop(), 0, false).ReplayPending(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, ok)
+	require.Zero(t, failed)
+}
+
+func TestReplayPending_DryRun(t *testing.T) {
+	store := newFakeStore(3)
+	pub := &fakePublisher{}
+
+	ok, _, err := New(store, pub, zap.NewNop(), 0, true).ReplayPending(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, ok)
+	require.Empty(t, pub.sent)
+	require.Empty(t, store.attempts)
+}
+
+func TestReplayPending_RateLimited(t *testing.T) {
+	store := newFakeStore(5)
+	start := time.Now()
+
+	_, _, err := New(store, &fakePublisher{}, zap.NewNop(), 100, false).ReplayPending(context.Background(), nil)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestReplayOne_NotFound(t *testing.T) {
+	err := New(newFakeStore(1), &fakePublisher{}, zap.NewNop(), 0, false).ReplayOne(context.Background(), 42)
+	require.ErrorIs(t, err, entity.ErrDeadLetterNotFound)
+}
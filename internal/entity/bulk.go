@@ -0,0 +1,21 @@
+package entity
+
+import "errors"
+
+var ErrInvalidConflictMode = errors.New("unknown on-conflict mode")
+
+// что делать при массовой загрузке с записями, которые уже есть в БД
+const (
+	ConflictSkip   = "skip"   // оставить существующую запись
+	ConflictUpsert = "upsert" // перезаписать существующую запись
+	ConflictError  = "error"  // прервать батч
+)
+
+// ParseConflictMode проверяет значение флага -on-conflict
+func ParseConflictMode(mode string) (string, error) {
+	switch mode {
+	case ConflictSkip, ConflictUpsert, ConflictError:
+		return mode, nil
+	}
+	return "", ErrInvalidConflictMode
+}
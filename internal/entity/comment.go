@@ -11,6 +11,8 @@ var (
 	ErrInternal         = errors.New("internal error")
 	ErrInternalComments = errors.New("could not fetch comments")
 	ErrTimeout          = errors.New("timeout exceeded")
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrStreamDisabled   = errors.New("comment stream disabled")
 )
 
 type Comment struct {
@@ -19,4 +21,10 @@ type Comment struct {
 	UserID    string    `json:"user_id"`
 	Text      string    `json:"text"`
 	CreatedAt time.Time `json:"created_at"`
+	Status    string    `json:"status,omitempty"`
+
+	// заполняются только для модераторов
+	ModeratedBy      string     `json:"moderated_by,omitempty"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
+	ModerationReason string     `json:"moderation_reason,omitempty"`
 }
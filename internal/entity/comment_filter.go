@@ -0,0 +1,34 @@
+package entity
+
+import "errors"
+
+var ErrCommentRejected = errors.New("comment rejected by content filter")
+
+// CommentRejection — отказ фильтра; Code уходит клиенту в APIError как есть
+type CommentRejection struct {
+	Rule    string
+	Code    string
+	Message string
+}
+
+func (e *CommentRejection) Error() string {
+	return e.Message
+}
+
+func (e *CommentRejection) Is(target error) bool {
+	return target == ErrCommentRejected
+}
+
+// CommentDraft — комментарий до записи, то, что видят правила фильтра
+type CommentDraft struct {
+	GameID string
+	UserID string
+	Text   string
+}
+
+// FilterVerdict — итог фильтра для пропущенного комментария.
+// Moderate: комментарий сохраняется со статусом pending, Reasons — какие правила сработали.
+type FilterVerdict struct {
+	Moderate bool
+	Reasons  []string
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Типы доменных событий. CommentAdded — комментарий стал публичным (в том числе после одобрения
+// или восстановления), CommentDeleted — перестал им быть (скрыт модератором или жалобами).
+const (
+	EventGameCreated    = "GameCreated"
+	EventCommentAdded   = "CommentAdded"
+	EventCommentDeleted = "CommentDeleted"
+)
+
+// Агрегаты: у каждого свой топик, ключ сообщения — game_id
+const (
+	AggregateGame    = "game"
+	AggregateComment = "comment"
+)
+
+// DomainEvent — конверт события, который уходит в Kafka как есть.
+// ID стабилен между повторными отправками — по нему потребители отсекают дубли.
+type DomainEvent struct {
+	ID          string          `json:"event_id"`
+	Type        string          `json:"type"`
+	Aggregate   string          `json:"aggregate"`
+	AggregateID string          `json:"aggregate_id"`
+	GameID      string          `json:"game_id"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Data        json.RawMessage `json:"data"`
+	// RequestID и TraceParent (W3C) не входят в тело, передаются заголовками
+	RequestID   string `json:"-"`
+	TraceParent string `json:"-"`
+}
+
+type GameEventData struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Genre       string    `json:"genre"`
+	Creator     string    `json:"creator"`
+	Description string    `json:"description"`
+	ReleaseDate time.Time `json:"release_date"`
+}
+
+type CommentEventData struct {
+	ID     string `json:"id"`
+	GameID string `json:"game_id"`
+	UserID string `json:"user_id"`
+	Text   string `json:"text,omitempty"`
+}
+
+// NewDomainEvent собирает событие с новым ID и текущим временем
+func NewDomainEvent(eventType, aggregate, aggregateID, gameID string, data any) (DomainEvent, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return DomainEvent{}, err
+	}
+	return DomainEvent{
+		ID:          uuid.NewString(),
+		Type:        eventType,
+		Aggregate:   aggregate,
+		AggregateID: aggregateID,
+		GameID:      gameID,
+		OccurredAt:  time.Now().UTC(),
+		Data:        raw,
+	}, nil
+}
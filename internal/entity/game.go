@@ -10,6 +10,7 @@ var (
 	ErrGameAlreadyExists = errors.New("game already exists")
 	ErrInsertGame        = errors.New("failed to insert game")
 	ErrCacheMiss         = errors.New("no data in redis")
+	ErrExportDisabled    = errors.New("export disabled")
 )
 
 type Game struct {
@@ -29,4 +30,11 @@ type GameInList struct {
 	Rating float64 `json:"rating"`
 }
 
+// ExportedGame — строка выгрузки каталога. Rating == nil — рейтинг неизвестен (нет снапшота);
+// это не то же самое, что игра без оценок (нулевой рейтинг)
+type ExportedGame struct {
+	Game   Game
+	Rating *GameRating
+}
+
 type RequestIDKey struct{}
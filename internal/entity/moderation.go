@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidModeration = errors.New("comment status does not allow this action")
+	ErrModeratorRequired = errors.New("moderator identity required")
+	ErrModerationReason  = errors.New("moderation reason required")
+)
+
+// Статусы комментария: в публичной ленте только visible
+const (
+	CommentVisible = "visible"
+	CommentPending = "pending"
+	CommentHidden  = "hidden"
+)
+
+// Действия модератора
+const (
+	ModerationApprove  = "approve"   // pending → visible
+	ModerationHide     = "hide"      // visible | pending → hidden
+	ModerationRestore  = "restore"   // hidden → visible
+	ModerationAutoHide = "auto_hide" // visible → pending, набралось достаточно жалоб
+)
+
+// ModeratorKey — ключ контекста с именем модератора (кладёт admin-middleware)
+type ModeratorKey struct{}
+
+// ModerationAudit — запись журнала модерации; пишется в той же транзакции, что и смена статуса
+type ModerationAudit struct {
+	ID         int64     `json:"id"`
+	CommentID  string    `json:"comment_id"`
+	GameID     string    `json:"game_id"`
+	Action     string    `json:"action"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Moderator  string    `json:"moderator"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// RateLimit — не больше Requests запросов за Period (с разрешённым всплеском до Requests)
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// RateLimitResult — решение лимитера по одному запросу
+type RateLimitResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter — через сколько можно повторить отклонённый запрос
+	RetryAfter time.Duration
+	// ResetAfter — через сколько лимит восстановится полностью
+	ResetAfter time.Duration
+}
@@ -1,12 +1,18 @@
 package entity
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 var (
-	ErrInvalidUUID        = errors.New("entered uuid is invalid")
-	ErrInternalRating     = errors.New("rating service error")
-	ErrServiceUnavailable = errors.New("rating service unavailable")
-	ErrBrokerUnavailable  = errors.New("broker service unavailable")
+	ErrInvalidUUID         = errors.New("entered uuid is invalid")
+	ErrInternalRating      = errors.New("rating service error")
+	ErrServiceUnavailable  = errors.New("rating service unavailable")
+	ErrBrokerUnavailable   = errors.New("broker service unavailable")
+	ErrInvalidEvent        = errors.New("invalid event payload")
+	ErrDeadLetterNotFound  = errors.New("dead letter not found")
+	ErrInvalidSubscription = errors.New("invalid rating subscription")
 )
 
 type GameRating struct {
@@ -20,3 +26,28 @@ type RatingMessage struct {
 	UserID string `json:"user_id"`
 	Rating int32  `json:"rating"`
 }
+
+// RatingUpdate — событие из топика rating-updates: новый агрегат рейтинга игры.
+// UpdatedAt задаёт порядок: более старые события не перетирают снапшот.
+type RatingUpdate struct {
+	GameID        string    `json:"game_id"`
+	AverageRating float64   `json:"average_rating"`
+	RatingsCount  int64     `json:"ratings_count"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// DeadLetter — сообщение, которое не удалось отправить в Kafka.
+// Хранится в том виде, в каком уходило в брокер (те же байты и заголовки с event-id),
+// чтобы повторная отправка была неотличима от исходной.
+type DeadLetter struct {
+	ID            int64             `json:"id"`
+	Topic         string            `json:"topic"`
+	Key           []byte            `json:"key"`
+	Payload       []byte            `json:"payload"`
+	Headers       map[string]string `json:"headers"`
+	Error         string            `json:"error"`
+	Attempts      int               `json:"attempts"`
+	CreatedAt     time.Time         `json:"created_at"`
+	LastAttemptAt *time.Time        `json:"last_attempt_at,omitempty"`
+	ReplayedAt    *time.Time        `json:"replayed_at,omitempty"`
+}
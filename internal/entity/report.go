@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidReport   = errors.New("invalid comment report")
+	ErrDuplicateReport = errors.New("comment already reported by this user")
+)
+
+// Причины жалобы на комментарий
+const (
+	ReportSpam       = "spam"
+	ReportHarassment = "harassment"
+	ReportSpoiler    = "spoiler"
+	ReportOther      = "other"
+)
+
+// SystemModerator — от чьего имени пишутся автоматические действия (скрытие по жалобам)
+const SystemModerator = "system"
+
+// CommentReport — жалоба пользователя на комментарий
+type CommentReport struct {
+	CommentID string
+	GameID    string
+	UserID    string
+	Reason    string
+	Details   string
+}
+
+// ReportedComment — комментарий со сводкой жалоб для модераторов
+type ReportedComment struct {
+	Comment
+	ReportsCount   int            `json:"reports_count"`
+	Reasons        map[string]int `json:"reasons"`
+	LastReportedAt time.Time      `json:"last_reported_at"`
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrWebhookNotFound = errors.New("webhook subscription not found")
+	ErrInvalidWebhook  = errors.New("invalid webhook subscription")
+	ErrPartnerRequired = errors.New("partner identity required")
+)
+
+// PartnerKey — ключ контекста с именем партнёра (кладёт middleware_admin.RequirePartner)
+type PartnerKey struct{}
+
+// Статусы доставки вебхука
+const (
+	WebhookPending   = "pending"
+	WebhookDelivered = "delivered"
+	WebhookFailed    = "failed"
+)
+
+// WebhookSubscription — подписка партнёра. GameID пустой — все игры.
+// Owner — партнёр, создавший подписку: только он видит её и управляет ею.
+type WebhookSubscription struct {
+	ID                  string     `json:"id"`
+	Owner               string     `json:"-"`
+	URL                 string     `json:"url"`
+	Secret              string     `json:"-"`
+	EventTypes          []string   `json:"event_types"`
+	GameID              string     `json:"game_id,omitempty"`
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+// WebhookDelivery — одно событие для одной подписки, вместе с адресом и секретом
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID string
+	URL            string
+	Secret         string
+	EventID        string
+	EventType      string
+	Payload        []byte
+	Attempts       int
+}
+
+// WebhookAttempt — запись журнала доставок
+type WebhookAttempt struct {
+	DeliveryID int64     `json:"delivery_id"`
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	Status     string    `json:"delivery_status"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// WebhookAttemptResult — итог попытки, который воркер сохраняет в репозиторий.
+// Если GiveUp — доставка помечается failed, иначе повторяется в NextAttemptAt.
+type WebhookAttemptResult struct {
+	Delivery      WebhookDelivery
+	StatusCode    int
+	Error         string
+	Duration      time.Duration
+	Success       bool
+	GiveUp        bool
+	NextAttemptAt time.Time
+}
@@ -0,0 +1,215 @@
+// Package fakerating — in-memory реализация rating service для тестов и локального запуска.
+// Повторяет контракт внешнего rating_service: принимает те же RatingMessage (из Kafka
+// или напрямую через PublishRating), считает средние и отдаёт топ по gRPC.
+package fakerating
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	gamekafka "github.com/RozmiDan/gameReviewHub/pkg/kafka"
+	ratingv1 "github.com/RozmiDan/gamehub-protos/gen/go/gamehub"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const bufSize = 1 << 20
+
+type Service struct {
+	ratingv1.UnimplementedRatingServiceServer
+
+	logger *zap.Logger
+
+	mu sync.RWMutex
+	// game_id → user_id → оценка; повторная оценка пользователя заменяет прежнюю
+	ratings map[string]map[string]int32
+}
+
+func New(logger *zap.Logger) *Service {
+	return &Service{
+		logger:  logger.With(zap.String("component", "fake-rating")),
+		ratings: make(map[string]map[string]int32),
+	}
+}
+
+// Apply учитывает оценку так же, как это делает внешний сервис при чтении из Kafka
+func (s *Service) Apply(msg entity.RatingMessage) error {
+	if _, err := uuid.Parse(msg.GameID); err != nil {
+		return entity.ErrInvalidUUID
+	}
+	if msg.Rating < 1 || msg.Rating > 10 {
+		return errors.New("rating must be between 1 and 10")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users, ok := s.ratings[msg.GameID]
+	if !ok {
+		users = make(map[string]int32)
+		s.ratings[msg.GameID] = users
+	}
+	users[msg.UserID] = msg.Rating
+	return nil
+}
+
+// PublishRating позволяет использовать фейк вместо Kafka-продьюсера (usecase.RatingProducer).
+// Сообщение проходит через JSON, чтобы формат совпадал с тем, что уходит в Kafka.
+func (s *Service) PublishRating(ctx context.Context, msg entity.RatingMessage) error {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return s.HandleMessage(raw)
+}
+
+// HandleMessage разбирает RatingMessage в JSON и применяет его
+func (s *Service) HandleMessage(raw []byte) error {
+	var msg entity.RatingMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return err
+	}
+	return s.Apply(msg)
+}
+
+// Consume читает топик с оценками (любой поддерживаемой версии схемы), пока не отменён ctx
+func (s *Service) Consume(ctx context.Context, brokers []string, topic string) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: brokers,
+		Topic:   topic,
+		GroupID: "fake-rating-service",
+	})
+	defer reader.Close()
+
+	for {
+		m, err := reader.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		msg, err := gamekafka.DecodeRating(m)
+		if err == nil {
+			err = s.Apply(msg)
+		}
+		if err != nil {
+			s.logger.Warn("skip malformed rating message", zap.Error(err), zap.ByteString("key", m.Key))
+		}
+	}
+}
+
+func (s *Service) SubmitRating(ctx context.Context, req *ratingv1.SubmitRatingRequest) (*ratingv1.SubmitRatingResponse, error) {
+	err := s.Apply(entity.RatingMessage{GameID: req.GetGameId(), UserID: req.GetUserId(), Rating: req.GetRating()})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &ratingv1.SubmitRatingResponse{Success: true}, nil
+}
+
+func (s *Service) GetGameRating(ctx context.Context, req *ratingv1.GetGameRatingRequest) (*ratingv1.GetGameRatingResponse, error) {
+	if _, err := uuid.Parse(req.GetGameId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid game_id")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	users, ok := s.ratings[req.GetGameId()]
+	if !ok || len(users) == 0 {
+		return nil, status.Error(codes.NotFound, "no ratings for game")
+	}
+	r := aggregate(req.GetGameId(), users)
+	return &ratingv1.GetGameRatingResponse{
+		GameId:        r.GameId,
+		AverageRating: r.AverageRating,
+		RatingsCount:  r.RatingsCount,
+	}, nil
+}
+
+// GetTopGames сортирует по среднему, затем по числу оценок.
+// offset — номер страницы, как и в остальной пагинации сервиса.
+func (s *Service) GetTopGames(ctx context.Context, req *ratingv1.GetTopGamesRequest) (*ratingv1.GetTopGamesResponse, error) {
+	if req.GetLimit() <= 0 || req.GetOffset() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must be > 0 and offset >= 0")
+	}
+
+	s.mu.RLock()
+	games := make([]*ratingv1.GameRating, 0, len(s.ratings))
+	for gameID, users := range s.ratings {
+		games = append(games, aggregate(gameID, users))
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(games, func(i, j int) bool {
+		if games[i].AverageRating != games[j].AverageRating {
+			return games[i].AverageRating > games[j].AverageRating
+		}
+		if games[i].RatingsCount != games[j].RatingsCount {
+			return games[i].RatingsCount > games[j].RatingsCount
+		}
+		return games[i].GameId < games[j].GameId
+	})
+
+	from := int(req.GetOffset()) * int(req.GetLimit())
+	if from >= len(games) {
+		return &ratingv1.GetTopGamesResponse{}, nil
+	}
+	to := from + int(req.GetLimit())
+	if to > len(games) {
+		to = len(games)
+	}
+	return &ratingv1.GetTopGamesResponse{Games: games[from:to]}, nil
+}
+
+func aggregate(gameID string, users map[string]int32) *ratingv1.GameRating {
+	var sum int64
+	for _, r := range users {
+		sum += int64(r)
+	}
+	return &ratingv1.GameRating{
+		GameId:        gameID,
+		AverageRating: float64(sum) / float64(len(users)),
+		RatingsCount:  int64(len(users)),
+	}
+}
+
+// Register вешает фейк и health-сервер на grpc.Server
+func (s *Service) Register(srv *grpc.Server) {
+	ratingv1.RegisterRatingServiceServer(srv, s)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+}
+
+// Serve поднимает grpc.Server на переданном listener'е; остановка — через возвращённый сервер
+func (s *Service) Serve(lis net.Listener) *grpc.Server {
+	srv := grpc.NewServer()
+	s.Register(srv)
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			s.logger.Error("fake rating server stopped", zap.Error(err))
+		}
+	}()
+	return srv
+}
+
+// ServeBufconn поднимает сервер в памяти и возвращает функцию для дозвона до него
+// (подходит для grpc.WithContextDialer / rating.Dialer)
+func (s *Service) ServeBufconn() (*grpc.Server, func(context.Context, string) (net.Conn, error)) {
+	lis := bufconn.Listen(bufSize)
+	srv := s.Serve(lis)
+	return srv, func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}
+}
@@ -0,0 +1,80 @@
+package fakerating
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	rating "github.com/RozmiDan/gameReviewHub/internal/repo/grpcclient"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	gameA = "00000000-0000-0000-0000-00000000000a"
+	gameB = "00000000-0000-0000-0000-00000000000b"
+	gameC = "00000000-0000-0000-0000-00000000000c"
+)
+
+func newClient(t *testing.T, svc *Service) *rating.Client {
+	t.Helper()
+	srv, dial := svc.ServeBufconn()
+	t.Cleanup(srv.Stop)
+
+	client, err := rating.New(context.Background(), zap.NewNop(), []string{"passthrough:///bufnet"},
+		time.Second, rating.Dialer(dial))
+	require.NoError(t, err)
+	return client
+}
+
+func TestService_OverBufconn(t *testing.T) {
+	ctx := context.Background()
+	svc := New(zap.NewNop())
+	client := newClient(t, svc)
+
+	// оценки приходят тем же JSON, что уходит в Kafka
+	require.NoError(t, svc.HandleMessage([]byte(`{"game_id":"`+gameA+`","user_id":"u1","rating":8}`)))
+	require.NoError(t, svc.PublishRating(ctx, entity.RatingMessage{GameID: gameA, UserID: "u2", Rating: 6}))
+	require.NoError(t, svc.PublishRating(ctx, entity.RatingMessage{GameID: gameB, UserID: "u1", Rating: 9}))
+	require.NoError(t, svc.PublishRating(ctx, entity.RatingMessage{GameID: gameC, UserID: "u1", Rating: 7}))
+	require.NoError(t, svc.PublishRating(ctx, entity.RatingMessage{GameID: gameC, UserID: "u2", Rating: 7}))
+
+	// повторная оценка пользователя заменяет прежнюю
+	require.NoError(t, svc.PublishRating(ctx, entity.RatingMessage{GameID: gameA, UserID: "u2", Rating: 10}))
+
+	got, err := client.GetGameRating(ctx, gameA)
+	require.NoError(t, err)
+	require.Equal(t, &entity.GameRating{GameID: gameA, AverageRating: 9, RatingsCount: 2}, got)
+
+	top, err := client.GetTopGames(ctx, 2, 0)
+	require.NoError(t, err)
+	require.Equal(t, []entity.GameRating{
+		{GameID: gameA, AverageRating: 9, RatingsCount: 2},
+		{GameID: gameB, AverageRating: 9, RatingsCount: 1},
+	}, top)
+
+	// offset — номер страницы
+	top, err = client.GetTopGames(ctx, 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, []entity.GameRating{{GameID: gameC, AverageRating: 7, RatingsCount: 2}}, top)
+
+	ok, err := client.SubmitRating(ctx, "u3", gameC, 1)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestService_Errors(t *testing.T) {
+	ctx := context.Background()
+	svc := New(zap.NewNop())
+	client := newClient(t, svc)
+
+	_, err := client.GetGameRating(ctx, gameA)
+	require.ErrorIs(t, err, entity.ErrGameNotFound)
+
+	_, err = client.GetGameRating(ctx, "not-a-uuid")
+	require.ErrorIs(t, err, entity.ErrInvalidUUID)
+
+	require.Error(t, svc.HandleMessage([]byte(`{broken`)))
+	require.Error(t, svc.Apply(entity.RatingMessage{GameID: gameA, UserID: "u1", Rating: 11}))
+}
@@ -0,0 +1,140 @@
+// Package health проверяет зависимости сервиса для /readyz.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// статусы проверки и сервиса целиком
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded" // упала некритичная зависимость — сервис работает, но хуже
+	StatusFail     = "fail"
+)
+
+const _defaultTimeout = time.Second
+
+// Probe — проверка одной зависимости; должна уважать дедлайн ctx
+type Probe func(ctx context.Context) error
+
+type check struct {
+	name     string
+	critical bool
+	probe    Probe
+}
+
+// CheckResult — итог проверки одной зависимости
+type CheckResult struct {
+	Status   string `json:"status"`
+	Critical bool   `json:"critical"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Report — итог всех проверок. Ready == false только если упала критичная зависимость.
+type Report struct {
+	Status string                 `json:"status"`
+	Ready  bool                   `json:"ready"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Checker запускает проверки параллельно, каждую со своим таймаутом
+type Checker struct {
+	logger  *zap.Logger
+	timeout time.Duration
+	checks  []check
+}
+
+type Option func(*Checker)
+
+// Timeout — сколько ждать одну проверку
+func Timeout(d time.Duration) Option {
+	return func(c *Checker) {
+		if d > 0 {
+			c.timeout = d
+		}
+	}
+}
+
+// WithCheck добавляет зависимость; некритичная при падении переводит сервис в degraded, но не в not ready
+func WithCheck(name string, critical bool, probe Probe) Option {
+	return func(c *Checker) {
+		c.checks = append(c.checks, check{name: name, critical: critical, probe: probe})
+	}
+}
+
+func New(logger *zap.Logger, opts ...Option) *Checker {
+	c := &Checker{
+		logger:  logger.With(zap.String("component", "health")),
+		timeout: _defaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Check опрашивает все зависимости
+func (c *Checker) Check(ctx context.Context) Report {
+	results := make([]CheckResult, len(c.checks))
+
+	var wg sync.WaitGroup
+	for i, ch := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, ch)
+		}()
+	}
+	wg.Wait()
+
+	rep := Report{Status: StatusOK, Ready: true, Checks: make(map[string]CheckResult, len(c.checks))}
+	for i, ch := range c.checks {
+		res := results[i]
+		rep.Checks[ch.name] = res
+		if res.Status == StatusOK {
+			continue
+		}
+		if ch.critical {
+			rep.Status = StatusFail
+			rep.Ready = false
+		} else if rep.Status == StatusOK {
+			rep.Status = StatusDegraded
+		}
+	}
+	return rep
+}
+
+func (c *Checker) run(ctx context.Context, ch check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	// проба может не уважать ctx — ждём её не дольше таймаута
+	done := make(chan error, 1)
+	go func() { done <- ch.probe(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	res := CheckResult{
+		Status:   StatusOK,
+		Critical: ch.critical,
+		Duration: time.Since(start).Round(time.Microsecond).String(),
+	}
+	if err != nil {
+		res.Status = StatusFail
+		res.Error = err.Error()
+		c.logger.Warn("dependency check failed",
+			zap.String("dependency", ch.name), zap.Bool("critical", ch.critical), zap.Error(err))
+	}
+	return res
+}
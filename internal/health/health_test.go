@@ -0,0 +1,55 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func ok(ctx context.Context) error { return nil }
+
+func down(ctx context.Context) error { return errors.New("connection refused") }
+
+// hang игнорирует ctx — Checker всё равно должен уложиться в таймаут
+func hang(ctx context.Context) error {
+	time.Sleep(time.Second)
+	return nil
+}
+
+func TestCheck_AllOK(t *testing.T) {
+	c := New(zap.NewNop(), WithCheck("postgres", true, ok), WithCheck("redis", false, ok))
+
+	rep := c.Check(context.Background())
+	require.True(t, rep.Ready)
+	require.Equal(t, StatusOK, rep.Status)
+	require.Equal(t, StatusOK, rep.Checks["postgres"].Status)
+	require.True(t, rep.Checks["postgres"].Critical)
+	require.False(t, rep.Checks["redis"].Critical)
+}
+
+func TestCheck_NonCriticalDegrades(t *testing.T) {
+	c := New(zap.NewNop(), WithCheck("postgres", true, ok), WithCheck("redis", false, down))
+
+	rep := c.Check(context.Background())
+	require.True(t, rep.Ready)
+	require.Equal(t, StatusDegraded, rep.Status)
+	require.Equal(t, StatusFail, rep.Checks["redis"].Status)
+	require.Equal(t, "connection refused", rep.Checks["redis"].Error)
+}
+
+func TestCheck_CriticalFails(t *testing.T) {
+	c := New(zap.NewNop(), Timeout(50*time.Millisecond),
+		WithCheck("postgres", true, hang), WithCheck("redis", false, down))
+
+	start := time.Now()
+	rep := c.Check(context.Background())
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+
+	require.False(t, rep.Ready)
+	require.Equal(t, StatusFail, rep.Status)
+	require.Equal(t, context.DeadlineExceeded.Error(), rep.Checks["postgres"].Error)
+}
@@ -0,0 +1,197 @@
+// Package lifecycle запускает компоненты сервиса по порядку и останавливает их в обратном.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const _defaultStopTimeout = 5 * time.Second
+
+// Component — часть сервиса: ресурс, который надо закрыть, фоновый воркер или сервер.
+// Все функции необязательны.
+type Component struct {
+	Name string
+	// Start вызывается по порядку добавления; ошибка прерывает запуск
+	Start func(ctx context.Context) error
+	// Run работает в своей горутине до отмены ctx. Ошибка Run останавливает весь сервис.
+	Run func(ctx context.Context) error
+	// Stop вызывается после отмены ctx у Run; Manager затем дожидается выхода Run.
+	// Компонент со Start останавливается, только если его Start успешно завершился: при ошибке запуска
+	// Stop не вызывается ни у упавшего компонента, ни у тех, до чьего Start дело не дошло.
+	// Компонент без Start — уже открытый ресурс, его Stop вызывается всегда.
+	Stop func(ctx context.Context) error
+	// StopTimeout — на Stop и ожидание Run вместе; 0 — таймаут Manager'а
+	StopTimeout time.Duration
+}
+
+type entry struct {
+	Component
+	started bool // Start отработал успешно или его нет
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// Manager — компоненты в порядке зависимостей: то, от чего зависят другие, добавляется раньше
+type Manager struct {
+	logger      *zap.Logger
+	stopTimeout time.Duration
+	entries     []*entry
+	stopOnce    sync.Once
+	stopErr     error
+}
+
+type Option func(*Manager)
+
+// StopTimeout — таймаут остановки компонента по умолчанию
+func StopTimeout(d time.Duration) Option {
+	return func(m *Manager) {
+		if d > 0 {
+			m.stopTimeout = d
+		}
+	}
+}
+
+func New(logger *zap.Logger, opts ...Option) *Manager {
+	m := &Manager{
+		logger:      logger.With(zap.String("component", "lifecycle")),
+		stopTimeout: _defaultStopTimeout,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Add регистрирует компонент. Добавлять сразу после создания ресурса:
+// если сборка сервиса упадёт дальше, Stop закроет всё уже открытое.
+func (m *Manager) Add(c Component) {
+	m.entries = append(m.entries, &entry{Component: c, started: c.Start == nil})
+}
+
+// Run запускает компоненты и ждёт отмены ctx (сигнал) или падения любого Run,
+// после чего останавливает всё в обратном порядке. Возвращает ошибки запуска, работы и остановки.
+func (m *Manager) Run(ctx context.Context) error {
+	failed := make(chan error, len(m.entries))
+
+	// 1) запуск по порядку
+	var startErr error
+	for _, e := range m.entries {
+		if e.Start != nil {
+			if err := e.Start(ctx); err != nil {
+				startErr = fmt.Errorf("start %s: %w", e.Name, err)
+				m.logger.Error("component start failed", zap.String("name", e.Name), zap.Error(err))
+				break
+			}
+			e.started = true
+		}
+		if e.Run != nil {
+			// не от ctx: по сигналу компоненты останавливает Stop в нужном порядке, а не все разом
+			runCtx, cancel := context.WithCancel(context.Background())
+			e.cancel, e.done = cancel, make(chan struct{})
+			go func(e *entry) {
+				defer close(e.done)
+				if err := e.Run(runCtx); err != nil && !errors.Is(err, context.Canceled) {
+					failed <- fmt.Errorf("%s: %w", e.Name, err)
+				}
+			}(e)
+		}
+		m.logger.Debug("component started", zap.String("name", e.Name))
+	}
+
+	// 2) работа до сигнала или первой ошибки
+	var runErr error
+	if startErr == nil {
+		m.logger.Info("all components started", zap.Int("count", len(m.entries)))
+		select {
+		case <-ctx.Done():
+			m.logger.Info("shutdown requested")
+		case runErr = <-failed:
+			m.logger.Error("component failed, shutting down", zap.Error(runErr))
+		}
+	}
+
+	// 3) остановка в обратном порядке
+	return errors.Join(startErr, runErr, m.Stop())
+}
+
+// Stop останавливает компоненты в обратном порядке, каждый со своим таймаутом.
+// Незапущенные компоненты со Start пропускаются (см. Component.Stop). Компонент, не уложившийся в таймаут, бросается — остальные всё равно останавливаются.
+// Повторный вызов возвращает результат первого.
+func (m *Manager) Stop() error {
+	m.stopOnce.Do(func() {
+		var errs []error
+		for i := len(m.entries) - 1; i >= 0; i-- {
+			e := m.entries[i]
+			if !e.started {
+				m.logger.Debug("component not started, skip stop", zap.String("name", e.Name))
+				continue
+			}
+			if err := m.stop(e); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		m.stopErr = errors.Join(errs...)
+	})
+	return m.stopErr
+}
+
+func (m *Manager) stop(e *entry) error {
+	timeout := e.StopTimeout
+	if timeout <= 0 {
+		timeout = m.stopTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	logger := m.logger.With(zap.String("name", e.Name))
+	start := time.Now()
+
+	if e.cancel != nil {
+		e.cancel()
+	}
+	done := make(chan error, 1)
+	go func() {
+		var err error
+		if e.Stop != nil {
+			err = e.Stop(ctx)
+		}
+		if e.done != nil {
+			<-e.done
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			logger.Error("component stop failed", zap.Error(err))
+			return fmt.Errorf("stop %s: %w", e.Name, err)
+		}
+		logger.Info("component stopped", zap.Duration("took", time.Since(start)))
+		return nil
+	case <-ctx.Done():
+		logger.Error("component stop timed out", zap.Duration("timeout", timeout))
+		return fmt.Errorf("stop %s: %w", e.Name, ctx.Err())
+	}
+}
+
+// Loop — Run для воркеров, которые работают до отмены ctx и ошибок не возвращают
+func Loop(run func(ctx context.Context)) func(context.Context) error {
+	return func(ctx context.Context) error {
+		run(ctx)
+		return nil
+	}
+}
+
+// Closer — Stop для ресурсов с Close без контекста; таймаут соблюдает Manager
+func Closer(close func() error) func(context.Context) error {
+	return func(context.Context) error {
+		return close()
+	}
+}
@@ -0,0 +1,306 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os/signal"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// journal — порядок событий запуска и остановки
+type journal struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (j *journal) add(e string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.events = append(j.events, e)
+}
+
+func (j *journal) all() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]string(nil), j.events...)
+}
+
+func component(j *journal, name string) Component {
+	return Component{
+		Name: name,
+		Start: func(context.Context) error {
+			j.add("start " + name)
+			return nil
+		},
+		Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			j.add("run done " + name)
+			return nil
+		},
+		Stop: func(context.Context) error {
+			j.add("stop " + name)
+			return nil
+		},
+	}
+}
+
+func TestManager_StopsInReverseOrder(t *testing.T) {
+	j := &journal{}
+	m := New(zap.NewNop())
+	m.Add(component(j, "db"))
+	m.Add(component(j, "worker"))
+	m.Add(component(j, "server"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- m.Run(ctx) }()
+
+	require.Eventually(t, func() bool { return len(j.all()) == 3 }, time.Second, time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	events := j.all()
+	require.Equal(t, []string{"start db", "start worker", "start server"}, events[:3])
+	// Stop и выход Run одного компонента идут параллельно, но следующий компонент — только после обоих
+	require.ElementsMatch(t, []string{"run done server", "stop server"}, events[3:5])
+	require.ElementsMatch(t, []string{"run done worker", "stop worker"}, events[5:7])
+	require.ElementsMatch(t, []string{"run done db", "stop db"}, events[7:9])
+
+	// повторный Stop ничего не делает
+	require.NoError(t, m.Stop())
+	require.Len(t, j.all(), 9)
+}
+
+func TestManager_StartFailure(t *testing.T) {
+	j := &journal{}
+	m := New(zap.NewNop())
+	m.Add(component(j, "db"))
+	m.Add(Component{Name: "broken", Start: func(context.Context) error { return errors.New("bind failed") }})
+	m.Add(Component{Name: "later", Stop: func(context.Context) error {
+		j.add("stop later")
+		return nil
+	}})
+
+	err := m.Run(context.Background())
+	require.ErrorContains(t, err, "start broken: bind failed")
+
+	// запущенное остановлено, созданное, но не запущенное — закрыто
+	events := j.all()
+	require.Equal(t, "start db", events[0])
+	require.Equal(t, "stop later", events[1])
+	require.ElementsMatch(t, []string{"run done db", "stop db"}, events[2:])
+}
+
+func TestManager_StartFailureStopsOnlyStarted(t *testing.T) {
+	j := &journal{}
+	m := New(zap.NewNop())
+	m.Add(component(j, "db"))
+	m.Add(component(j, "cache"))
+	broken := component(j, "broken")
+	broken.Start = func(context.Context) error {
+		j.add("start broken")
+		return errors.New("bind failed")
+	}
+	m.Add(broken)
+	m.Add(Component{Name: "pool", Stop: func(context.Context) error {
+		j.add("stop pool")
+		return nil
+	}})
+	m.Add(component(j, "server"))
+
+	err := m.Run(context.Background())
+	require.ErrorContains(t, err, "start broken: bind failed")
+
+	// ни упавший, ни не дошедший до Start компонент не останавливаются; открытый ресурс — закрывается,
+	// запущенные — в обратном порядке
+	events := j.all()
+	require.Equal(t, []string{"start db", "start cache", "start broken", "stop pool"}, events[:4])
+	require.ElementsMatch(t, []string{"run done cache", "stop cache"}, events[4:6])
+	require.ElementsMatch(t, []string{"run done db", "stop db"}, events[6:8])
+	require.Len(t, events, 8)
+}
+
+func TestManager_StopWithoutRun(t *testing.T) {
+	j := &journal{}
+	m := New(zap.NewNop())
+	m.Add(Component{Name: "db", Stop: func(context.Context) error {
+		j.add("stop db")
+		return nil
+	}})
+	m.Add(component(j, "server"))
+
+	// сборка сервиса упала до Run: закрываются только ресурсы
+	require.NoError(t, m.Stop())
+	require.Equal(t, []string{"stop db"}, j.all())
+}
+
+func TestManager_RunFailureStopsService(t *testing.T) {
+	j := &journal{}
+	m := New(zap.NewNop())
+	m.Add(component(j, "db"))
+	m.Add(Component{Name: "server", Run: func(context.Context) error { return errors.New("listener closed") }})
+
+	err := m.Run(context.Background())
+	require.ErrorContains(t, err, "server: listener closed")
+	require.Contains(t, j.all(), "stop db")
+}
+
+func TestManager_StopTimeout(t *testing.T) {
+	j := &journal{}
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	m := New(zap.NewNop(), StopTimeout(20*time.Millisecond))
+	m.Add(component(j, "db"))
+	// Close без контекста, который не возвращается
+	m.Add(Component{Name: "stuck", Stop: Closer(func() error {
+		<-release
+		return nil
+	})})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err := m.Run(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "stop stuck")
+	require.Less(t, time.Since(start), time.Second)
+	// зависший компонент не мешает остановить остальные
+	require.Contains(t, j.all(), "stop db")
+}
+
+// bufferedProducer — как async kafka.Writer: Publish только кладёт в буфер, Close отправляет остаток
+type bufferedProducer struct {
+	mu        sync.Mutex
+	buffer    []string
+	delivered []string
+	closed    bool
+}
+
+func (p *bufferedProducer) Publish(msg string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return errors.New("producer closed")
+	}
+	p.buffer = append(p.buffer, msg)
+	return nil
+}
+
+func (p *bufferedProducer) Close() error {
+	// отправка буфера небыстрая
+	time.Sleep(20 * time.Millisecond)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.delivered = append(p.delivered, p.buffer...)
+	p.buffer = nil
+	p.closed = true
+	return nil
+}
+
+func TestManager_SIGTERMUnderLoad(t *testing.T) {
+	producer := &bufferedProducer{}
+	var inFlight atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rate", func(w http.ResponseWriter, r *http.Request) {
+		inFlight.Add(1)
+		defer inFlight.Add(-1)
+		// запрос ещё обрабатывается, когда приходит сигнал
+		time.Sleep(30 * time.Millisecond)
+		if err := producer.Publish(r.URL.Query().Get("id")); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	})
+	server := &http.Server{Handler: mux}
+
+	var (
+		listener net.Listener
+		addr     = make(chan string, 1)
+	)
+	m := New(zap.NewNop(), StopTimeout(time.Second))
+	m.Add(Component{Name: "kafka_producer", Stop: Closer(producer.Close)})
+	m.Add(Component{
+		Name: "http_server",
+		Start: func(context.Context) (err error) {
+			listener, err = net.Listen("tcp", "127.0.0.1:0")
+			if err == nil {
+				addr <- listener.Addr().String()
+			}
+			return err
+		},
+		Run: func(context.Context) error {
+			if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		Stop: server.Shutdown,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+	done := make(chan error, 1)
+	go func() { done <- m.Run(ctx) }()
+	base := "http://" + <-addr
+
+	// нагрузка: клиенты шлют запросы, пока сервер их принимает
+	var (
+		accepted atomic.Int32
+		clients  sync.WaitGroup
+		seq      atomic.Int32
+	)
+	for range 8 {
+		clients.Add(1)
+		go func() {
+			defer clients.Done()
+			client := &http.Client{Timeout: time.Second}
+			for {
+				id := seq.Add(1)
+				resp, err := client.Get(base + "/rate?id=" + strconv.Itoa(int(id)))
+				if err != nil {
+					return // сервер закрыл listener
+				}
+				resp.Body.Close()
+				if resp.StatusCode != http.StatusAccepted {
+					t.Errorf("request failed during shutdown: %d", resp.StatusCode)
+					return
+				}
+				accepted.Add(1)
+			}
+		}()
+	}
+
+	// SIGTERM посреди нагрузки, когда есть запросы в обработке
+	require.Eventually(t, func() bool { return accepted.Load() >= 20 && inFlight.Load() > 0 },
+		5*time.Second, time.Millisecond)
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGTERM))
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("service did not stop")
+	}
+	clients.Wait()
+
+	// каждый принятый запрос дошёл до «брокера»: буфер отправлен при остановке, ничего не потеряно
+	producer.mu.Lock()
+	defer producer.mu.Unlock()
+	require.Empty(t, producer.buffer)
+	require.Len(t, producer.delivered, int(accepted.Load()))
+	require.Zero(t, inFlight.Load())
+}
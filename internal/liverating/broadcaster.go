@@ -0,0 +1,345 @@
+// Package liverating раздаёт обновления рейтингов игр подключённым клиентам (WebSocket).
+package liverating
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	prom_metrics "github.com/RozmiDan/gameReviewHub/pkg/metrics"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// метка stream в метриках WebSocket
+const streamName = "ratings"
+
+// EventRating — тип сообщения с новым агрегатом рейтинга
+const EventRating = "rating"
+
+var (
+	// ErrSlowClient — очередь клиента переполнилась, он отключён
+	ErrSlowClient = errors.New("client is too slow, dropped")
+	// ErrClosed — рассылка остановлена
+	ErrClosed = errors.New("broadcaster stopped")
+)
+
+// TopSource — откуда берётся текущий топ (rating service)
+type TopSource interface {
+	GetTopGames(ctx context.Context, limit, offset int32) ([]entity.GameRating, error)
+}
+
+// UpdateSource — обновления агрегатов со всех реплик.
+// Канал закрывается при отмене ctx или если читатель отстал.
+type UpdateSource interface {
+	SubscribeRatingUpdates(ctx context.Context) <-chan entity.RatingUpdate
+}
+
+// Subscription — на что подписан клиент: конкретные игры или первые Top игр рейтинга
+type Subscription struct {
+	GameIDs []string `json:"game_ids,omitempty"`
+	Top     int      `json:"top,omitempty"`
+}
+
+// Event — сообщение клиенту об изменении рейтинга игры
+type Event struct {
+	Type          string  `json:"type"`
+	GameID        string  `json:"game_id"`
+	AverageRating float64 `json:"average_rating"`
+	RatingsCount  int64   `json:"ratings_count"`
+	// Delta — изменение средней относительно прошлого известного значения
+	Delta *float64 `json:"delta,omitempty"`
+	// Rank — место в топе (с 1), если игра в него входит
+	Rank      int       `json:"rank,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Client — одно подключение. Broadcaster кладёт сообщения в Send и никогда не ждёт:
+// если очередь полна, клиент отключается (Send закрывается, Err() == ErrSlowClient).
+type Client struct {
+	send chan []byte
+	err  error
+
+	games map[string]struct{}
+	top   int
+}
+
+// Send — очередь готовых JSON-сообщений; закрывается при отключении
+func (c *Client) Send() <-chan []byte {
+	return c.send
+}
+
+// Err — причина отключения; читать после закрытия Send
+func (c *Client) Err() error {
+	return c.err
+}
+
+func (c *Client) wants(gameID string, rank int, inTop bool) bool {
+	if _, ok := c.games[gameID]; ok {
+		return true
+	}
+	return inTop && rank < c.top
+}
+
+type Broadcaster struct {
+	top     TopSource
+	updates UpdateSource
+	logger  *zap.Logger
+
+	sendBuffer int
+	maxGames   int
+	maxTop     int
+	topRefresh time.Duration
+	minRefresh time.Duration
+	now        func() time.Time
+
+	mu      sync.Mutex
+	clients map[*Client]struct{}
+	// rank — game_id → место в топе (с 0) по последнему чтению из rating service
+	rank        map[string]int
+	cutoff      float64
+	topFull     bool
+	lastRefresh time.Time
+	// last — последняя известная средняя игры, от неё считается Delta
+	last map[string]float64
+}
+
+func New(top TopSource, updates UpdateSource, logger *zap.Logger, opts ...Option) *Broadcaster {
+	b := &Broadcaster{
+		top:        top,
+		updates:    updates,
+		logger:     logger.With(zap.String("component", "rating-broadcaster")),
+		sendBuffer: _defaultSendBuffer,
+		maxGames:   _defaultMaxGames,
+		maxTop:     _defaultMaxTop,
+		topRefresh: _defaultTopRefresh,
+		minRefresh: _defaultMinRefresh,
+		now:        time.Now,
+		clients:    make(map[*Client]struct{}),
+		rank:       make(map[string]int),
+		last:       make(map[string]float64),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Register добавляет клиента без подписки; пока не вызван Subscribe, сообщений нет
+func (b *Broadcaster) Register() *Client {
+	c := &Client{send: make(chan []byte, b.sendBuffer)}
+
+	b.mu.Lock()
+	b.clients[c] = struct{}{}
+	b.mu.Unlock()
+
+	if prom_metrics.WSConnections != nil {
+		prom_metrics.WSConnections.WithLabelValues(streamName).Inc()
+	}
+	return c
+}
+
+// Unregister убирает клиента; повторный вызов и вызов после отключения безопасны
+func (b *Broadcaster) Unregister(c *Client) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.removeLocked(c, nil)
+}
+
+// Subscribe заменяет подписку клиента
+func (b *Broadcaster) Subscribe(c *Client, sub Subscription) error {
+	if err := b.validate(sub); err != nil {
+		return err
+	}
+
+	games := make(map[string]struct{}, len(sub.GameIDs))
+	for _, id := range sub.GameIDs {
+		games[id] = struct{}{}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c.games = games
+	c.top = sub.Top
+	return nil
+}
+
+func (b *Broadcaster) validate(sub Subscription) error {
+	switch {
+	case len(sub.GameIDs) == 0 && sub.Top == 0:
+		return fmt.Errorf("%w: game_ids or top is required", entity.ErrInvalidSubscription)
+	case len(sub.GameIDs) > 0 && sub.Top != 0:
+		return fmt.Errorf("%w: game_ids and top are mutually exclusive", entity.ErrInvalidSubscription)
+	case len(sub.GameIDs) > b.maxGames:
+		return fmt.Errorf("%w: at most %d game_ids", entity.ErrInvalidSubscription, b.maxGames)
+	case sub.Top < 0 || sub.Top > b.maxTop:
+		return fmt.Errorf("%w: top must be between 1 and %d", entity.ErrInvalidSubscription, b.maxTop)
+	}
+	for _, id := range sub.GameIDs {
+		if _, err := uuid.Parse(id); err != nil {
+			return fmt.Errorf("%w: game_id %q is not a valid UUID", entity.ErrInvalidSubscription, id)
+		}
+	}
+	return nil
+}
+
+// Run читает обновления и периодически перечитывает топ, пока не отменён ctx.
+// Отставшую подписку на обновления открывает заново. При остановке отключает всех клиентов.
+func (b *Broadcaster) Run(ctx context.Context) {
+	b.logger.Info("rating broadcaster started")
+	defer b.closeAll()
+
+	b.refreshTop(ctx, true)
+	ticker := time.NewTicker(b.topRefresh)
+	defer ticker.Stop()
+
+	for {
+		updates := b.updates.SubscribeRatingUpdates(ctx)
+	read:
+		for {
+			select {
+			case <-ctx.Done():
+				b.logger.Info("rating broadcaster stopped")
+				return
+			case <-ticker.C:
+				b.refreshTop(ctx, true)
+			case upd, ok := <-updates:
+				if !ok {
+					break read
+				}
+				b.Broadcast(ctx, upd)
+			}
+		}
+		if ctx.Err() != nil {
+			b.logger.Info("rating broadcaster stopped")
+			return
+		}
+		b.logger.Warn("rating updates subscription closed, resubscribing")
+	}
+}
+
+// Broadcast раздаёт обновление подписанным клиентам, не блокируясь на медленных
+func (b *Broadcaster) Broadcast(ctx context.Context, upd entity.RatingUpdate) {
+	// игра в топе или может в него войти — порядок мог измениться
+	b.mu.Lock()
+	_, inTop := b.rank[upd.GameID]
+	mayEnter := !b.topFull || upd.AverageRating >= b.cutoff
+	b.mu.Unlock()
+	if inTop || mayEnter {
+		b.refreshTop(ctx, false)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	evt := Event{
+		Type:          EventRating,
+		GameID:        upd.GameID,
+		AverageRating: upd.AverageRating,
+		RatingsCount:  upd.RatingsCount,
+		UpdatedAt:     upd.UpdatedAt,
+	}
+	if prev, ok := b.last[upd.GameID]; ok {
+		delta := upd.AverageRating - prev
+		evt.Delta = &delta
+	}
+	b.last[upd.GameID] = upd.AverageRating
+
+	rank, inTop := b.rank[upd.GameID]
+	if inTop {
+		evt.Rank = rank + 1
+	}
+
+	msg, err := json.Marshal(evt)
+	if err != nil {
+		b.logger.Error("failed to marshal rating event", zap.Error(err))
+		return
+	}
+
+	sent := 0
+	for c := range b.clients {
+		if !c.wants(upd.GameID, rank, inTop) {
+			continue
+		}
+		select {
+		case c.send <- msg:
+			sent++
+		default:
+			b.logger.Warn("slow websocket client dropped")
+			b.removeLocked(c, ErrSlowClient)
+		}
+	}
+
+	if sent > 0 && prom_metrics.WSMessagesSent != nil {
+		prom_metrics.WSMessagesSent.WithLabelValues(streamName).Add(float64(sent))
+	}
+}
+
+// refreshTop перечитывает топ; без force — не чаще minRefresh
+func (b *Broadcaster) refreshTop(ctx context.Context, force bool) {
+	b.mu.Lock()
+	if !force && b.now().Sub(b.lastRefresh) < b.minRefresh {
+		b.mu.Unlock()
+		return
+	}
+	b.lastRefresh = b.now()
+	b.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, _defaultTopTimeout)
+	defer cancel()
+
+	games, err := b.top.GetTopGames(ctx, int32(b.maxTop), 0)
+	if err != nil {
+		b.logger.Warn("failed to refresh top games", zap.Error(err))
+		return
+	}
+
+	rank := make(map[string]int, len(games))
+	for i, g := range games {
+		rank[g.GameID] = i
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rank = rank
+	b.topFull = len(games) >= b.maxTop
+	b.cutoff = 0
+	if len(games) > 0 {
+		b.cutoff = games[len(games)-1].AverageRating
+	}
+	// базовые значения для Delta; уже известные не трогаем — топ мог прочитаться после обновления
+	for _, g := range games {
+		if _, ok := b.last[g.GameID]; !ok {
+			b.last[g.GameID] = g.AverageRating
+		}
+	}
+}
+
+func (b *Broadcaster) closeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for c := range b.clients {
+		b.removeLocked(c, ErrClosed)
+	}
+}
+
+// removeLocked закрывает Send; reason == nil — клиент ушёл сам
+func (b *Broadcaster) removeLocked(c *Client, reason error) {
+	if _, ok := b.clients[c]; !ok {
+		return
+	}
+	delete(b.clients, c)
+	c.err = reason
+	close(c.send)
+
+	if prom_metrics.WSConnections != nil {
+		prom_metrics.WSConnections.WithLabelValues(streamName).Dec()
+	}
+	if errors.Is(reason, ErrSlowClient) && prom_metrics.WSClientsDropped != nil {
+		prom_metrics.WSClientsDropped.WithLabelValues(streamName, "slow_client").Inc()
+	}
+}
@@ -0,0 +1,186 @@
+package liverating
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	game1 = "11111111-1111-1111-1111-111111111111"
+	game2 = "22222222-2222-2222-2222-222222222222"
+	game3 = "33333333-3333-3333-3333-333333333333"
+)
+
+type fakeTop struct {
+	games []entity.GameRating
+	calls int
+}
+
+func (f *fakeTop) GetTopGames(ctx context.Context, limit, offset int32) ([]entity.GameRating, error) {
+	f.calls++
+	if int(limit) < len(f.games) {
+		return f.games[:limit], nil
+	}
+	return f.games, nil
+}
+
+type fakeUpdates struct {
+	ch chan entity.RatingUpdate
+}
+
+func (f *fakeUpdates) SubscribeRatingUpdates(ctx context.Context) <-chan entity.RatingUpdate {
+	return f.ch
+}
+
+func newTestBroadcaster(top *fakeTop, opts ...Option) *Broadcaster {
+	b := New(top, &fakeUpdates{}, zap.NewNop(), opts...)
+	b.refreshTop(context.Background(), true)
+	return b
+}
+
+func recv(t *testing.T, c *Client) Event {
+	t.Helper()
+	select {
+	case msg := <-c.Send():
+		var evt Event
+		require.NoError(t, json.Unmarshal(msg, &evt))
+		return evt
+	default:
+		t.Fatal("no message for client")
+	}
+	return Event{}
+}
+
+func TestBroadcaster_RoutesByGameAndTop(t *testing.T) {
+	top := &fakeTop{games: []entity.GameRating{
+		{GameID: game1, AverageRating: 9},
+		{GameID: game2, AverageRating: 8},
+	}}
+	b := newTestBroadcaster(top)
+
+	byGame := b.Register()
+	require.NoError(t, b.Subscribe(byGame, Subscription{GameIDs: []string{game3}}))
+	top1 := b.Register()
+	require.NoError(t, b.Subscribe(top1, Subscription{Top: 1}))
+	top2 := b.Register()
+	require.NoError(t, b.Subscribe(top2, Subscription{Top: 2}))
+	idle := b.Register()
+
+	b.Broadcast(context.Background(), entity.RatingUpdate{GameID: game2, AverageRating: 8.5, RatingsCount: 3})
+
+	evt := recv(t, top2)
+	require.Equal(t, EventRating, evt.Type)
+	require.Equal(t, game2, evt.GameID)
+	require.Equal(t, 2, evt.Rank)
+	require.NotNil(t, evt.Delta)
+	require.InDelta(t, 0.5, *evt.Delta, 1e-9)
+
+	require.Empty(t, top1.Send())
+	require.Empty(t, byGame.Send())
+	require.Empty(t, idle.Send())
+
+	b.Broadcast(context.Background(), entity.RatingUpdate{GameID: game3, AverageRating: 5})
+	evt = recv(t, byGame)
+	require.Equal(t, game3, evt.GameID)
+	require.Zero(t, evt.Rank)
+	require.Nil(t, evt.Delta)
+}
+
+func TestBroadcaster_NewLeaderRefreshesTop(t *testing.T) {
+	top := &fakeTop{games: []entity.GameRating{{GameID: game1, AverageRating: 9}}}
+	b := newTestBroadcaster(top, Limits(10, 1))
+	b.minRefresh = 0
+
+	c := b.Register()
+	require.NoError(t, b.Subscribe(c, Subscription{Top: 1}))
+
+	// rating service уже пересчитал: game2 вышла на первое место
+	top.games = []entity.GameRating{{GameID: game2, AverageRating: 9.5}, {GameID: game1, AverageRating: 9}}
+	b.Broadcast(context.Background(), entity.RatingUpdate{GameID: game2, AverageRating: 9.5})
+
+	evt := recv(t, c)
+	require.Equal(t, game2, evt.GameID)
+	require.Equal(t, 1, evt.Rank)
+	require.Equal(t, 2, top.calls)
+
+	// ниже отсечки топа — rating service не дёргаем
+	b.Broadcast(context.Background(), entity.RatingUpdate{GameID: game3, AverageRating: 3})
+	require.Equal(t, 2, top.calls)
+	require.Empty(t, c.Send())
+}
+
+func TestBroadcaster_SlowClientDropped(t *testing.T) {
+	b := newTestBroadcaster(&fakeTop{}, SendBuffer(1))
+
+	slow := b.Register()
+	require.NoError(t, b.Subscribe(slow, Subscription{GameIDs: []string{game1}}))
+	fast := b.Register()
+	require.NoError(t, b.Subscribe(fast, Subscription{GameIDs: []string{game1}}))
+
+	b.Broadcast(context.Background(), entity.RatingUpdate{GameID: game1, AverageRating: 7})
+	recv(t, fast)
+	b.Broadcast(context.Background(), entity.RatingUpdate{GameID: game1, AverageRating: 8})
+
+	<-slow.Send()
+	_, ok := <-slow.Send()
+	require.False(t, ok)
+	require.ErrorIs(t, slow.Err(), ErrSlowClient)
+
+	require.Equal(t, game1, recv(t, fast).GameID)
+	require.Len(t, b.clients, 1)
+
+	// отключённого хабом клиента handler всё равно снимает — это безопасно
+	b.Unregister(slow)
+	b.Unregister(fast)
+	require.Empty(t, b.clients)
+}
+
+func TestBroadcaster_SubscriptionValidation(t *testing.T) {
+	b := newTestBroadcaster(&fakeTop{}, Limits(2, 10))
+	c := b.Register()
+
+	for name, sub := range map[string]Subscription{
+		"empty":          {},
+		"both":           {GameIDs: []string{game1}, Top: 3},
+		"too many games": {GameIDs: []string{game1, game2, game3}},
+		"top too large":  {Top: 11},
+		"negative top":   {Top: -1},
+		"invalid uuid":   {GameIDs: []string{"nope"}},
+	} {
+		require.ErrorIs(t, b.Subscribe(c, sub), entity.ErrInvalidSubscription, name)
+	}
+	require.NoError(t, b.Subscribe(c, Subscription{Top: 10}))
+}
+
+func TestBroadcaster_RunStopsAndClosesClients(t *testing.T) {
+	updates := &fakeUpdates{ch: make(chan entity.RatingUpdate, 1)}
+	b := New(&fakeTop{}, updates, zap.NewNop())
+	c := b.Register()
+	require.NoError(t, b.Subscribe(c, Subscription{GameIDs: []string{game1}}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.Run(ctx)
+	}()
+
+	updates.ch <- entity.RatingUpdate{GameID: game1, AverageRating: 6}
+	select {
+	case <-c.Send():
+	case <-time.After(time.Second):
+		t.Fatal("update was not delivered")
+	}
+
+	cancel()
+	<-done
+	_, ok := <-c.Send()
+	require.False(t, ok)
+	require.ErrorIs(t, c.Err(), ErrClosed)
+}
@@ -0,0 +1,45 @@
+package liverating
+
+import "time"
+
+const (
+	_defaultSendBuffer = 64
+	_defaultMaxGames   = 50
+	_defaultMaxTop     = 100
+	_defaultTopRefresh = 30 * time.Second
+	_defaultMinRefresh = time.Second
+	_defaultTopTimeout = 2 * time.Second
+)
+
+// Option -.
+type Option func(*Broadcaster)
+
+// SendBuffer — очередь сообщений клиента; переполнилась — клиент отключается
+func SendBuffer(n int) Option {
+	return func(b *Broadcaster) {
+		if n > 0 {
+			b.sendBuffer = n
+		}
+	}
+}
+
+// Limits — сколько игр можно перечислить в подписке и максимальный N для "top N"
+func Limits(maxGames, maxTop int) Option {
+	return func(b *Broadcaster) {
+		if maxGames > 0 {
+			b.maxGames = maxGames
+		}
+		if maxTop > 0 {
+			b.maxTop = maxTop
+		}
+	}
+}
+
+// TopRefresh — как часто перечитывать топ у rating service, даже если обновлений не было
+func TopRefresh(d time.Duration) Option {
+	return func(b *Broadcaster) {
+		if d > 0 {
+			b.topRefresh = d
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Package outbox переносит доменные события из таблицы event_outbox в брокер.
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"go.uber.org/zap"
+)
+
+const (
+	_defaultPollInterval = time.Second
+	_defaultBatchSize    = 100
+)
+
+type Store interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	FetchPendingEvents(ctx context.Context, limit int) ([]entity.DomainEvent, error)
+	MarkEventsPublished(ctx context.Context, ids []string) error
+}
+
+type Publisher interface {
+	PublishEvent(ctx context.Context, evt entity.DomainEvent) error
+}
+
+type Relay struct {
+	store     Store
+	pub       Publisher
+	logger    *zap.Logger
+	interval  time.Duration
+	batchSize int
+}
+
+func New(store Store, pub Publisher, logger *zap.Logger, interval time.Duration, batchSize int) *Relay {
+	if interval <= 0 {
+		interval = _defaultPollInterval
+	}
+	if batchSize <= 0 {
+		batchSize = _defaultBatchSize
+	}
+	return &Relay{
+		store:     store,
+		pub:       pub,
+		logger:    logger.With(zap.String("component", "outbox-relay")),
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run опрашивает outbox, пока не отменён ctx. Полный батч — сразу берём следующий.
+func (r *Relay) Run(ctx context.Context) {
+	r.logger.Info("outbox relay started")
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := r.Flush(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.logger.Warn("outbox flush failed", zap.Error(err))
+		}
+		if err == nil && n == r.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("outbox relay stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush отправляет один батч и возвращает число отправленных событий.
+// События уходят по порядку; на первой ошибке останавливаемся, чтобы не нарушить порядок
+// внутри игры, а уже отправленные помечаются — повторно уйдёт только хвост.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	var published int
+	var pubErr error
+
+	err := r.store.WithinTx(ctx, func(ctx context.Context) error {
+		events, err := r.store.FetchPendingEvents(ctx, r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, 0, len(events))
+		for _, evt := range events {
+			if pubErr = r.pub.PublishEvent(ctx, evt); pubErr != nil {
+				break
+			}
+			ids = append(ids, evt.ID)
+		}
+		published = len(ids)
+		return r.store.MarkEventsPublished(ctx, ids)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if published > 0 {
+		r.logger.Debug("outbox events published", zap.Int("count", published))
+	}
+	return published, pubErr
+}
@@ -0,0 +1,86 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeStore struct {
+	pending   []entity.DomainEvent
+	published []string
+}
+
+func (f *fakeStore) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (f *fakeStore) FetchPendingEvents(ctx context.Context, limit int) ([]entity.DomainEvent, error) {
+	if len(f.pending) > limit {
+		return f.pending[:limit], nil
+	}
+	return f.pending, nil
+}
+
+func (f *fakeStore) MarkEventsPublished(ctx context.Context, ids []string) error {
+	f.published = append(f.published, ids...)
+	f.pending = f.pending[len(ids):]
+	return nil
+}
+
+type fakePublisher struct {
+	failOn string
+	sent   []string
+}
+
+func (f *fakePublisher) PublishEvent(ctx context.Context, evt entity.DomainEvent) error {
+	if evt.ID == f.failOn {
+		return errors.New("broker down")
+	}
+	f.sent = append(f.sent, evt.ID)
+	return nil
+}
+
+func events(ids ...string) []entity.DomainEvent {
+	out := make([]entity.DomainEvent, len(ids))
+	for i, id := range ids {
+		out[i] = entity.DomainEvent{ID: id, Type: entity.EventGameCreated, Aggregate: entity.AggregateGame}
+	}
+	return out
+}
+
+func TestRelay_Flush(t *testing.T) {
+	store := &fakeStore{pending: events("e1", "e2", "e3")}
+	pub := &fakePublisher{}
+	relay := New(store, pub, zap.NewNop(), 0, 2)
+
+	n, err := relay.Flush(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	n, err = relay.Flush(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	require.Equal(t, []string{"e1", "e2", "e3"}, pub.sent)
+	require.Equal(t, []string{"e1", "e2", "e3"}, store.published)
+	require.Empty(t, store.pending)
+}
+
+func TestRelay_FlushStopsOnFailure(t *testing.T) {
+	store := &fakeStore{pending: events("e1", "e2", "e3")}
+	pub := &fakePublisher{failOn: "e2"}
+	relay := New(store, pub, zap.NewNop(), 0, 10)
+
+	n, err := relay.Flush(context.Background())
+	require.Error(t, err)
+	require.Equal(t, 1, n)
+
+	// e2 и e3 остаются в outbox и уйдут следующим проходом в том же порядке
+	require.Equal(t, []string{"e1"}, store.published)
+	require.Equal(t, events("e2", "e3"), store.pending)
+}
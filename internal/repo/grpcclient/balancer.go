@@ -0,0 +1,87 @@
+package rating
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	prom_metrics "github.com/RozmiDan/gameReviewHub/pkg/metrics"
+	"google.golang.org/grpc"
+	_ "google.golang.org/grpc/health" // регистрирует клиентский health-check для балансировщика
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+	"google.golang.org/grpc/status"
+)
+
+const backendsScheme = "rating-backends"
+
+// dialTarget превращает список адресов в target для grpc.
+//   - один адрес (в том числе "dns:///rating:50051") используется как есть,
+//     dns-резолвер сам вернёт все A-записи;
+//   - несколько адресов отдаются через manual-резолвер, локальный для этого соединения.
+func dialTarget(addrs []string) (string, []grpc.DialOption, error) {
+	cleaned := make([]string, 0, len(addrs))
+	for _, a := range addrs {
+		if a = strings.TrimSpace(a); a != "" {
+			cleaned = append(cleaned, a)
+		}
+	}
+
+	switch len(cleaned) {
+	case 0:
+		return "", nil, fmt.Errorf("no rating service addresses configured")
+	case 1:
+		return cleaned[0], nil, nil
+	}
+
+	state := resolver.State{Addresses: make([]resolver.Address, len(cleaned))}
+	for i, a := range cleaned {
+		if strings.Contains(a, "://") {
+			return "", nil, fmt.Errorf("scheme is allowed only for a single address, got %q", a)
+		}
+		state.Addresses[i] = resolver.Address{Addr: a}
+	}
+
+	r := manual.NewBuilderWithScheme(backendsScheme)
+	r.InitialState(state)
+
+	return backendsScheme + ":///rating", []grpc.DialOption{grpc.WithResolvers(r)}, nil
+}
+
+// serviceConfig включает round_robin и, опционально, клиентский health-check:
+// бэкенды, ответившие NOT_SERVING, исключаются из ротации
+func serviceConfig(healthCheck bool, healthService string) string {
+	if !healthCheck {
+		return `{"loadBalancingConfig":[{"round_robin":{}}]}`
+	}
+	return fmt.Sprintf(`{"loadBalancingConfig":[{"round_robin":{}}],"healthCheckConfig":{"serviceName":%q}}`,
+		healthService)
+}
+
+// backendMetricsInterceptor пишет метрики по каждому бэкенду.
+// Стоит после ретраев, поэтому каждая попытка учитывается отдельно.
+func backendMetricsInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+		var p peer.Peer
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Peer(&p))...)
+
+		if prom_metrics.GRPCClientRequests == nil {
+			return err
+		}
+
+		backend := "unknown"
+		if p.Addr != nil {
+			backend = p.Addr.String()
+		}
+		name := shortMethod(method)
+		prom_metrics.GRPCClientRequests.WithLabelValues(backend, name, status.Code(err).String()).Inc()
+		prom_metrics.GRPCClientDuration.WithLabelValues(backend, name).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
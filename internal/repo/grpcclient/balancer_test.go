@@ -0,0 +1,119 @@
+package rating
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ratingv1 "github.com/RozmiDan/gamehub-protos/gen/go/gamehub"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// stubBackend — in-process rating service, считающий обращения
+type stubBackend struct {
+	ratingv1.UnimplementedRatingServiceServer
+	addr   string
+	calls  atomic.Int64
+	health *health.Server
+	srv    *grpc.Server
+}
+
+func (s *stubBackend) GetTopGames(ctx context.Context, req *ratingv1.GetTopGamesRequest) (*ratingv1.GetTopGamesResponse, error) {
+	s.calls.Add(1)
+	return &ratingv1.GetTopGamesResponse{}, nil
+}
+
+func startBackends(t *testing.T, n int) []*stubBackend {
+	t.Helper()
+	backends := make([]*stubBackend, n)
+	for i := range backends {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		b := &stubBackend{addr: lis.Addr().String(), health: health.NewServer(), srv: grpc.NewServer()}
+		ratingv1.RegisterRatingServiceServer(b.srv, b)
+		healthpb.RegisterHealthServer(b.srv, b.health)
+		go b.srv.Serve(lis)
+		t.Cleanup(b.srv.Stop)
+
+		backends[i] = b
+	}
+	return backends
+}
+
+func addrsOf(backends []*stubBackend) []string {
+	out := make([]string, len(backends))
+	for i, b := range backends {
+		out[i] = b.addr
+	}
+	return out
+}
+
+func TestClient_RoundRobinAcrossBackends(t *testing.T) {
+	backends := startBackends(t, 3)
+
+	client, err := New(context.Background(), zap.NewNop(), addrsOf(backends), 2*time.Second)
+	require.NoError(t, err)
+
+	// ждём, пока все сабканалы станут READY, и проверяем, что каждый получил трафик
+	require.Eventually(t, func() bool {
+		if _, err := client.GetTopGames(context.Background(), 10, 0); err != nil {
+			return false
+		}
+		for _, b := range backends {
+			if b.calls.Load() == 0 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestClient_UnhealthyBackendIsSkipped(t *testing.T) {
+	backends := startBackends(t, 3)
+
+	client, err := New(context.Background(), zap.NewNop(), addrsOf(backends), 2*time.Second)
+	require.NoError(t, err)
+
+	sick := backends[0]
+	sick.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	// после того как балансировщик увидит NOT_SERVING, бэкенд перестаёт получать вызовы
+	require.Eventually(t, func() bool {
+		before := sick.calls.Load()
+		for i := 0; i < 20; i++ {
+			if _, err := client.GetTopGames(context.Background(), 10, 0); err != nil {
+				return false
+			}
+		}
+		return sick.calls.Load() == before
+	}, 5*time.Second, 50*time.Millisecond)
+
+	for _, b := range backends[1:] {
+		require.Positive(t, b.calls.Load())
+	}
+}
+
+func TestDialTarget(t *testing.T) {
+	target, opts, err := dialTarget([]string{"dns:///rating:50051"})
+	require.NoError(t, err)
+	require.Equal(t, "dns:///rating:50051", target)
+	require.Empty(t, opts)
+
+	target, opts, err = dialTarget([]string{"a:1", " b:2 ", ""})
+	require.NoError(t, err)
+	require.Equal(t, backendsScheme+":///rating", target)
+	require.Len(t, opts, 1)
+
+	_, _, err = dialTarget(nil)
+	require.Error(t, err)
+
+	_, _, err = dialTarget([]string{"dns:///a:1", "b:2"})
+	require.Error(t, err)
+}
@@ -0,0 +1,198 @@
+package rating
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	prom_metrics "github.com/RozmiDan/gameReviewHub/pkg/metrics"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateHalfOpen
+	stateOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case stateClosed:
+		return "closed"
+	case stateHalfOpen:
+		return "half-open"
+	case stateOpen:
+		return "open"
+	}
+	return "unknown"
+}
+
+// circuitBreaker — простой breaker на последовательных ошибках:
+// closed → (threshold ошибок подряд) → open → (openTimeout) → half-open → closed/open
+type circuitBreaker struct {
+	name             string
+	failureThreshold uint32
+	openTimeout      time.Duration
+	halfOpenRequests uint32
+	logger           *zap.Logger
+	now              func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures uint32
+	inFlight uint32
+	openedAt time.Time
+	// generation растёт при каждой смене состояния: результат вызова, пропущенного
+	// в другом поколении (медленный вызов из closed, закончившийся в half-open), не учитывается
+	generation uint64
+}
+
+func newCircuitBreaker(name string, threshold uint32, openTimeout time.Duration,
+	halfOpen uint32, logger *zap.Logger) *circuitBreaker {
+
+	if threshold == 0 {
+		threshold = 1
+	}
+	if halfOpen == 0 {
+		halfOpen = 1
+	}
+	cb := &circuitBreaker{
+		name:             name,
+		failureThreshold: threshold,
+		openTimeout:      openTimeout,
+		halfOpenRequests: halfOpen,
+		logger:           logger.With(zap.String("component", "circuit-breaker")),
+		now:              time.Now,
+	}
+	cb.reportState()
+	return cb
+}
+
+// allow решает, можно ли пропустить вызов; поколение передаётся в done/release
+func (cb *circuitBreaker) allow() (uint64, bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case stateOpen:
+		if cb.now().Sub(cb.openedAt) < cb.openTimeout {
+			return 0, false
+		}
+		cb.setState(stateHalfOpen)
+		fallthrough
+	case stateHalfOpen:
+		if cb.inFlight >= cb.halfOpenRequests {
+			return 0, false
+		}
+		cb.inFlight++
+	}
+	return cb.generation, true
+}
+
+// done фиксирует результат вызова, пропущенного через allow
+func (cb *circuitBreaker) done(gen uint64, success bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	// состояние сменилось, пока шёл вызов: его результат относится к прошлому состоянию
+	if gen != cb.generation {
+		return
+	}
+
+	if cb.state == stateHalfOpen && cb.inFlight > 0 {
+		cb.inFlight--
+	}
+
+	if success {
+		cb.failures = 0
+		if cb.state != stateClosed {
+			cb.setState(stateClosed)
+		}
+		return
+	}
+
+	cb.failures++
+	if cb.state == stateHalfOpen || cb.failures >= cb.failureThreshold {
+		cb.openedAt = cb.now()
+		cb.setState(stateOpen)
+	}
+}
+
+// release освобождает слот half-open без изменения состояния
+func (cb *circuitBreaker) release(gen uint64) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if gen == cb.generation && cb.state == stateHalfOpen && cb.inFlight > 0 {
+		cb.inFlight--
+	}
+}
+
+func (cb *circuitBreaker) currentState() breakerState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// setState вызывается под мьютексом
+func (cb *circuitBreaker) setState(s breakerState) {
+	if cb.state == s {
+		return
+	}
+	cb.logger.Warn("circuit breaker state changed",
+		zap.String("target", cb.name),
+		zap.String("from", cb.state.String()),
+		zap.String("to", s.String()),
+	)
+	cb.state = s
+	cb.inFlight = 0
+	cb.generation++
+	cb.reportState()
+}
+
+func (cb *circuitBreaker) reportState() {
+	// метрики могут быть не инициализированы (например, в тестах)
+	if prom_metrics.GRPCBreakerState == nil {
+		return
+	}
+	prom_metrics.GRPCBreakerState.WithLabelValues(cb.name).Set(float64(cb.state))
+}
+
+// isBreakerFailure — какие ошибки считаем отказом сервиса, а не бизнес-ответом
+func isBreakerFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
+
+// unaryInterceptor отбивает вызовы с entity.ErrServiceUnavailable, пока breaker открыт
+func (cb *circuitBreaker) unaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+		gen, ok := cb.allow()
+		if !ok {
+			return entity.ErrServiceUnavailable
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		// отмена со стороны клиента — не проблема сервиса
+		if err != nil && ctx.Err() == context.Canceled {
+			cb.release(gen)
+			return err
+		}
+		cb.done(gen, !isBreakerFailure(err))
+		return err
+	}
+}
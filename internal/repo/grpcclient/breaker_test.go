@@ -0,0 +1,122 @@
+package rating
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCircuitBreaker_Interceptor(t *testing.T) {
+	now := time.Now()
+	cb := newCircuitBreaker("test", 2, time.Second, 1, zap.NewNop())
+	cb.now = func() time.Time { return now }
+	interceptor := cb.unaryInterceptor()
+
+	calls := 0
+	var invokeErr error
+	invoker := func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return invokeErr
+	}
+	call := func() error {
+		return interceptor(context.Background(), "/svc/Method", nil, nil, nil, invoker)
+	}
+
+	// бизнес-ошибки не открывают breaker
+	invokeErr = status.Error(codes.NotFound, "no ratings")
+	for i := 0; i < 3; i++ {
+		require.Equal(t, codes.NotFound, status.Code(call()))
+	}
+	require.Equal(t, stateClosed, cb.currentState())
+
+	// два Unavailable подряд → open
+	invokeErr = status.Error(codes.Unavailable, "down")
+	require.Error(t, call())
+	require.Error(t, call())
+	require.Equal(t, stateOpen, cb.currentState())
+
+	// пока open — fail fast без вызова сервиса
+	calls = 0
+	require.ErrorIs(t, call(), entity.ErrServiceUnavailable)
+	require.Equal(t, 0, calls)
+
+	// после openTimeout пропускаем пробный вызов; неудача снова открывает
+	now = now.Add(time.Second)
+	require.Equal(t, codes.Unavailable, status.Code(call()))
+	require.Equal(t, 1, calls)
+	require.Equal(t, stateOpen, cb.currentState())
+
+	// успешный пробный вызов закрывает breaker
+	now = now.Add(time.Second)
+	invokeErr = nil
+	require.NoError(t, call())
+	require.Equal(t, stateClosed, cb.currentState())
+}
+
+func TestCircuitBreaker_HalfOpenLimit(t *testing.T) {
+	now := time.Now()
+	cb := newCircuitBreaker("test", 1, time.Second, 1, zap.NewNop())
+	cb.now = func() time.Time { return now }
+
+	gen, ok := cb.allow()
+	require.True(t, ok)
+	cb.done(gen, false)
+	require.Equal(t, stateOpen, cb.currentState())
+
+	now = now.Add(time.Second)
+	gen, ok = cb.allow()
+	require.True(t, ok)
+	// второй параллельный вызов в half-open не пропускаем
+	_, ok = cb.allow()
+	require.False(t, ok)
+
+	cb.release(gen)
+	_, ok = cb.allow()
+	require.True(t, ok)
+}
+
+func TestCircuitBreaker_StaleClosedCallIgnoredInHalfOpen(t *testing.T) {
+	now := time.Now()
+	cb := newCircuitBreaker("test", 1, time.Second, 1, zap.NewNop())
+	cb.now = func() time.Time { return now }
+
+	// медленный вызов пропущен в closed
+	slow, ok := cb.allow()
+	require.True(t, ok)
+
+	// параллельный вызов падает и открывает breaker, затем — half-open с пробой
+	gen, ok := cb.allow()
+	require.True(t, ok)
+	cb.done(gen, false)
+	require.Equal(t, stateOpen, cb.currentState())
+	now = now.Add(time.Second)
+	probe, ok := cb.allow()
+	require.True(t, ok)
+	require.Equal(t, stateHalfOpen, cb.currentState())
+
+	// медленный вызов завершился успешно: breaker не закрывается и слот пробы не освобождается
+	cb.done(slow, true)
+	require.Equal(t, stateHalfOpen, cb.currentState())
+	_, ok = cb.allow()
+	require.False(t, ok)
+	cb.release(slow)
+	_, ok = cb.allow()
+	require.False(t, ok)
+
+	// решает только проба
+	cb.done(probe, false)
+	require.Equal(t, stateOpen, cb.currentState())
+}
+
+func TestShortMethod(t *testing.T) {
+	require.Equal(t, "GetTopGames", shortMethod("/gamehub.rating.RatingService/GetTopGames"))
+	require.Equal(t, "Ping", shortMethod("Ping"))
+}
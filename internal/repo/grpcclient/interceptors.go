@@ -0,0 +1,76 @@
+package rating
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	prom_metrics "github.com/RozmiDan/gameReviewHub/pkg/metrics"
+	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// callMetricsInterceptor пишет длительность вызова глазами usecase: с ретраями и дедлайном.
+// Стоит первым в цепочке; отказ открытого breaker'а пишется с code="BreakerOpen".
+func callMetricsInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		if prom_metrics.GRPCCallDuration == nil {
+			return err
+		}
+		code := status.Code(err).String()
+		if errors.Is(err, entity.ErrServiceUnavailable) {
+			code = "BreakerOpen"
+		}
+		prom_metrics.GRPCCallDuration.WithLabelValues(shortMethod(method), code).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+// deadlineInterceptor ограничивает каждый вызов дедлайном из конфига.
+// Если у ctx уже есть более ранний дедлайн — остаётся он.
+func deadlineInterceptor(def time.Duration, perMethod map[string]time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+
+		timeout := def
+		if t, ok := perMethod[shortMethod(method)]; ok && t > 0 {
+			timeout = t
+		}
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// retryInterceptor повторяет вызовы только при codes.Unavailable
+func retryInterceptor(o *options) grpc.UnaryClientInterceptor {
+	if o.retryAttempts <= 1 {
+		return grpc_retry.UnaryClientInterceptor(grpc_retry.Disable())
+	}
+	return grpc_retry.UnaryClientInterceptor(
+		grpc_retry.WithMax(o.retryAttempts),
+		grpc_retry.WithCodes(codes.Unavailable),
+		grpc_retry.WithBackoff(grpc_retry.BackoffExponentialWithJitter(o.retryBackoff, o.retryJitter)),
+	)
+}
+
+// "/gamehub.rating.RatingService/GetTopGames" → "GetTopGames"
+func shortMethod(fullMethod string) string {
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[i+1:]
+	}
+	return fullMethod
+}
@@ -0,0 +1,112 @@
+package rating
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+const (
+	_defaultCallTimeout      = time.Second
+	_defaultRetryAttempts    = 3
+	_defaultRetryBackoff     = 50 * time.Millisecond
+	_defaultRetryJitter      = 0.2
+	_defaultFailureThreshold = 5
+	_defaultOpenTimeout      = 10 * time.Second
+	_defaultHalfOpenRequests = 1
+)
+
+type options struct {
+	callTimeout    time.Duration
+	methodTimeouts map[string]time.Duration
+
+	retryAttempts uint
+	retryBackoff  time.Duration
+	retryJitter   float64
+
+	failureThreshold uint32
+	openTimeout      time.Duration
+	halfOpenRequests uint32
+
+	healthCheck   bool
+	healthService string
+
+	tls *tlsFiles
+
+	dialer func(context.Context, string) (net.Conn, error)
+}
+
+// Option -.
+type Option func(*options)
+
+// CallTimeouts задаёт дедлайн вызова по умолчанию и дедлайны для отдельных методов.
+// Ключ — короткое имя метода, например "GetTopGames".
+func CallTimeouts(def time.Duration, perMethod map[string]time.Duration) Option {
+	return func(o *options) {
+		if def > 0 {
+			o.callTimeout = def
+		}
+		o.methodTimeouts = perMethod
+	}
+}
+
+// Retry задаёт число попыток и экспоненциальный backoff с джиттером для codes.Unavailable.
+func Retry(attempts uint, backoff time.Duration, jitter float64) Option {
+	return func(o *options) {
+		o.retryAttempts = attempts
+		o.retryBackoff = backoff
+		o.retryJitter = jitter
+	}
+}
+
+// Breaker -.
+func Breaker(failureThreshold uint32, openTimeout time.Duration, halfOpenRequests uint32) Option {
+	return func(o *options) {
+		o.failureThreshold = failureThreshold
+		o.openTimeout = openTimeout
+		o.halfOpenRequests = halfOpenRequests
+	}
+}
+
+// HealthCheck включает клиентский grpc.health.v1 для исключения больных бэкендов.
+// service — имя сервиса в health-сервере, пустая строка означает сервер целиком.
+func HealthCheck(enabled bool, service string) Option {
+	return func(o *options) {
+		o.healthCheck = enabled
+		o.healthService = service
+	}
+}
+
+// TLS включает TLS до rating service. certFile/keyFile задают клиентский сертификат для mTLS,
+// serverName переопределяет имя, с которым сверяется сертификат сервера.
+// Файлы перечитываются при изменении на диске.
+func TLS(caFile, certFile, keyFile, serverName string) Option {
+	return func(o *options) {
+		o.tls = &tlsFiles{
+			caFile:     caFile,
+			certFile:   certFile,
+			keyFile:    keyFile,
+			serverName: serverName,
+		}
+	}
+}
+
+// Dialer подменяет сетевой дозвон, например на bufconn для встроенного фейкового сервиса.
+func Dialer(d func(context.Context, string) (net.Conn, error)) Option {
+	return func(o *options) {
+		o.dialer = d
+	}
+}
+
+func defaultOptions() *options {
+	return &options{
+		callTimeout:      _defaultCallTimeout,
+		retryAttempts:    _defaultRetryAttempts,
+		retryBackoff:     _defaultRetryBackoff,
+		retryJitter:      _defaultRetryJitter,
+		failureThreshold: _defaultFailureThreshold,
+		openTimeout:      _defaultOpenTimeout,
+		halfOpenRequests: _defaultHalfOpenRequests,
+		healthCheck:      true,
+	}
+}
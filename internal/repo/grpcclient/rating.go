@@ -2,47 +2,113 @@ package rating
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/RozmiDan/gameReviewHub/internal/entity"
 	ratingv1 "github.com/RozmiDan/gamehub-protos/gen/go/gamehub"
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 )
 
+const breakerTarget = "rating_service"
+
 type Client struct {
 	api    ratingv1.RatingServiceClient
+	conn   *grpc.ClientConn
 	logger *zap.Logger
 }
 
-func New(ctx context.Context, log *zap.Logger, addr string,
-	timeout time.Duration) (*Client, error) {
+func New(ctx context.Context, log *zap.Logger, addrs []string,
+	timeout time.Duration, opts ...Option) (*Client, error) {
+
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	target, targetOpts, err := dialTarget(addrs)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds credentials.TransportCredentials = insecure.NewCredentials()
+	if o.tls != nil {
+		reloader, err := newTLSReloader(*o.tls, log)
+		if err != nil {
+			return nil, err
+		}
+		creds = &reloadingCreds{reloader: reloader}
+	}
+
+	breaker := newCircuitBreaker(breakerTarget, o.failureThreshold, o.openTimeout, o.halfOpenRequests, log)
 
 	dialCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// 2) блокирующий Dial — не вернётся, пока соединение не перейдёт в состояние READY или не истечёт dialCtx
-	conn, err := grpc.DialContext(
-		dialCtx,
-		addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	dialOpts := append(targetOpts,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(serviceConfig(o.healthCheck, o.healthService)),
 		grpc.WithBlock(),
+		// спан на каждую попытку вызова, trace context уходит в metadata
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		// порядок: длительность вызова → общий дедлайн → breaker → ретраи → метрики и логирование каждой попытки
 		grpc.WithChainUnaryInterceptor(
+			callMetricsInterceptor(),
+			deadlineInterceptor(o.callTimeout, o.methodTimeouts),
+			breaker.unaryInterceptor(),
+			retryInterceptor(o),
+			backendMetricsInterceptor(),
 			grpc_zap.UnaryClientInterceptor(log),
 		),
 	)
+
+	if o.dialer != nil {
+		dialOpts = append(dialOpts, grpc.WithContextDialer(o.dialer))
+	}
+
+	// блокирующий Dial — не вернётся, пока хотя бы один бэкенд не перейдёт в READY или не истечёт dialCtx
+	conn, err := grpc.DialContext(dialCtx, target, dialOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("cannot dial rating service at %s: %w", addr, err)
+		return nil, fmt.Errorf("cannot dial rating service at %s: %w", strings.Join(addrs, ","), err)
 	}
 
-	log.Info("connected to rating service", zap.String("addr", addr))
+	log.Info("connected to rating service", zap.Strings("addrs", addrs))
 	api := ratingv1.NewRatingServiceClient(conn)
-	return &Client{api: api, logger: log}, nil
+	return &Client{api: api, conn: conn, logger: log}, nil
+}
+
+// Ping — проверка для /readyz: есть ли хоть один живой бэкенд.
+// Смотрим состояние соединения, а не шлём RPC — проверка не трогает breaker и метрики вызовов.
+func (c *Client) Ping(ctx context.Context) error {
+	for {
+		state := c.conn.GetState()
+		switch state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.Idle:
+			c.conn.Connect()
+		case connectivity.Shutdown:
+			return errors.New("rating service connection is closed")
+		}
+		if !c.conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("rating service is %s: %w", strings.ToLower(state.String()), ctx.Err())
+		}
+	}
+}
+
+// Close закрывает соединение; вызовы после него завершаются codes.Canceled
+func (c *Client) Close() error {
+	return c.conn.Close()
 }
 
 func (c *Client) SubmitRating(ctx context.Context, userID, gameID string, rating int32) (bool, error) {
@@ -0,0 +1,184 @@
+package rating
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+)
+
+// tlsFiles — пути к файлам сертификатов; пустой CertFile/KeyFile означает TLS без клиентского сертификата
+type tlsFiles struct {
+	caFile     string
+	certFile   string
+	keyFile    string
+	serverName string
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// tlsReloader перечитывает сертификаты, когда файлы на диске меняются.
+// Проверка делается при каждом хендшейке: сертификаты нужны только в этот момент,
+// поэтому отдельная горутина-наблюдатель не требуется.
+type tlsReloader struct {
+	files  tlsFiles
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	stamps map[string]fileStamp
+	config *tls.Config
+}
+
+func newTLSReloader(files tlsFiles, logger *zap.Logger) (*tlsReloader, error) {
+	if (files.certFile == "") != (files.keyFile == "") {
+		return nil, errors.New("tls: cert_file and key_file must be set together")
+	}
+	r := &tlsReloader{
+		files:  files,
+		logger: logger.With(zap.String("component", "tls-reloader")),
+	}
+	if _, err := r.current(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// current возвращает актуальный tls.Config, перечитывая файлы при изменении.
+// Если новые файлы битые — остаёмся на предыдущей конфигурации.
+func (r *tlsReloader) current() (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stamps, err := r.stat()
+	if err != nil {
+		if r.config != nil {
+			r.logger.Warn("cannot stat tls files, keeping previous config", zap.Error(err))
+			return r.config, nil
+		}
+		return nil, err
+	}
+	if r.config != nil && sameStamps(stamps, r.stamps) {
+		return r.config, nil
+	}
+
+	cfg, err := r.load()
+	if err != nil {
+		if r.config != nil {
+			r.logger.Error("cannot reload tls files, keeping previous config", zap.Error(err))
+			return r.config, nil
+		}
+		return nil, err
+	}
+
+	if r.config != nil {
+		r.logger.Info("tls certificates reloaded")
+	}
+	r.config = cfg
+	r.stamps = stamps
+	return cfg, nil
+}
+
+func (r *tlsReloader) stat() (map[string]fileStamp, error) {
+	stamps := make(map[string]fileStamp, 3)
+	for _, path := range []string{r.files.caFile, r.files.certFile, r.files.keyFile} {
+		if path == "" {
+			continue
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("tls: stat %s: %w", path, err)
+		}
+		stamps[path] = fileStamp{modTime: fi.ModTime(), size: fi.Size()}
+	}
+	return stamps, nil
+}
+
+func (r *tlsReloader) load() (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: r.files.serverName,
+	}
+
+	// без CA проверяем сервер по системному пулу
+	if r.files.caFile != "" {
+		pem, err := os.ReadFile(r.files.caFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: read ca bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("tls: no certificates found in %s", r.files.caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if r.files.certFile != "" {
+		cert, err := tls.LoadX509KeyPair(r.files.certFile, r.files.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: load client key pair: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+func sameStamps(a, b map[string]fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || !w.modTime.Equal(v.modTime) || w.size != v.size {
+			return false
+		}
+	}
+	return true
+}
+
+// reloadingCreds — TransportCredentials, которые на каждом хендшейке
+// берут свежий tls.Config из reloader'а и делегируют стандартным grpc-credentials
+type reloadingCreds struct {
+	reloader *tlsReloader
+}
+
+func (c *reloadingCreds) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	cfg, err := c.reloader.current()
+	if err != nil {
+		return nil, nil, err
+	}
+	return credentials.NewTLS(cfg).ClientHandshake(ctx, authority, conn)
+}
+
+func (c *reloadingCreds) ServerHandshake(net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("tls: reloading credentials are client-only")
+}
+
+func (c *reloadingCreds) Info() credentials.ProtocolInfo {
+	return credentials.NewTLS(&tls.Config{ServerName: c.reloader.files.serverName}).Info()
+}
+
+func (c *reloadingCreds) Clone() credentials.TransportCredentials {
+	return &reloadingCreds{reloader: c.reloader}
+}
+
+// OverrideServerName -.
+//
+// Deprecated: используйте TLS(..., serverName).
+func (c *reloadingCreds) OverrideServerName(name string) error {
+	c.reloader.mu.Lock()
+	defer c.reloader.mu.Unlock()
+	c.reloader.files.serverName = name
+	c.reloader.config = nil
+	return nil
+}
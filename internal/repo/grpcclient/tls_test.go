@@ -0,0 +1,188 @@
+package rating
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ratingv1 "github.com/RozmiDan/gamehub-protos/gen/go/gamehub"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue выпускает leaf-сертификат, подписанный CA; возвращает PEM сертификата и ключа
+func (ca *testCA) issue(t *testing.T, cn string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{cn},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+// startTLSBackend поднимает rating service, требующий клиентский сертификат от clientCA
+func startTLSBackend(t *testing.T, serverCA, clientCA *testCA) string {
+	t.Helper()
+	certPEM, keyPEM := serverCA.issue(t, "rating.internal", x509.ExtKeyUsageServerAuth)
+	serverCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(clientCA.cert)
+
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	ratingv1.RegisterRatingServiceServer(srv, &stubBackend{})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestClient_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := newTestCA(t, "server-ca")
+	clientCA := newTestCA(t, "client-ca")
+	addr := startTLSBackend(t, serverCA, clientCA)
+
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client.key")
+	writeFile(t, caFile, serverCA.pem)
+	certPEM, keyPEM := clientCA.issue(t, "main-service", x509.ExtKeyUsageClientAuth)
+	writeFile(t, certFile, certPEM)
+	writeFile(t, keyFile, keyPEM)
+
+	client, err := New(context.Background(), zap.NewNop(), []string{addr}, 2*time.Second,
+		TLS(caFile, certFile, keyFile, "rating.internal"))
+	require.NoError(t, err)
+
+	_, err = client.GetTopGames(context.Background(), 10, 0)
+	require.NoError(t, err)
+}
+
+func TestClient_TLSWithoutClientCertRejected(t *testing.T) {
+	dir := t.TempDir()
+	serverCA := newTestCA(t, "server-ca")
+	addr := startTLSBackend(t, serverCA, newTestCA(t, "client-ca"))
+
+	caFile := filepath.Join(dir, "ca.pem")
+	writeFile(t, caFile, serverCA.pem)
+
+	// сервер требует клиентский сертификат — без него соединение не поднимется
+	_, err := New(context.Background(), zap.NewNop(), []string{addr}, 300*time.Millisecond,
+		TLS(caFile, "", "", "rating.internal"), HealthCheck(false, ""))
+	require.Error(t, err)
+}
+
+func TestTLSReloader_PicksUpChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+
+	first := newTestCA(t, "first")
+	writeFile(t, caFile, first.pem)
+
+	r, err := newTLSReloader(tlsFiles{caFile: caFile}, zap.NewNop())
+	require.NoError(t, err)
+
+	cfg1, err := r.current()
+	require.NoError(t, err)
+
+	// без изменений файлов конфиг не пересобирается
+	same, err := r.current()
+	require.NoError(t, err)
+	require.Same(t, cfg1, same)
+
+	// подменяем CA и сдвигаем mtime, чтобы изменение точно было заметно
+	second := newTestCA(t, "second")
+	writeFile(t, caFile, second.pem)
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(caFile, later, later))
+
+	cfg2, err := r.current()
+	require.NoError(t, err)
+	require.NotSame(t, cfg1, cfg2)
+	require.False(t, cfg1.RootCAs.Equal(cfg2.RootCAs))
+
+	// битый файл — остаёмся на последней рабочей конфигурации
+	writeFile(t, caFile, []byte("garbage"))
+	later = later.Add(time.Minute)
+	require.NoError(t, os.Chtimes(caFile, later, later))
+
+	cfg3, err := r.current()
+	require.NoError(t, err)
+	require.Same(t, cfg2, cfg3)
+}
+
+func TestTLSReloader_RequiresCertAndKeyTogether(t *testing.T) {
+	_, err := newTLSReloader(tlsFiles{certFile: "client.pem"}, zap.NewNop())
+	require.Error(t, err)
+}
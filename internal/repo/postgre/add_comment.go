@@ -3,13 +3,17 @@ package postgres_storage
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/RozmiDan/gameReviewHub/internal/entity"
 	"github.com/jackc/pgx/v5/pgconn"
 	"go.uber.org/zap"
 )
 
-func (r *RatingRepository) AddComment(ctx context.Context, gameID, userID, text string) (string, error) {
+// AddComment сохраняет комментарий со статусом status (visible или pending — после фильтра)
+func (r *RatingRepository) AddComment(ctx context.Context, gameID, userID, text, status string) (_ string, err error) {
+	defer observe("AddComment", time.Now(), &err)
+
 	// 1) забираем request_id
 	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
 
@@ -21,13 +25,13 @@ func (r *RatingRepository) AddComment(ctx context.Context, gameID, userID, text
 
 	// 2) готовим и выполняем запрос
 	const sqlQuery = `
-        INSERT INTO comments(game_id, user_id, text)
-        VALUES($1, $2, $3)
+        INSERT INTO comments(game_id, user_id, text, status)
+        VALUES($1, $2, $3, $4)
 		RETURNING id
     `
 
 	var commentID string
-	err := r.pg.Pool.QueryRow(ctx, sqlQuery, gameID, userID, text).Scan(&commentID)
+	err = r.conn(ctx).QueryRow(ctx, sqlQuery, gameID, userID, text, status).Scan(&commentID)
 
 	if err != nil {
 		// если ключ game_id не существует → 23503 foreign_key_violation
@@ -40,7 +44,7 @@ func (r *RatingRepository) AddComment(ctx context.Context, gameID, userID, text
 		return "", entity.ErrInsertComment
 	}
 
-	logger.Info("successfuly insert comment", zap.String("commentID", commentID))
+	logger.Info("successfuly insert comment", zap.String("commentID", commentID), zap.String("status", status))
 
 	return commentID, nil
 }
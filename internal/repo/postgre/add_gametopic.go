@@ -3,6 +3,7 @@ package postgres_storage
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/RozmiDan/gameReviewHub/internal/entity"
 	"github.com/jackc/pgx/v5"
@@ -10,7 +11,9 @@ import (
 	"go.uber.org/zap"
 )
 
-func (r *RatingRepository) AddGameTopic(ctx context.Context, gameInfo *entity.Game) (string, error) {
+func (r *RatingRepository) AddGameTopic(ctx context.Context, gameInfo *entity.Game) (_ string, err error) {
+	defer observe("AddGameTopic", time.Now(), &err)
+
 	// 1) забираем request_id
 	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
 
@@ -58,7 +61,7 @@ func (r *RatingRepository) AddGameTopic(ctx context.Context, gameInfo *entity.Ga
 	}
 
 	var gameID string
-	err := r.pg.Pool.QueryRow(ctx, sqlQuery, args...).Scan(&gameID)
+	err = r.conn(ctx).QueryRow(ctx, sqlQuery, args...).Scan(&gameID)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -0,0 +1,165 @@
+package postgres_storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// Массовая загрузка (cmd/seed): батч идёт COPY во временную таблицу, оттуда — INSERT ... ON CONFLICT.
+// В режиме ConflictError COPY пишет прямо в таблицу, и первый же конфликт прерывает батч.
+
+var (
+	gameColumns    = []string{"id", "name", "genre", "creator", "description", "release_date"}
+	commentColumns = []string{"id", "game_id", "user_id", "text", "status", "created_at"}
+)
+
+// CopyGames загружает батч игр. Возвращает число записанных строк: вставленных и, при upsert, обновлённых.
+// skip пропускает игры с уже занятым id или name; upsert обновляет по id, занятое другим id name — ошибка.
+func (r *RatingRepository) CopyGames(ctx context.Context, games []entity.Game, onConflict string) (_ int64, err error) {
+	defer observe("CopyGames", time.Now(), &err)
+
+	logger := r.logger.With(zap.String("func", "CopyGames"), zap.String("on_conflict", onConflict))
+
+	rows := make([][]any, len(games))
+	for i, g := range games {
+		id, err := uuid.Parse(g.ID)
+		if err != nil {
+			return 0, fmt.Errorf("game %q: %w", g.Name, entity.ErrInvalidUUID)
+		}
+		rows[i] = []any{id, g.Name, g.Genre, g.Creator, g.Description, g.ReleaseDate}
+	}
+
+	var insert string
+	switch onConflict {
+	case entity.ConflictSkip:
+		insert = `
+			INSERT INTO games (id, name, genre, creator, description, release_date)
+			SELECT id, name, genre, creator, description, release_date FROM bulk_games
+			ON CONFLICT DO NOTHING;
+		`
+	case entity.ConflictUpsert:
+		insert = `
+			INSERT INTO games (id, name, genre, creator, description, release_date)
+			SELECT id, name, genre, creator, description, release_date FROM bulk_games
+			ON CONFLICT (id) DO UPDATE SET
+			  name         = EXCLUDED.name,
+			  genre        = EXCLUDED.genre,
+			  creator      = EXCLUDED.creator,
+			  description  = EXCLUDED.description,
+			  release_date = EXCLUDED.release_date,
+			  updated_at   = now();
+		`
+	}
+
+	n, err := r.bulkCopy(ctx, "games", gameColumns, rows, insert)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			logger.Warn("game already exists", zap.String("detail", pgErr.Detail))
+			return 0, fmt.Errorf("%w: %s", entity.ErrGameAlreadyExists, pgErr.Detail)
+		}
+		logger.Error("failed to copy games", zap.Int("batch", len(games)), zap.Error(err))
+		return 0, entity.ErrInsertGame
+	}
+
+	logger.Debug("games copied", zap.Int("batch", len(games)), zap.Int64("written", n))
+	return n, nil
+}
+
+// CopyComments загружает батч комментариев. В режимах skip и upsert комментарии к несуществующим
+// играм пропускаются; upsert по id меняет текст и автора, статус модерации не трогает.
+func (r *RatingRepository) CopyComments(ctx context.Context, comments []entity.Comment, onConflict string) (_ int64, err error) {
+	defer observe("CopyComments", time.Now(), &err)
+
+	logger := r.logger.With(zap.String("func", "CopyComments"), zap.String("on_conflict", onConflict))
+
+	rows := make([][]any, len(comments))
+	for i, c := range comments {
+		id, err1 := uuid.Parse(c.ID)
+		gameID, err2 := uuid.Parse(c.GameID)
+		userID, err3 := uuid.Parse(c.UserID)
+		if err := errors.Join(err1, err2, err3); err != nil {
+			return 0, fmt.Errorf("comment %q: %w", c.ID, entity.ErrInvalidUUID)
+		}
+		rows[i] = []any{id, gameID, userID, c.Text, c.Status, c.CreatedAt}
+	}
+
+	var insert string
+	switch onConflict {
+	case entity.ConflictSkip:
+		insert = `
+			INSERT INTO comments (id, game_id, user_id, text, status, created_at)
+			SELECT b.id, b.game_id, b.user_id, b.text, b.status, b.created_at FROM bulk_comments b
+			WHERE EXISTS (SELECT 1 FROM games g WHERE g.id = b.game_id)
+			ON CONFLICT DO NOTHING;
+		`
+	case entity.ConflictUpsert:
+		insert = `
+			INSERT INTO comments (id, game_id, user_id, text, status, created_at)
+			SELECT b.id, b.game_id, b.user_id, b.text, b.status, b.created_at FROM bulk_comments b
+			WHERE EXISTS (SELECT 1 FROM games g WHERE g.id = b.game_id)
+			ON CONFLICT (id) DO UPDATE SET
+			  game_id = EXCLUDED.game_id,
+			  user_id = EXCLUDED.user_id,
+			  text    = EXCLUDED.text;
+		`
+	}
+
+	n, err := r.bulkCopy(ctx, "comments", commentColumns, rows, insert)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23505": // unique_violation
+				logger.Warn("comment already exists", zap.String("detail", pgErr.Detail))
+				return 0, fmt.Errorf("comment already exists: %s", pgErr.Detail)
+			case "23503": // foreign_key_violation
+				logger.Warn("comment for unknown game", zap.String("detail", pgErr.Detail))
+				return 0, fmt.Errorf("%w: %s", entity.ErrGameNotFound, pgErr.Detail)
+			}
+		}
+		logger.Error("failed to copy comments", zap.Int("batch", len(comments)), zap.Error(err))
+		return 0, entity.ErrInsertComment
+	}
+
+	logger.Debug("comments copied", zap.Int("batch", len(comments)), zap.Int64("written", n))
+	return n, nil
+}
+
+// bulkCopy: insert == "" — COPY прямо в table, иначе COPY во временную bulk_<table> и insert из неё.
+// Временная таблица живёт до конца транзакции, поэтому всё — в одной транзакции.
+func (r *RatingRepository) bulkCopy(ctx context.Context, table string, columns []string, rows [][]any,
+	insert string) (int64, error) {
+
+	if insert == "" {
+		return r.conn(ctx).CopyFrom(ctx, pgx.Identifier{table}, columns, pgx.CopyFromRows(rows))
+	}
+
+	var written int64
+	err := r.WithinTx(ctx, func(ctx context.Context) error {
+		q := r.conn(ctx)
+		tmp := "bulk_" + table
+		if _, err := q.Exec(ctx, fmt.Sprintf(
+			`CREATE TEMP TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP;`, tmp, table)); err != nil {
+			return fmt.Errorf("create %s: %w", tmp, err)
+		}
+		if _, err := q.CopyFrom(ctx, pgx.Identifier{tmp}, columns, pgx.CopyFromRows(rows)); err != nil {
+			return fmt.Errorf("copy into %s: %w", tmp, err)
+		}
+		tag, err := q.Exec(ctx, insert)
+		if err != nil {
+			return err
+		}
+		written = tag.RowsAffected()
+		return nil
+	})
+	return written, err
+}
@@ -0,0 +1,116 @@
+package postgres_storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const deadLetterColumns = `id, topic, msg_key, payload, headers, error, attempts, created_at, last_attempt_at, replayed_at`
+
+// SaveDeadLetter сохраняет неотправленное сообщение
+func (r *RatingRepository) SaveDeadLetter(ctx context.Context, dl entity.DeadLetter) (err error) {
+	defer observe("SaveDeadLetter", time.Now(), &err)
+
+	const sqlQuery = `
+        INSERT INTO kafka_dead_letters (topic, msg_key, payload, headers, error)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+
+	headers := dl.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	if _, err := r.conn(ctx).Exec(ctx, sqlQuery, dl.Topic, dl.Key, dl.Payload, headers, dl.Error); err != nil {
+		r.logger.Error("failed to save dead letter", zap.Error(err), zap.String("topic", dl.Topic))
+		return entity.ErrInternal
+	}
+	return nil
+}
+
+// ListDeadLetters возвращает сообщения по возрастанию id; pendingOnly — только ещё не переотправленные
+func (r *RatingRepository) ListDeadLetters(ctx context.Context, pendingOnly bool, afterID int64, limit int) (_ []entity.DeadLetter, err error) {
+	defer observe("ListDeadLetters", time.Now(), &err)
+
+	sqlQuery := `SELECT ` + deadLetterColumns + ` FROM kafka_dead_letters WHERE id > $1`
+	if pendingOnly {
+		sqlQuery += ` AND replayed_at IS NULL`
+	}
+	sqlQuery += ` ORDER BY id LIMIT $2`
+
+	rows, err := r.conn(ctx).Query(ctx, sqlQuery, afterID, limit)
+	if err != nil {
+		r.logger.Error("failed to list dead letters", zap.Error(err))
+		return nil, entity.ErrInternal
+	}
+	defer rows.Close()
+
+	out := make([]entity.DeadLetter, 0, limit)
+	for rows.Next() {
+		dl, err := scanDeadLetter(rows)
+		if err != nil {
+			r.logger.Error("failed to scan dead letter", zap.Error(err))
+			return nil, entity.ErrInternal
+		}
+		out = append(out, dl)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("dead letters rows error", zap.Error(err))
+		return nil, entity.ErrInternal
+	}
+	return out, nil
+}
+
+// GetDeadLetter возвращает одно сообщение
+func (r *RatingRepository) GetDeadLetter(ctx context.Context, id int64) (_ *entity.DeadLetter, err error) {
+	defer observe("GetDeadLetter", time.Now(), &err)
+
+	sqlQuery := `SELECT ` + deadLetterColumns + ` FROM kafka_dead_letters WHERE id = $1`
+
+	dl, err := scanDeadLetter(r.conn(ctx).QueryRow(ctx, sqlQuery, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrDeadLetterNotFound
+		}
+		r.logger.Error("failed to get dead letter", zap.Error(err), zap.Int64("id", id))
+		return nil, entity.ErrInternal
+	}
+	return &dl, nil
+}
+
+// MarkDeadLetterAttempt фиксирует попытку переотправки: успешная проставляет replayed_at
+func (r *RatingRepository) MarkDeadLetterAttempt(ctx context.Context, id int64, replayErr error) (err error) {
+	defer observe("MarkDeadLetterAttempt", time.Now(), &err)
+
+	sqlQuery := `
+        UPDATE kafka_dead_letters
+           SET attempts = attempts + 1, last_attempt_at = now(), replayed_at = now()
+         WHERE id = $1
+    `
+	args := []any{id}
+	if replayErr != nil {
+		sqlQuery = `
+        UPDATE kafka_dead_letters
+           SET attempts = attempts + 1, last_attempt_at = now(), error = $2
+         WHERE id = $1
+    `
+		args = append(args, replayErr.Error())
+	}
+
+	if _, err := r.conn(ctx).Exec(ctx, sqlQuery, args...); err != nil {
+		r.logger.Error("failed to update dead letter", zap.Error(err), zap.Int64("id", id))
+		return entity.ErrInternal
+	}
+	return nil
+}
+
+func scanDeadLetter(row pgx.Row) (entity.DeadLetter, error) {
+	var dl entity.DeadLetter
+	err := row.Scan(&dl.ID, &dl.Topic, &dl.Key, &dl.Payload, &dl.Headers, &dl.Error, &dl.Attempts,
+		&dl.CreatedAt, &dl.LastAttemptAt, &dl.ReplayedAt)
+	return dl, err
+}
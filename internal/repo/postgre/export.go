@@ -0,0 +1,167 @@
+package postgres_storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// Выгрузки (GET /games/export, GET /games/{game_id}/comments/export) читают серверным курсором:
+// в памяти только текущий батч, сколько бы строк ни было в таблице. Вся выгрузка — одна
+// read-only транзакция REPEATABLE READ, поэтому файл — согласованный снимок, даже если
+// во время выгрузки добавляют игры.
+
+// ExportGames отдаёт игры по id батчами до batchSize. limit <= 0 — без ограничения.
+// Ошибка fn прерывает выгрузку и возвращается как есть.
+func (r *RatingRepository) ExportGames(ctx context.Context, limit, offset int32, batchSize int,
+	fn func([]entity.Game) error) (err error) {
+	defer observe("ExportGames", time.Now(), &err)
+
+	// 1) забираем request_id
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+
+	// 2) оборачиваем логгер
+	logger := r.logger.With(zap.String("func", "ExportGames"))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	// LIMIT NULL — то же, что LIMIT ALL
+	var lim any
+	if limit > 0 {
+		lim = limit
+	}
+	const declare = `
+        DECLARE export_games NO SCROLL CURSOR FOR
+        SELECT id, name, genre, creator, description, release_date
+        FROM games
+        ORDER BY id
+        LIMIT $1 OFFSET $2
+    `
+
+	var batch []entity.Game
+	return r.exportCursor(ctx, logger, "export_games", declare, []any{lim, offset}, batchSize,
+		func(rows pgx.Rows) error {
+			var g entity.Game
+			if err := rows.Scan(&g.ID, &g.Name, &g.Genre, &g.Creator, &g.Description, &g.ReleaseDate); err != nil {
+				return err
+			}
+			g.Rating.GameID = g.ID
+			batch = append(batch, g)
+			return nil
+		},
+		func() error {
+			// fn может держать батч дальше — следующий собирается в новом срезе
+			out := batch
+			batch = nil
+			return fn(out)
+		})
+}
+
+// ExportComments отдаёт видимые комментарии игры по времени создания батчами до batchSize
+func (r *RatingRepository) ExportComments(ctx context.Context, gameID string, batchSize int,
+	fn func([]entity.Comment) error) (err error) {
+	defer observe("ExportComments", time.Now(), &err)
+
+	// 1) забираем request_id
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+
+	// 2) оборачиваем логгер
+	logger := r.logger.With(zap.String("func", "ExportComments"), zap.String("game_id", gameID))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	// порядок совпадает с idx_comments_game_keyset
+	const declare = `
+        DECLARE export_comments NO SCROLL CURSOR FOR
+        SELECT id, game_id, user_id, text, created_at
+        FROM comments
+        WHERE game_id = $1 AND status = $2
+        ORDER BY created_at, id
+    `
+
+	var batch []entity.Comment
+	return r.exportCursor(ctx, logger, "export_comments", declare, []any{gameID, entity.CommentVisible}, batchSize,
+		func(rows pgx.Rows) error {
+			c := entity.Comment{Status: entity.CommentVisible}
+			if err := rows.Scan(&c.ID, &c.GameID, &c.UserID, &c.Text, &c.CreatedAt); err != nil {
+				return err
+			}
+			batch = append(batch, c)
+			return nil
+		},
+		func() error {
+			out := batch
+			batch = nil
+			return fn(out)
+		})
+}
+
+// exportCursor открывает курсор declare с именем name и читает его по batchSize строк:
+// scan — на каждую строку, flush — после каждого непустого батча.
+// Ошибки БД логируются и становятся entity.ErrInternal; отмена ctx и ошибки flush возвращаются как есть.
+func (r *RatingRepository) exportCursor(ctx context.Context, logger *zap.Logger, name, declare string, args []any,
+	batchSize int, scan func(pgx.Rows) error, flush func() error) error {
+
+	dbErr := func(msg string, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		logger.Error(msg, zap.Error(err))
+		return entity.ErrInternal
+	}
+
+	// 1) транзакция и курсор
+	tx, err := r.pg.Pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return dbErr("begin export tx failed", err)
+	}
+	// read-only: Rollback заодно закрывает курсор
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	if _, err := tx.Exec(ctx, declare, args...); err != nil {
+		return dbErr("declare cursor failed", err)
+	}
+
+	// 2) батчи, пока курсор не кончится
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM %s", batchSize, name)
+	total := 0
+	for {
+		rows, err := tx.Query(ctx, fetch)
+		if err != nil {
+			return dbErr("fetch failed", err)
+		}
+		n := 0
+		for rows.Next() {
+			if err := scan(rows); err != nil {
+				rows.Close()
+				return dbErr("scan failed", err)
+			}
+			n++
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return dbErr("rows iteration error", err)
+		}
+
+		if n > 0 {
+			if err := flush(); err != nil {
+				if !errors.Is(err, context.Canceled) {
+					logger.Warn("export aborted", zap.Int("exported", total), zap.Error(err))
+				}
+				return err
+			}
+			total += n
+		}
+		if n < batchSize {
+			logger.Info("export finished", zap.Int("exported", total))
+			return nil
+		}
+	}
+}
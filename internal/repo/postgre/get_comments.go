@@ -3,13 +3,19 @@ package postgres_storage
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/RozmiDan/gameReviewHub/internal/entity"
 	"github.com/jackc/pgx/v5/pgconn"
 	"go.uber.org/zap"
 )
 
-func (r *RatingRepository) GetCommentsGame(ctx context.Context, gameID string, limit, offset int32) ([]entity.Comment, error) {
+// GetCommentsGame возвращает комментарии игры, новые первыми.
+// allStatuses — для модераторов: вместе со скрытыми и ожидающими проверки, с полями модерации.
+func (r *RatingRepository) GetCommentsGame(ctx context.Context, gameID string, limit, offset int32,
+	allStatuses bool) (_ []entity.Comment, err error) {
+	defer observe("GetCommentsGame", time.Now(), &err)
+
 	// 1) забираем request_id
 	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
 
@@ -21,14 +27,14 @@ func (r *RatingRepository) GetCommentsGame(ctx context.Context, gameID string, l
 
 	// 2) готовим и выполняем запрос
 	const sqlQuery = `
-        SELECT id, user_id, text, created_at
+        SELECT id, user_id, text, created_at, status, moderated_by, moderated_at, moderation_reason
         FROM comments
-        WHERE game_id = $1
+        WHERE game_id = $1 AND ($4 OR status = 'visible')
 		ORDER BY created_at DESC
       	LIMIT $2 OFFSET $3
     `
 
-	rows, err := r.pg.Pool.Query(ctx, sqlQuery, gameID, limit, offset*limit)
+	rows, err := r.pg.Pool.Query(ctx, sqlQuery, gameID, limit, offset*limit, allStatuses)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -44,11 +50,23 @@ func (r *RatingRepository) GetCommentsGame(ctx context.Context, gameID string, l
 	// 3) сканируем результат
 	var comments []entity.Comment
 	for rows.Next() {
-		var comment entity.Comment
-		if err := rows.Scan(&comment.ID, &comment.UserID, &comment.Text, &comment.CreatedAt); err != nil {
+		var (
+			comment     entity.Comment
+			moderatedBy *string
+			reason      *string
+		)
+		err := rows.Scan(&comment.ID, &comment.UserID, &comment.Text, &comment.CreatedAt, &comment.Status,
+			&moderatedBy, &comment.ModeratedAt, &reason)
+		if err != nil {
 			logger.Error("scan failed", zap.Error(err))
 			return nil, entity.ErrInternalComments
 		}
+		if allStatuses {
+			comment.ModeratedBy = deref(moderatedBy)
+			comment.ModerationReason = deref(reason)
+		} else {
+			comment.ModeratedAt = nil
+		}
 		comments = append(comments, comment)
 	}
 
@@ -0,0 +1,70 @@
+package postgres_storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// GetCommentsAfter возвращает комментарии игры, добавленные после afterID, по возрастанию (created_at, id).
+// Курсор — id комментария: его и отдаёт SSE-лента как Last-Event-ID. Только видимые комментарии.
+func (r *RatingRepository) GetCommentsAfter(ctx context.Context, gameID, afterID string, limit int32) (_ []entity.Comment, err error) {
+	defer observe("GetCommentsAfter", time.Now(), &err)
+
+	// 1) забираем request_id
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+
+	// 2) оборачиваем логгер
+	logger := r.logger.With(zap.String("func", "GetCommentsAfter"))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	// 3) находим позицию курсора
+	const cursorQuery = `SELECT created_at FROM comments WHERE id = $1 AND game_id = $2`
+
+	var cursorAt time.Time
+	if err := r.conn(ctx).QueryRow(ctx, cursorQuery, afterID, gameID).Scan(&cursorAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrCommentNotFound
+		}
+		logger.Error("failed to resolve comment cursor", zap.Error(err), zap.String("after_id", afterID))
+		return nil, entity.ErrInternalComments
+	}
+
+	// 4) keyset по индексу idx_comments_game_keyset
+	const sqlQuery = `
+        SELECT id, user_id, text, created_at
+        FROM comments
+        WHERE game_id = $1 AND status = 'visible' AND (created_at, id) > ($2, $3::uuid)
+        ORDER BY created_at, id
+        LIMIT $4
+    `
+
+	rows, err := r.conn(ctx).Query(ctx, sqlQuery, gameID, cursorAt, afterID, limit)
+	if err != nil {
+		logger.Error("query failed", zap.Error(err))
+		return nil, entity.ErrInternalComments
+	}
+	defer rows.Close()
+
+	comments := make([]entity.Comment, 0, limit)
+	for rows.Next() {
+		comment := entity.Comment{GameID: gameID}
+		if err := rows.Scan(&comment.ID, &comment.UserID, &comment.Text, &comment.CreatedAt); err != nil {
+			logger.Error("scan failed", zap.Error(err))
+			return nil, entity.ErrInternalComments
+		}
+		comments = append(comments, comment)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("rows iteration error", zap.Error(err))
+		return nil, entity.ErrInternalComments
+	}
+
+	return comments, nil
+}
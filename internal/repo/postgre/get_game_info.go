@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/RozmiDan/gameReviewHub/internal/entity"
 	"go.uber.org/zap"
 )
 
-func (r *RatingRepository) GetGameInfo(ctx context.Context, ids []string) ([]entity.GameInList, error) {
+func (r *RatingRepository) GetGameInfo(ctx context.Context, ids []string) (_ []entity.GameInList, err error) {
+	defer observe("GetGameInfo", time.Now(), &err)
+
 	// 1) забираем request_id
 	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
 
@@ -0,0 +1,57 @@
+package postgres_storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"go.uber.org/zap"
+)
+
+// GetRatingSnapshots — последние известные рейтинги игр из game_rating_snapshot (их пишет consumer rating-updates).
+// Игр без оценок в ответе нет.
+func (r *RatingRepository) GetRatingSnapshots(ctx context.Context, ids []string) (_ []entity.GameRating, err error) {
+	defer observe("GetRatingSnapshots", time.Now(), &err)
+
+	// 1) забираем request_id
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+
+	// 2) оборачиваем логгер
+	logger := r.logger.With(zap.String("func", "GetRatingSnapshots"))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	if len(ids) == 0 {
+		return []entity.GameRating{}, nil
+	}
+
+	const sqlQuery = `
+        SELECT game_id, average_rating, ratings_count
+        FROM game_rating_snapshot
+        WHERE game_id = ANY($1)
+    `
+
+	rows, err := r.conn(ctx).Query(ctx, sqlQuery, ids)
+	if err != nil {
+		logger.Error("query failed", zap.Error(err))
+		return nil, entity.ErrInternal
+	}
+	defer rows.Close()
+
+	out := make([]entity.GameRating, 0, len(ids))
+	for rows.Next() {
+		var gr entity.GameRating
+		if err := rows.Scan(&gr.GameID, &gr.AverageRating, &gr.RatingsCount); err != nil {
+			logger.Error("scan failed", zap.Error(err))
+			return nil, entity.ErrInternal
+		}
+		out = append(out, gr)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Error("rows iteration error", zap.Error(err))
+		return nil, entity.ErrInternal
+	}
+
+	return out, nil
+}
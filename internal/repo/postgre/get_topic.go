@@ -3,13 +3,16 @@ package postgres_storage
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/RozmiDan/gameReviewHub/internal/entity"
 	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
-func (r *RatingRepository) GetGameTopic(ctx context.Context, gameID string) (*entity.Game, error) {
+func (r *RatingRepository) GetGameTopic(ctx context.Context, gameID string) (_ *entity.Game, err error) {
+	defer observe("GetGameTopic", time.Now(), &err)
+
 	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
 	logger := r.logger.With(zap.String("func", "GetGameTopic"))
 	if reqID != "" {
@@ -0,0 +1,44 @@
+package postgres_storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	prom_metrics "github.com/RozmiDan/gameReviewHub/pkg/metrics"
+	"github.com/jackc/pgx/v5"
+)
+
+// ожидаемые исходы операций — ответ БД, а не её сбой; в DBErrors не попадают
+var expectedErrs = []error{
+	pgx.ErrNoRows,
+	entity.ErrGameNotFound,
+	entity.ErrGameAlreadyExists,
+	entity.ErrCommentNotFound,
+	entity.ErrDuplicateReport,
+	entity.ErrInvalidModeration,
+	entity.ErrWebhookNotFound,
+	entity.ErrDeadLetterNotFound,
+	// клиент ушёл, не дождавшись ответа
+	context.Canceled,
+}
+
+// observe пишет длительность операции репозитория и считает её сбои.
+// Вызывать первой строкой метода с именованной ошибкой: defer observe("GetGameTopic", time.Now(), &err)
+func observe(operation string, start time.Time, err *error) {
+	if prom_metrics.DBDuration == nil {
+		return
+	}
+	prom_metrics.DBDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+
+	if *err == nil {
+		return
+	}
+	for _, expected := range expectedErrs {
+		if errors.Is(*err, expected) {
+			return
+		}
+	}
+	prom_metrics.DBErrors.WithLabelValues(operation).Inc()
+}
@@ -0,0 +1,166 @@
+package postgres_storage
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+const moderatedCommentColumns = `c.id, c.game_id, c.user_id, c.text, c.created_at, c.status,
+	c.moderated_by, c.moderated_at, c.moderation_reason`
+
+// ListCommentsByStatus — очередь модерации: комментарии со статусом status, старые первыми
+func (r *RatingRepository) ListCommentsByStatus(ctx context.Context, status string, limit, offset int32) (_ []entity.Comment, err error) {
+	defer observe("ListCommentsByStatus", time.Now(), &err)
+
+	sqlQuery := `SELECT ` + moderatedCommentColumns + `
+        FROM comments c
+        WHERE c.status = $1
+        ORDER BY c.created_at, c.id
+        LIMIT $2 OFFSET $3`
+
+	rows, err := r.conn(ctx).Query(ctx, sqlQuery, status, limit, offset*limit)
+	if err != nil {
+		r.logger.Error("failed to list comments by status", zap.Error(err), zap.String("status", status))
+		return nil, entity.ErrInternalComments
+	}
+	defer rows.Close()
+
+	out := make([]entity.Comment, 0, limit)
+	for rows.Next() {
+		c, err := scanModeratedComment(rows)
+		if err != nil {
+			r.logger.Error("failed to scan comment", zap.Error(err))
+			return nil, entity.ErrInternalComments
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("comments rows error", zap.Error(err))
+		return nil, entity.ErrInternalComments
+	}
+	return out, nil
+}
+
+// SetCommentStatus переводит комментарий в статус to, если текущий статус входит в from.
+// Возвращает обновлённый комментарий и прежний статус.
+// Строка блокируется до конца транзакции, чтобы два модератора не переписали друг друга.
+func (r *RatingRepository) SetCommentStatus(ctx context.Context, commentID string, from []string, to,
+	moderator, reason string) (_ *entity.Comment, _ string, err error) {
+	defer observe("SetCommentStatus", time.Now(), &err)
+
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+	logger := r.logger.With(zap.String("func", "SetCommentStatus"), zap.String("comment_id", commentID))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	// 1) текущий статус под блокировкой
+	var prev string
+	err = r.conn(ctx).QueryRow(ctx, `SELECT status FROM comments WHERE id = $1 FOR UPDATE`, commentID).Scan(&prev)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", entity.ErrCommentNotFound
+		}
+		logger.Error("failed to lock comment", zap.Error(err))
+		return nil, "", entity.ErrInternal
+	}
+	if !slices.Contains(from, prev) {
+		return nil, prev, entity.ErrInvalidModeration
+	}
+
+	// 2) смена статуса
+	sqlQuery := `
+        UPDATE comments c
+        SET status = $2, moderated_by = $3, moderated_at = now(), moderation_reason = NULLIF($4, '')
+        WHERE c.id = $1
+        RETURNING ` + moderatedCommentColumns
+
+	c, err := scanModeratedComment(r.conn(ctx).QueryRow(ctx, sqlQuery, commentID, to, moderator, reason))
+	if err != nil {
+		logger.Error("failed to update comment status", zap.Error(err))
+		return nil, "", entity.ErrInternal
+	}
+
+	logger.Info("comment status changed", zap.String("from", prev), zap.String("to", to),
+		zap.String("moderator", moderator))
+	return &c, prev, nil
+}
+
+// AddModerationAudit пишет запись в журнал модерации
+func (r *RatingRepository) AddModerationAudit(ctx context.Context, a entity.ModerationAudit) (err error) {
+	defer observe("AddModerationAudit", time.Now(), &err)
+
+	const sqlQuery = `
+        INSERT INTO comment_moderation_audit (comment_id, game_id, action, from_status, to_status, moderator, reason)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `
+
+	_, err = r.conn(ctx).Exec(ctx, sqlQuery, a.CommentID, a.GameID, a.Action, a.FromStatus, a.ToStatus,
+		a.Moderator, a.Reason)
+	if err != nil {
+		r.logger.Error("failed to write moderation audit", zap.Error(err), zap.String("comment_id", a.CommentID))
+		return entity.ErrInternal
+	}
+	return nil
+}
+
+// ListModerationAudit — журнал по комментарию в порядке записи
+func (r *RatingRepository) ListModerationAudit(ctx context.Context, commentID string) (_ []entity.ModerationAudit, err error) {
+	defer observe("ListModerationAudit", time.Now(), &err)
+
+	const sqlQuery = `
+        SELECT id, comment_id, game_id, action, from_status, to_status, moderator, reason, created_at
+        FROM comment_moderation_audit
+        WHERE comment_id = $1
+        ORDER BY id
+    `
+
+	rows, err := r.conn(ctx).Query(ctx, sqlQuery, commentID)
+	if err != nil {
+		r.logger.Error("failed to list moderation audit", zap.Error(err), zap.String("comment_id", commentID))
+		return nil, entity.ErrInternal
+	}
+	defer rows.Close()
+
+	out := []entity.ModerationAudit{}
+	for rows.Next() {
+		var a entity.ModerationAudit
+		if err := rows.Scan(&a.ID, &a.CommentID, &a.GameID, &a.Action, &a.FromStatus, &a.ToStatus,
+			&a.Moderator, &a.Reason, &a.CreatedAt); err != nil {
+			r.logger.Error("failed to scan moderation audit", zap.Error(err))
+			return nil, entity.ErrInternal
+		}
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("moderation audit rows error", zap.Error(err))
+		return nil, entity.ErrInternal
+	}
+	return out, nil
+}
+
+func scanModeratedComment(row pgx.Row) (entity.Comment, error) {
+	var (
+		c           entity.Comment
+		moderatedBy *string
+		reason      *string
+	)
+	err := row.Scan(&c.ID, &c.GameID, &c.UserID, &c.Text, &c.CreatedAt, &c.Status,
+		&moderatedBy, &c.ModeratedAt, &reason)
+	c.ModeratedBy = deref(moderatedBy)
+	c.ModerationReason = deref(reason)
+	return c, err
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
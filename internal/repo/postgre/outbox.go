@@ -0,0 +1,91 @@
+package postgres_storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"go.uber.org/zap"
+)
+
+// PublishEvent кладёт событие в outbox. Вызывается внутри WithinTx вместе с основной записью,
+// поэтому событие появляется только если сама запись закоммичена. В Kafka его отправляет relay.
+func (r *RatingRepository) PublishEvent(ctx context.Context, evt entity.DomainEvent) (err error) {
+	defer observe("PublishEvent", time.Now(), &err)
+
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+	logger := r.logger.With(zap.String("func", "PublishEvent"))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	const sqlQuery = `
+        INSERT INTO event_outbox (id, event_type, aggregate, aggregate_id, game_id, payload, request_id,
+                                  trace_parent, occurred_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    `
+
+	_, err = r.conn(ctx).Exec(ctx, sqlQuery, evt.ID, evt.Type, evt.Aggregate, evt.AggregateID,
+		evt.GameID, []byte(evt.Data), reqID, evt.TraceParent, evt.OccurredAt)
+	if err != nil {
+		logger.Error("failed to insert event into outbox", zap.Error(err), zap.String("type", evt.Type))
+		return entity.ErrInternal
+	}
+
+	logger.Debug("event stored in outbox", zap.String("event_id", evt.ID), zap.String("type", evt.Type))
+	return nil
+}
+
+// FetchPendingEvents блокирует до limit неотправленных событий (SKIP LOCKED — несколько
+// реплик не выберут одно и то же). Вызывать внутри WithinTx.
+func (r *RatingRepository) FetchPendingEvents(ctx context.Context, limit int) (_ []entity.DomainEvent, err error) {
+	defer observe("FetchPendingEvents", time.Now(), &err)
+
+	const sqlQuery = `
+        SELECT id, event_type, aggregate, aggregate_id, game_id, payload, request_id, trace_parent, occurred_at
+          FROM event_outbox
+         WHERE published_at IS NULL
+         ORDER BY occurred_at
+         LIMIT $1
+           FOR UPDATE SKIP LOCKED
+    `
+
+	rows, err := r.conn(ctx).Query(ctx, sqlQuery, limit)
+	if err != nil {
+		r.logger.Error("failed to fetch outbox events", zap.Error(err))
+		return nil, entity.ErrInternal
+	}
+	defer rows.Close()
+
+	events := make([]entity.DomainEvent, 0, limit)
+	for rows.Next() {
+		var evt entity.DomainEvent
+		if err := rows.Scan(&evt.ID, &evt.Type, &evt.Aggregate, &evt.AggregateID, &evt.GameID,
+			&evt.Data, &evt.RequestID, &evt.TraceParent, &evt.OccurredAt); err != nil {
+			r.logger.Error("failed to scan outbox event", zap.Error(err))
+			return nil, entity.ErrInternal
+		}
+		events = append(events, evt)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("outbox rows error", zap.Error(err))
+		return nil, entity.ErrInternal
+	}
+	return events, nil
+}
+
+// MarkEventsPublished отмечает события отправленными
+func (r *RatingRepository) MarkEventsPublished(ctx context.Context, ids []string) (err error) {
+	defer observe("MarkEventsPublished", time.Now(), &err)
+
+	if len(ids) == 0 {
+		return nil
+	}
+	const sqlQuery = `UPDATE event_outbox SET published_at = now() WHERE id = ANY($1)`
+
+	if _, err := r.conn(ctx).Exec(ctx, sqlQuery, ids); err != nil {
+		r.logger.Error("failed to mark outbox events published", zap.Error(err))
+		return entity.ErrInternal
+	}
+	return nil
+}
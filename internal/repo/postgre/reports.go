@@ -0,0 +1,132 @@
+package postgres_storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// AddCommentReport сохраняет жалобу и возвращает, сколько всего жалоб на комментарий.
+// Строка комментария блокируется до конца транзакции: параллельные жалобы считаются по очереди,
+// и порог срабатывает ровно один раз.
+func (r *RatingRepository) AddCommentReport(ctx context.Context, rep entity.CommentReport) (_ int, err error) {
+	defer observe("AddCommentReport", time.Now(), &err)
+
+	// 1) забираем request_id
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+
+	// 2) оборачиваем логгер
+	logger := r.logger.With(zap.String("func", "AddCommentReport"), zap.String("comment_id", rep.CommentID))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	// 3) комментарий должен принадлежать игре из URL
+	var one int
+	err = r.conn(ctx).QueryRow(ctx,
+		`SELECT 1 FROM comments WHERE id = $1 AND game_id = $2 FOR UPDATE`, rep.CommentID, rep.GameID).Scan(&one)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, entity.ErrCommentNotFound
+		}
+		logger.Error("failed to lock comment", zap.Error(err))
+		return 0, entity.ErrInternal
+	}
+
+	// 4) жалоба; повтор от того же пользователя не вставляется
+	const insertQuery = `
+        INSERT INTO comment_reports (comment_id, user_id, reason, details)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (comment_id, user_id) DO NOTHING
+    `
+	tag, err := r.conn(ctx).Exec(ctx, insertQuery, rep.CommentID, rep.UserID, rep.Reason, rep.Details)
+	if err != nil {
+		logger.Error("failed to insert report", zap.Error(err))
+		return 0, entity.ErrInternal
+	}
+	if tag.RowsAffected() == 0 {
+		return 0, entity.ErrDuplicateReport
+	}
+
+	// 5) сколько жалоб теперь
+	var count int
+	err = r.conn(ctx).QueryRow(ctx,
+		`SELECT count(*) FROM comment_reports WHERE comment_id = $1`, rep.CommentID).Scan(&count)
+	if err != nil {
+		logger.Error("failed to count reports", zap.Error(err))
+		return 0, entity.ErrInternal
+	}
+
+	logger.Info("comment reported", zap.String("reason", rep.Reason), zap.Int("reports", count))
+	return count, nil
+}
+
+// ListReportedComments — комментарии с жалобами, больше жалоб — выше; status сужает выборку
+func (r *RatingRepository) ListReportedComments(ctx context.Context, status string, limit, offset int32) (_ []entity.ReportedComment, err error) {
+	defer observe("ListReportedComments", time.Now(), &err)
+
+	sqlQuery := `
+        SELECT ` + moderatedCommentColumns + `,
+               r.cnt, r.last_at, r.spam, r.harassment, r.spoiler, r.other
+        FROM (
+            SELECT comment_id,
+                   count(*)                                        AS cnt,
+                   max(created_at)                                 AS last_at,
+                   count(*) FILTER (WHERE reason = 'spam')         AS spam,
+                   count(*) FILTER (WHERE reason = 'harassment')   AS harassment,
+                   count(*) FILTER (WHERE reason = 'spoiler')      AS spoiler,
+                   count(*) FILTER (WHERE reason = 'other')        AS other
+            FROM comment_reports
+            GROUP BY comment_id
+        ) r
+        JOIN comments c ON c.id = r.comment_id
+        WHERE ($1 = '' OR c.status = $1)
+        ORDER BY r.cnt DESC, r.last_at DESC, c.id
+        LIMIT $2 OFFSET $3`
+
+	rows, err := r.conn(ctx).Query(ctx, sqlQuery, status, limit, offset*limit)
+	if err != nil {
+		r.logger.Error("failed to list reported comments", zap.Error(err))
+		return nil, entity.ErrInternalComments
+	}
+	defer rows.Close()
+
+	out := make([]entity.ReportedComment, 0, limit)
+	for rows.Next() {
+		var (
+			rc                               entity.ReportedComment
+			moderatedBy, reason              *string
+			spam, harassment, spoiler, other int
+		)
+		err := rows.Scan(&rc.ID, &rc.GameID, &rc.UserID, &rc.Text, &rc.CreatedAt, &rc.Status,
+			&moderatedBy, &rc.ModeratedAt, &reason,
+			&rc.ReportsCount, &rc.LastReportedAt, &spam, &harassment, &spoiler, &other)
+		if err != nil {
+			r.logger.Error("failed to scan reported comment", zap.Error(err))
+			return nil, entity.ErrInternalComments
+		}
+		rc.ModeratedBy = deref(moderatedBy)
+		rc.ModerationReason = deref(reason)
+		rc.Reasons = map[string]int{}
+		for k, v := range map[string]int{
+			entity.ReportSpam:       spam,
+			entity.ReportHarassment: harassment,
+			entity.ReportSpoiler:    spoiler,
+			entity.ReportOther:      other,
+		} {
+			if v > 0 {
+				rc.Reasons[k] = v
+			}
+		}
+		out = append(out, rc)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("reported comments rows error", zap.Error(err))
+		return nil, entity.ErrInternalComments
+	}
+	return out, nil
+}
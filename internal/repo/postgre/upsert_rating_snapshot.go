@@ -0,0 +1,52 @@
+package postgres_storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// UpsertRatingSnapshot сохраняет агрегат рейтинга игры.
+// Возвращает false, если в таблице уже лежит такое же или более свежее значение —
+// так повторная доставка события ничего не меняет.
+func (r *RatingRepository) UpsertRatingSnapshot(ctx context.Context, upd entity.RatingUpdate) (_ bool, err error) {
+	defer observe("UpsertRatingSnapshot", time.Now(), &err)
+
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+	logger := r.logger.With(zap.String("func", "UpsertRatingSnapshot"))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	const sqlQuery = `
+        INSERT INTO game_rating_snapshot (game_id, average_rating, ratings_count, updated_at)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (game_id) DO UPDATE
+          SET average_rating = EXCLUDED.average_rating,
+              ratings_count  = EXCLUDED.ratings_count,
+              updated_at     = EXCLUDED.updated_at
+          WHERE game_rating_snapshot.updated_at < EXCLUDED.updated_at
+    `
+
+	tag, err := r.pg.Pool.Exec(ctx, sqlQuery, upd.GameID, upd.AverageRating, upd.RatingsCount, upd.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			logger.Info("game_id not found", zap.String("game_id", upd.GameID))
+			return false, entity.ErrGameNotFound
+		}
+		logger.Error("failed to upsert rating snapshot", zap.Error(err))
+		return false, entity.ErrInternal
+	}
+
+	applied := tag.RowsAffected() > 0
+	logger.Debug("rating snapshot upserted",
+		zap.String("game_id", upd.GameID),
+		zap.Bool("applied", applied),
+	)
+	return applied, nil
+}
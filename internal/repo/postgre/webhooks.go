@@ -0,0 +1,311 @@
+package postgres_storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+const webhookColumns = `id, owner, url, secret, event_types, game_id, active, consecutive_failures, disabled_at, created_at`
+
+func (r *RatingRepository) CreateWebhook(ctx context.Context, sub *entity.WebhookSubscription) (_ string, err error) {
+	defer observe("CreateWebhook", time.Now(), &err)
+
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+	logger := r.logger.With(zap.String("func", "CreateWebhook"))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	const sqlQuery = `
+        INSERT INTO webhook_subscriptions (owner, url, secret, event_types, game_id)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id
+    `
+
+	var id string
+	err = r.conn(ctx).QueryRow(ctx, sqlQuery, sub.Owner, sub.URL, sub.Secret, sub.EventTypes,
+		nullableUUID(sub.GameID)).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			logger.Info("game_id not found", zap.String("game_id", sub.GameID))
+			return "", entity.ErrGameNotFound
+		}
+		logger.Error("failed to insert webhook", zap.Error(err))
+		return "", entity.ErrInternal
+	}
+
+	logger.Info("webhook created", zap.String("webhook_id", id))
+	return id, nil
+}
+
+// Операции с подписками ниже ограничены владельцем: чужая подписка для партнёра — ErrWebhookNotFound
+
+func (r *RatingRepository) ListWebhooks(ctx context.Context, owner string) (_ []entity.WebhookSubscription, err error) {
+	defer observe("ListWebhooks", time.Now(), &err)
+
+	rows, err := r.conn(ctx).Query(ctx,
+		`SELECT `+webhookColumns+` FROM webhook_subscriptions WHERE owner = $1 ORDER BY created_at`, owner)
+	if err != nil {
+		r.logger.Error("failed to list webhooks", zap.Error(err))
+		return nil, entity.ErrInternal
+	}
+	defer rows.Close()
+
+	subs := []entity.WebhookSubscription{}
+	for rows.Next() {
+		sub, err := scanWebhook(rows)
+		if err != nil {
+			r.logger.Error("failed to scan webhook", zap.Error(err))
+			return nil, entity.ErrInternal
+		}
+		subs = append(subs, sub)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("webhooks rows error", zap.Error(err))
+		return nil, entity.ErrInternal
+	}
+	return subs, nil
+}
+
+func (r *RatingRepository) GetWebhook(ctx context.Context, id, owner string) (_ *entity.WebhookSubscription, err error) {
+	defer observe("GetWebhook", time.Now(), &err)
+
+	sub, err := scanWebhook(r.conn(ctx).QueryRow(ctx,
+		`SELECT `+webhookColumns+` FROM webhook_subscriptions WHERE id = $1 AND owner = $2`, id, owner))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrWebhookNotFound
+		}
+		r.logger.Error("failed to get webhook", zap.Error(err), zap.String("webhook_id", id))
+		return nil, entity.ErrInternal
+	}
+	return &sub, nil
+}
+
+func (r *RatingRepository) DeleteWebhook(ctx context.Context, id, owner string) (err error) {
+	defer observe("DeleteWebhook", time.Now(), &err)
+
+	tag, err := r.conn(ctx).Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1 AND owner = $2`, id, owner)
+	if err != nil {
+		r.logger.Error("failed to delete webhook", zap.Error(err), zap.String("webhook_id", id))
+		return entity.ErrInternal
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// EnableWebhook снова включает подписку после автоотключения и сбрасывает счётчик ошибок
+func (r *RatingRepository) EnableWebhook(ctx context.Context, id, owner string) (err error) {
+	defer observe("EnableWebhook", time.Now(), &err)
+
+	const sqlQuery = `
+        UPDATE webhook_subscriptions
+           SET active = true, consecutive_failures = 0, disabled_at = NULL
+         WHERE id = $1 AND owner = $2
+    `
+	tag, err := r.conn(ctx).Exec(ctx, sqlQuery, id, owner)
+	if err != nil {
+		r.logger.Error("failed to enable webhook", zap.Error(err), zap.String("webhook_id", id))
+		return entity.ErrInternal
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// ListWebhookAttempts — журнал доставок подписки, новые сверху; offset — номер страницы
+func (r *RatingRepository) ListWebhookAttempts(ctx context.Context, subID string, limit, offset int32) (_ []entity.WebhookAttempt, err error) {
+	defer observe("ListWebhookAttempts", time.Now(), &err)
+
+	const sqlQuery = `
+        SELECT a.delivery_id, d.event_id, d.event_type, a.attempt, a.status_code, a.error,
+               a.duration_ms, d.status, a.created_at
+          FROM webhook_delivery_attempts a
+          JOIN webhook_deliveries d ON d.id = a.delivery_id
+         WHERE d.subscription_id = $1
+         ORDER BY a.id DESC
+         LIMIT $2 OFFSET $3
+    `
+
+	rows, err := r.conn(ctx).Query(ctx, sqlQuery, subID, limit, offset*limit)
+	if err != nil {
+		r.logger.Error("failed to list webhook attempts", zap.Error(err))
+		return nil, entity.ErrInternal
+	}
+	defer rows.Close()
+
+	attempts := []entity.WebhookAttempt{}
+	for rows.Next() {
+		var a entity.WebhookAttempt
+		if err := rows.Scan(&a.DeliveryID, &a.EventID, &a.EventType, &a.Attempt, &a.StatusCode, &a.Error,
+			&a.DurationMs, &a.Status, &a.CreatedAt); err != nil {
+			r.logger.Error("failed to scan webhook attempt", zap.Error(err))
+			return nil, entity.ErrInternal
+		}
+		attempts = append(attempts, a)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("webhook attempts rows error", zap.Error(err))
+		return nil, entity.ErrInternal
+	}
+	return attempts, nil
+}
+
+// EnqueueWebhookDeliveries создаёт доставки события для всех подходящих активных подписок.
+// Вызывается в транзакции с основной записью, как и outbox.
+func (r *RatingRepository) EnqueueWebhookDeliveries(ctx context.Context, evt entity.DomainEvent) (err error) {
+	defer observe("EnqueueWebhookDeliveries", time.Now(), &err)
+
+	const sqlQuery = `
+        INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+        SELECT id, $1, $2, $3
+          FROM webhook_subscriptions
+         WHERE active
+           AND $2 = ANY(event_types)
+           AND (game_id IS NULL OR game_id = $4)
+        ON CONFLICT (subscription_id, event_id) DO NOTHING
+    `
+
+	// тело вебхука — тот же конверт, что уходит в Kafka
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	if _, err := r.conn(ctx).Exec(ctx, sqlQuery, evt.ID, evt.Type, payload, evt.GameID); err != nil {
+		r.logger.Error("failed to enqueue webhook deliveries", zap.Error(err), zap.String("type", evt.Type))
+		return entity.ErrInternal
+	}
+	return nil
+}
+
+// ClaimWebhookDeliveries берёт до limit доставок, которым пора уходить, и откладывает их на lease —
+// другой воркер не возьмёт их, пока эта попытка не завершится (или не истечёт lease).
+func (r *RatingRepository) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) (_ []entity.WebhookDelivery, err error) {
+	defer observe("ClaimWebhookDeliveries", time.Now(), &err)
+
+	const sqlQuery = `
+        WITH due AS (
+            SELECT d.id
+              FROM webhook_deliveries d
+              JOIN webhook_subscriptions s ON s.id = d.subscription_id
+             WHERE d.status = 'pending' AND d.next_attempt_at <= now() AND s.active
+             ORDER BY d.next_attempt_at
+             LIMIT $1
+               FOR UPDATE OF d SKIP LOCKED
+        )
+        UPDATE webhook_deliveries d
+           SET next_attempt_at = now() + make_interval(secs => $2)
+          FROM webhook_subscriptions s
+         WHERE d.id IN (SELECT id FROM due) AND s.id = d.subscription_id
+        RETURNING d.id, d.subscription_id, s.url, s.secret, d.event_id, d.event_type, d.payload, d.attempts
+    `
+
+	rows, err := r.conn(ctx).Query(ctx, sqlQuery, limit, lease.Seconds())
+	if err != nil {
+		r.logger.Error("failed to claim webhook deliveries", zap.Error(err))
+		return nil, entity.ErrInternal
+	}
+	defer rows.Close()
+
+	var out []entity.WebhookDelivery
+	for rows.Next() {
+		var d entity.WebhookDelivery
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.URL, &d.Secret, &d.EventID, &d.EventType,
+			&d.Payload, &d.Attempts); err != nil {
+			r.logger.Error("failed to scan webhook delivery", zap.Error(err))
+			return nil, entity.ErrInternal
+		}
+		out = append(out, d)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("webhook deliveries rows error", zap.Error(err))
+		return nil, entity.ErrInternal
+	}
+	return out, nil
+}
+
+// RecordWebhookAttempt пишет попытку в журнал и обновляет доставку и подписку.
+// После disableAfter неудачных попыток подряд подписка отключается; возвращает true, если это произошло сейчас.
+func (r *RatingRepository) RecordWebhookAttempt(ctx context.Context, res entity.WebhookAttemptResult, disableAfter int) (_ bool, err error) {
+	defer observe("RecordWebhookAttempt", time.Now(), &err)
+
+	var disabled bool
+	err = r.WithinTx(ctx, func(ctx context.Context) error {
+		d := res.Delivery
+		attempt := d.Attempts + 1
+
+		_, err := r.conn(ctx).Exec(ctx, `
+            INSERT INTO webhook_delivery_attempts (delivery_id, attempt, status_code, error, duration_ms)
+            VALUES ($1, $2, $3, $4, $5)`,
+			d.ID, attempt, res.StatusCode, res.Error, res.Duration.Milliseconds())
+		if err != nil {
+			return err
+		}
+
+		if res.Success {
+			if _, err := r.conn(ctx).Exec(ctx, `
+                UPDATE webhook_deliveries
+                   SET status = 'delivered', attempts = $2, last_error = '', delivered_at = now()
+                 WHERE id = $1`, d.ID, attempt); err != nil {
+				return err
+			}
+			_, err := r.conn(ctx).Exec(ctx,
+				`UPDATE webhook_subscriptions SET consecutive_failures = 0 WHERE id = $1`, d.SubscriptionID)
+			return err
+		}
+
+		status := entity.WebhookPending
+		if res.GiveUp {
+			status = entity.WebhookFailed
+		}
+		if _, err := r.conn(ctx).Exec(ctx, `
+            UPDATE webhook_deliveries
+               SET status = $2, attempts = $3, last_error = $4, next_attempt_at = $5
+             WHERE id = $1`, d.ID, status, attempt, res.Error, res.NextAttemptAt); err != nil {
+			return err
+		}
+
+		return r.conn(ctx).QueryRow(ctx, `
+            UPDATE webhook_subscriptions
+               SET consecutive_failures = consecutive_failures + 1,
+                   active      = active AND consecutive_failures + 1 < $2,
+                   disabled_at = CASE WHEN active AND consecutive_failures + 1 >= $2 THEN now() ELSE disabled_at END
+             WHERE id = $1
+            RETURNING NOT active AND consecutive_failures = $2`,
+			d.SubscriptionID, disableAfter).Scan(&disabled)
+	})
+	if err != nil {
+		r.logger.Error("failed to record webhook attempt", zap.Error(err), zap.Int64("delivery_id", res.Delivery.ID))
+		return false, entity.ErrInternal
+	}
+	return disabled, nil
+}
+
+func scanWebhook(row pgx.Row) (entity.WebhookSubscription, error) {
+	var sub entity.WebhookSubscription
+	var gameID *string
+	err := row.Scan(&sub.ID, &sub.Owner, &sub.URL, &sub.Secret, &sub.EventTypes, &gameID, &sub.Active,
+		&sub.ConsecutiveFailures, &sub.DisabledAt, &sub.CreatedAt)
+	if gameID != nil {
+		sub.GameID = *gameID
+	}
+	return sub, err
+}
+
+func nullableUUID(id string) any {
+	if id == "" {
+		return nil
+	}
+	return id
+}
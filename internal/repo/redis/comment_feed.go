@@ -0,0 +1,34 @@
+package redis_build
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"go.uber.org/zap"
+)
+
+// CommentFeed — лента новых комментариев поверх Hub, канал на игру
+type CommentFeed struct {
+	hub    *Hub
+	logger *zap.Logger
+}
+
+func NewCommentFeed(hub *Hub) *CommentFeed {
+	return &CommentFeed{hub: hub, logger: hub.logger.With(zap.String("feed", "comments"))}
+}
+
+// PublishComment рассылает комментарий подписчикам игры на всех репликах
+func (f *CommentFeed) PublishComment(ctx context.Context, c entity.Comment) error {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return f.hub.Publish(ctx, c.GameID, b)
+}
+
+// SubscribeComments возвращает канал новых комментариев игры.
+// Канал закрывается при отмене ctx или если читатель отстал и был отключён хабом.
+func (f *CommentFeed) SubscribeComments(ctx context.Context, gameID string) <-chan entity.Comment {
+	return decodeStream[entity.Comment](ctx, f.hub.Subscribe(gameID), f.logger)
+}
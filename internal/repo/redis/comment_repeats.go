@@ -0,0 +1,24 @@
+package redis_build
+
+import (
+	"context"
+	"time"
+)
+
+const commentRepeatPrefix = "comments:repeat:"
+
+// CountCommentRepeat увеличивает счётчик текста пользователя и возвращает его значение.
+// Окно отсчитывается от первой отправки: TTL ставится только новому ключу.
+func (r *RedisCache) CountCommentRepeat(ctx context.Context, userID, textHash string, window time.Duration) (int64, error) {
+	newCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+
+	key := commentRepeatPrefix + userID + ":" + textHash
+	pipe := r.client.TxPipeline()
+	incr := pipe.Incr(newCtx, key)
+	pipe.ExpireNX(newCtx, key, window)
+	if _, err := pipe.Exec(newCtx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
@@ -0,0 +1,171 @@
+package redis_build
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const _defaultSubscriberBuffer = 32
+
+// Hub раздаёт сообщения Redis Pub/Sub локальным подписчикам.
+// На реплику — одна PSUBSCRIBE-подписка на prefix*, дальше fan-out в памяти,
+// поэтому число соединений с Redis не растёт вместе с числом клиентов.
+type Hub struct {
+	client *redis.Client
+	logger *zap.Logger
+	prefix string
+	buffer int
+
+	mu   sync.Mutex
+	subs map[string]map[*Subscription]struct{}
+}
+
+// Subscription — подписка на один канал. C закрывается при Close
+// или если подписчик не успевает читать (буфер переполнен) — такого клиента отключаем,
+// чтобы медленный потребитель не задерживал остальных.
+type Subscription struct {
+	C <-chan []byte
+
+	hub     *Hub
+	channel string
+	ch      chan []byte
+	once    sync.Once
+}
+
+// NewHub создаёт хаб для каналов с заданным префиксом; buffer — размер очереди подписчика
+func (r *RedisCache) NewHub(prefix string, buffer int) *Hub {
+	if buffer <= 0 {
+		buffer = _defaultSubscriberBuffer
+	}
+	return &Hub{
+		client: r.client,
+		logger: r.logger.With(zap.String("hub", prefix)),
+		prefix: prefix,
+		buffer: buffer,
+		subs:   make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Publish отправляет сообщение всем репликам, подписанным на канал
+func (h *Hub) Publish(ctx context.Context, channel string, payload []byte) error {
+	newCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	if err := h.client.Publish(newCtx, h.prefix+channel, payload).Err(); err != nil {
+		h.logger.Error("cant publish to redis", zap.String("channel", channel), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Subscribe регистрирует локального подписчика; сообщения приходят, пока запущен Run
+func (h *Hub) Subscribe(channel string) *Subscription {
+	ch := make(chan []byte, h.buffer)
+	sub := &Subscription{C: ch, hub: h, channel: channel, ch: ch}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	set, ok := h.subs[channel]
+	if !ok {
+		set = make(map[*Subscription]struct{})
+		h.subs[channel] = set
+	}
+	set[sub] = struct{}{}
+	return sub
+}
+
+// Close отписывает и закрывает C; повторный вызов безопасен
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.closeLocked()
+}
+
+func (s *Subscription) closeLocked() {
+	s.once.Do(func() {
+		if set, ok := s.hub.subs[s.channel]; ok {
+			delete(set, s)
+			if len(set) == 0 {
+				delete(s.hub.subs, s.channel)
+			}
+		}
+		close(s.ch)
+	})
+}
+
+// Run читает PSUBSCRIBE prefix* и раздаёт сообщения, пока не отменён ctx.
+// Переподключение к Redis go-redis делает сам; сообщения, пришедшие во время разрыва, теряются —
+// SSE-клиенты добирают их по Last-Event-ID.
+func (h *Hub) Run(ctx context.Context) {
+	ps := h.client.PSubscribe(ctx, h.prefix+"*")
+	defer ps.Close()
+
+	h.logger.Info("redis hub started")
+	ch := ps.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			h.logger.Info("redis hub stopped")
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			h.dispatch(strings.TrimPrefix(msg.Channel, h.prefix), []byte(msg.Payload))
+		}
+	}
+}
+
+// dispatch не блокируется: подписчик с полным буфером отключается
+func (h *Hub) dispatch(channel string, payload []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs[channel] {
+		select {
+		case sub.ch <- payload:
+		default:
+			h.logger.Warn("slow subscriber dropped", zap.String("channel", channel))
+			sub.closeLocked()
+		}
+	}
+}
+
+// decodeStream разбирает JSON из подписки в отдельной горутине.
+// out без буфера: пока читатель занят, копится буфер подписки, и хаб отключает отставших.
+func decodeStream[T any](ctx context.Context, sub *Subscription, logger *zap.Logger) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+		defer sub.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case raw, ok := <-sub.C:
+				if !ok {
+					return
+				}
+				var v T
+				if err := json.Unmarshal(raw, &v); err != nil {
+					logger.Warn("skip malformed pubsub message", zap.Error(err))
+					continue
+				}
+				select {
+				case out <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,58 @@
+package redis_build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestHub(buffer int) *Hub {
+	r := &RedisCache{logger: zap.NewNop()}
+	return r.NewHub("test:", buffer)
+}
+
+func TestHub_DispatchToChannelSubscribers(t *testing.T) {
+	h := newTestHub(4)
+	a := h.Subscribe("game-1")
+	b := h.Subscribe("game-1")
+	other := h.Subscribe("game-2")
+
+	h.dispatch("game-1", []byte("hello"))
+
+	require.Equal(t, []byte("hello"), <-a.C)
+	require.Equal(t, []byte("hello"), <-b.C)
+	require.Len(t, other.C, 0)
+}
+
+func TestHub_SlowSubscriberDropped(t *testing.T) {
+	h := newTestHub(1)
+	slow := h.Subscribe("game-1")
+	fast := h.Subscribe("game-1")
+
+	h.dispatch("game-1", []byte("1"))
+	<-fast.C
+	h.dispatch("game-1", []byte("2"))
+
+	// первый буфер ещё не прочитан — медленный отключён, но успел получить то, что влезло
+	require.Equal(t, []byte("1"), <-slow.C)
+	_, ok := <-slow.C
+	require.False(t, ok)
+
+	require.Equal(t, []byte("2"), <-fast.C)
+	require.Len(t, h.subs["game-1"], 1)
+}
+
+func TestHub_CloseUnsubscribes(t *testing.T) {
+	h := newTestHub(1)
+	sub := h.Subscribe("game-1")
+	sub.Close()
+	sub.Close()
+
+	_, ok := <-sub.C
+	require.False(t, ok)
+	require.Empty(t, h.subs)
+
+	// рассылка после отписки не паникует
+	h.dispatch("game-1", []byte("x"))
+}
@@ -0,0 +1,72 @@
+package redis_build
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/redis/go-redis/v9"
+)
+
+const rateLimitPrefix = "ratelimit:"
+
+// GCRA (вариант token bucket с одним ключом): в ключе — theoretical arrival time.
+// Время берётся у Redis, а не у реплики: часы реплик за nginx могут расходиться.
+var gcraScript = redis.NewScript(`
+local key      = KEYS[1]
+local limit    = tonumber(ARGV[1])
+local period   = tonumber(ARGV[2])
+
+local interval = period / limit
+local t   = redis.call("TIME")
+local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+
+local tat = tonumber(redis.call("GET", key) or now)
+if tat < now then
+  tat = now
+end
+
+local new_tat  = tat + interval
+local allow_at = new_tat - period
+local diff     = now - allow_at
+
+if diff < 0 then
+  return {0, 0, tostring(-diff), tostring(tat - now)}
+end
+
+local reset_after = new_tat - now
+redis.call("SET", key, tostring(new_tat), "PX", math.ceil(reset_after * 1000))
+return {1, math.floor(diff / interval), "0", tostring(reset_after)}
+`)
+
+// AllowRate списывает один запрос из лимита ключа; состояние общее для всех реплик
+func (r *RedisCache) AllowRate(ctx context.Context, key string, limit entity.RateLimit) (entity.RateLimitResult, error) {
+	newCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+
+	res, err := gcraScript.Run(newCtx, r.client, []string{rateLimitPrefix + key},
+		limit.Requests, limit.Period.Seconds()).Slice()
+	if err != nil {
+		return entity.RateLimitResult{}, err
+	}
+
+	allowed, _ := res[0].(int64)
+	remaining, _ := res[1].(int64)
+	return entity.RateLimitResult{
+		Allowed:    allowed == 1,
+		Limit:      limit.Requests,
+		Remaining:  int(remaining),
+		RetryAfter: seconds(res[2]),
+		ResetAfter: seconds(res[3]),
+	}, nil
+}
+
+func seconds(v any) time.Duration {
+	s, _ := v.(string)
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(f * float64(time.Second))
+}
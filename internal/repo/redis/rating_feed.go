@@ -0,0 +1,38 @@
+package redis_build
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"go.uber.org/zap"
+)
+
+// канал внутри хаба: обновления всех игр идут одним потоком, фильтрует подписчик
+const ratingUpdatesChannel = "updates"
+
+// RatingFeed — обновления агрегатов рейтинга для всех реплик.
+// Kafka-консьюмер в группе видит только свои партиции, поэтому обновление,
+// применённое на одной реплике, раздаётся остальным через Redis.
+type RatingFeed struct {
+	hub    *Hub
+	logger *zap.Logger
+}
+
+func NewRatingFeed(hub *Hub) *RatingFeed {
+	return &RatingFeed{hub: hub, logger: hub.logger.With(zap.String("feed", "ratings"))}
+}
+
+// PublishRatingUpdate рассылает обновление всем репликам
+func (f *RatingFeed) PublishRatingUpdate(ctx context.Context, upd entity.RatingUpdate) error {
+	b, err := json.Marshal(upd)
+	if err != nil {
+		return err
+	}
+	return f.hub.Publish(ctx, ratingUpdatesChannel, b)
+}
+
+// SubscribeRatingUpdates возвращает канал обновлений; закрывается при отмене ctx или отставании читателя
+func (f *RatingFeed) SubscribeRatingUpdates(ctx context.Context) <-chan entity.RatingUpdate {
+	return decodeStream[entity.RatingUpdate](ctx, f.hub.Subscribe(ratingUpdatesChannel), f.logger)
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
@@ -22,9 +23,23 @@ func NewRedisClient(redis_address, passwrd string, db, ttl int, logger *zap.Logg
 		DB:       db,
 	})
 	logger = logger.With(zap.String("component", "Redis"))
+	// спаны пишутся в глобальный TracerProvider; пока трейсинг выключен — no-op
+	if err := redisotel.InstrumentTracing(client, redisotel.WithDBStatement(false)); err != nil {
+		logger.Warn("cant instrument redis tracing", zap.Error(err))
+	}
 	return &RedisCache{client: client, logger: logger, ttl: ttl}
 }
 
+// Ping — проверка для /readyz
+func (r *RedisCache) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
+// Close закрывает пул соединений
+func (r *RedisCache) Close() error {
+	return r.client.Close()
+}
+
 func (r *RedisCache) Get(ctx context.Context, key string) (string, error) {
 	newCtx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
@@ -51,3 +66,16 @@ func (r *RedisCache) Set(ctx context.Context, key, value string) error {
 	}
 	return nil
 }
+
+// Incr увеличивает счётчик и возвращает новое значение; ключ без TTL
+func (r *RedisCache) Incr(ctx context.Context, key string) (int64, error) {
+	newCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	n, err := r.client.Incr(newCtx, key).Result()
+	if err != nil {
+		r.logger.Error("cant incr key in redis", zap.String("key", key), zap.Error(err))
+		return 0, err
+	}
+	return n, nil
+}
@@ -0,0 +1,56 @@
+package seed
+
+import "github.com/RozmiDan/gameReviewHub/internal/entity"
+
+const (
+	_defaultBatchSize = 500
+	// GetGameInfo передаёт id отдельными параметрами, а у Postgres их не больше 65535
+	_maxBatchSize = 10000
+)
+
+// Option -.
+type Option func(*Loader)
+
+// BatchSize — записей в одном COPY (и в одной транзакции) или в одной пачке оценок
+func BatchSize(n int) Option {
+	return func(l *Loader) {
+		if n > 0 {
+			l.batchSize = min(n, _maxBatchSize)
+		}
+	}
+}
+
+// OnConflict — entity.ConflictSkip (по умолчанию), ConflictUpsert или ConflictError
+func OnConflict(mode string) Option {
+	return func(l *Loader) {
+		l.onConflict = mode
+	}
+}
+
+// DryRun — только прочитать и проверить файл, ничего не записывая
+func DryRun(on bool) Option {
+	return func(l *Loader) {
+		l.dryRun = on
+	}
+}
+
+// OnProgress вызывается после каждого батча
+func OnProgress(fn func(Progress)) Option {
+	return func(l *Loader) {
+		l.onProgress = fn
+	}
+}
+
+// OnInvalid вызывается для каждой пропущенной некорректной записи
+func OnInvalid(fn func(kind string, err *RecordError)) Option {
+	return func(l *Loader) {
+		l.onInvalid = fn
+	}
+}
+
+func defaults(l *Loader) {
+	l.batchSize = _defaultBatchSize
+	l.onConflict = entity.ConflictSkip
+	l.onProgress = func(Progress) {}
+	l.onInvalid = func(string, *RecordError) {}
+}
@@ -0,0 +1,250 @@
+package seed
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// форматы исходных файлов
+const (
+	FormatAuto   = "auto"
+	FormatJSON   = "json"   // JSON-массив объектов: seed_games_table.json, seed_comments.json, seed_rating.json
+	FormatJSONL  = "jsonl"  // объект на строку: load-test/games.csv
+	FormatJMeter = "jmeter" // путь;тело запроса: load-test/comments.csv, load-test/ratings.csv
+	FormatCSV    = "csv"    // CSV с заголовком из имён полей
+)
+
+// Row — одна запись файла: имя поля → значение
+type Row struct {
+	// Record — порядковый номер записи с 1, для сообщений об ошибках
+	Record int
+	Fields map[string]string
+}
+
+// RecordError — запись, которую не удалось разобрать или которая не прошла проверку.
+// Загрузка её пропускает и продолжается; остальные ошибки Reader'а её прерывают.
+type RecordError struct {
+	Record int
+	Err    error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("record %d: %v", e.Record, e.Err)
+}
+
+func (e *RecordError) Unwrap() error { return e.Err }
+
+// Reader отдаёт записи по одной, в конце — io.EOF. Файл целиком в память не читается.
+type Reader interface {
+	Next() (Row, error)
+}
+
+// NewReader; FormatAuto определяет формат по первому значащему символу файла
+func NewReader(r io.Reader, format string) (Reader, error) {
+	br := bufio.NewReader(r)
+	// UTF-8 BOM от Excel ломает и JSON, и первое имя колонки CSV
+	if bom, _ := br.Peek(3); bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+		_, _ = br.Discard(3)
+	}
+	if format == FormatAuto {
+		format = detectFormat(br)
+	}
+
+	switch format {
+	case FormatJSON:
+		dec := json.NewDecoder(br)
+		dec.UseNumber()
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("json: %w", err)
+		}
+		if tok != json.Delim('[') {
+			return nil, errors.New("json: expected an array of objects")
+		}
+		return &jsonReader{dec: dec}, nil
+	case FormatJSONL:
+		return &lineReader{scanner: newScanner(br), parse: parseJSONObject}, nil
+	case FormatJMeter:
+		return &lineReader{scanner: newScanner(br), parse: parseJMeterLine}, nil
+	case FormatCSV:
+		cr := csv.NewReader(br)
+		cr.TrimLeadingSpace = true
+		header, err := cr.Read()
+		if err != nil {
+			return nil, fmt.Errorf("csv header: %w", err)
+		}
+		for i := range header {
+			header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+		}
+		// строки с другим числом полей — ошибка записи, а не всего файла
+		cr.FieldsPerRecord = -1
+		return &csvReader{reader: cr, header: header}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+func detectFormat(br *bufio.Reader) string {
+	for n := 1; ; n++ {
+		peek, _ := br.Peek(n)
+		if len(peek) < n {
+			return FormatCSV
+		}
+		switch peek[n-1] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '[':
+			return FormatJSON
+		case '{':
+			return FormatJSONL
+		case '/':
+			return FormatJMeter
+		}
+		return FormatCSV
+	}
+}
+
+type jsonReader struct {
+	dec    *json.Decoder
+	record int
+}
+
+func (r *jsonReader) Next() (Row, error) {
+	if !r.dec.More() {
+		return Row{}, io.EOF
+	}
+	r.record++
+
+	// синтаксическая ошибка внутри массива не даёт продолжить чтение — это ошибка всего файла
+	var obj map[string]any
+	if err := r.dec.Decode(&obj); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return Row{}, &RecordError{r.record, errors.New("not a JSON object")}
+		}
+		return Row{}, fmt.Errorf("record %d: %w", r.record, err)
+	}
+	fields, err := flatten(obj)
+	if err != nil {
+		return Row{}, &RecordError{r.record, err}
+	}
+	return Row{Record: r.record, Fields: fields}, nil
+}
+
+// lineReader — форматы «запись на строку»; пустые строки пропускаются
+type lineReader struct {
+	scanner *bufio.Scanner
+	parse   func(line []byte) (map[string]string, error)
+	record  int
+}
+
+func newScanner(r io.Reader) *bufio.Scanner {
+	s := bufio.NewScanner(r)
+	// длинные описания игр не влезают в стандартные 64KB
+	s.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	return s
+}
+
+func (r *lineReader) Next() (Row, error) {
+	for r.scanner.Scan() {
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		r.record++
+		fields, err := r.parse(line)
+		if err != nil {
+			return Row{}, &RecordError{r.record, err}
+		}
+		return Row{Record: r.record, Fields: fields}, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return Row{}, err
+	}
+	return Row{}, io.EOF
+}
+
+func parseJSONObject(line []byte) (map[string]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return flatten(obj)
+}
+
+// parseJMeterLine: /games/<game_id>/comments;{"user_id": "...", "text": "..."} — game_id берётся из пути
+func parseJMeterLine(line []byte) (map[string]string, error) {
+	path, body, ok := bytes.Cut(line, []byte(";"))
+	if !ok {
+		return nil, errors.New("expected <path>;<json body>")
+	}
+	parts := strings.Split(strings.Trim(string(path), "/"), "/")
+	if len(parts) < 2 || parts[0] != "games" {
+		return nil, fmt.Errorf("unexpected path %q", path)
+	}
+
+	fields, err := parseJSONObject(body)
+	if err != nil {
+		return nil, err
+	}
+	fields["game_id"] = parts[1]
+	return fields, nil
+}
+
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+	record int
+}
+
+func (r *csvReader) Next() (Row, error) {
+	values, err := r.reader.Read()
+	if err == io.EOF {
+		return Row{}, io.EOF
+	}
+	r.record++
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return Row{}, &RecordError{r.record, err}
+		}
+		return Row{}, err
+	}
+	if len(values) != len(r.header) {
+		return Row{}, &RecordError{r.record, fmt.Errorf("expected %d fields, got %d", len(r.header), len(values))}
+	}
+
+	fields := make(map[string]string, len(values))
+	for i, v := range values {
+		fields[r.header[i]] = v
+	}
+	return Row{Record: r.record, Fields: fields}, nil
+}
+
+// flatten приводит значения JSON-объекта к строкам — дальше все форматы проверяются одинаково
+func flatten(obj map[string]any) (map[string]string, error) {
+	fields := make(map[string]string, len(obj))
+	for k, v := range obj {
+		switch v := v.(type) {
+		case nil:
+			fields[k] = ""
+		case string:
+			fields[k] = v
+		case json.Number:
+			fields[k] = v.String()
+		case bool:
+			fields[k] = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("field %q: nested values are not supported", k)
+		}
+	}
+	return fields, nil
+}
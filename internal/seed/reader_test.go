@@ -0,0 +1,135 @@
+package seed
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readAll(t *testing.T, r Reader) (rows []Row, invalid []*RecordError) {
+	t.Helper()
+	for {
+		row, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return rows, invalid
+		}
+		var recErr *RecordError
+		if errors.As(err, &recErr) {
+			invalid = append(invalid, recErr)
+			continue
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func TestNewReader_DetectsFormat(t *testing.T) {
+	cases := map[string]string{
+		"json array":   "\xEF\xBB\xBF  [{\"name\": \"Doom\", \"rating\": 7}]",
+		"json lines":   "{\"name\": \"Doom\", \"rating\": 7}\n\n",
+		"jmeter":       "/games/4b825dc6-1e82-4f38-9f8e-3a2f1b3c4d5e/rating;{\"name\": \"Doom\", \"rating\": 7}\n",
+		"csv":          "name,rating\nDoom,7\n",
+		"csv with bom": "\xEF\xBB\xBFName, Rating\nDoom,7\n",
+	}
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			r, err := NewReader(strings.NewReader(input), FormatAuto)
+			require.NoError(t, err)
+			rows, invalid := readAll(t, r)
+			require.Empty(t, invalid)
+			require.Len(t, rows, 1)
+			require.Equal(t, 1, rows[0].Record)
+			require.Equal(t, "Doom", rows[0].Fields["name"])
+			require.Equal(t, "7", rows[0].Fields["rating"])
+		})
+	}
+}
+
+func TestNewReader_JMeterTakesGameFromPath(t *testing.T) {
+	r, err := NewReader(strings.NewReader(
+		`/games/4b825dc6-1e82-4f38-9f8e-3a2f1b3c4d5e/comments;{"user_id": "u1", "text": "gg"}`), FormatJMeter)
+	require.NoError(t, err)
+	rows, _ := readAll(t, r)
+	require.Equal(t, map[string]string{
+		"game_id": "4b825dc6-1e82-4f38-9f8e-3a2f1b3c4d5e", "user_id": "u1", "text": "gg",
+	}, rows[0].Fields)
+}
+
+func TestNewReader_BadRecordsAreSkipped(t *testing.T) {
+	t.Run("json lines", func(t *testing.T) {
+		r, err := NewReader(strings.NewReader("{\"name\":\"a\"}\nnot json\n{\"name\":{\"nested\":1}}\n{\"name\":\"b\"}\n"), FormatJSONL)
+		require.NoError(t, err)
+		rows, invalid := readAll(t, r)
+		require.Len(t, rows, 2)
+		require.Equal(t, 4, rows[1].Record)
+		require.Len(t, invalid, 2)
+		require.Equal(t, 2, invalid[0].Record)
+		require.ErrorContains(t, invalid[1], `field "name": nested values are not supported`)
+	})
+	t.Run("json array", func(t *testing.T) {
+		r, err := NewReader(strings.NewReader(`[{"name":"a"}, 42, {"name":"b"}]`), FormatJSON)
+		require.NoError(t, err)
+		rows, invalid := readAll(t, r)
+		require.Len(t, rows, 2)
+		require.Len(t, invalid, 1)
+		require.Equal(t, 2, invalid[0].Record)
+	})
+	t.Run("csv", func(t *testing.T) {
+		r, err := NewReader(strings.NewReader("name,genre\na,b\nonly-one\nc,d\n"), FormatCSV)
+		require.NoError(t, err)
+		rows, invalid := readAll(t, r)
+		require.Len(t, rows, 2)
+		require.Len(t, invalid, 1)
+		require.ErrorContains(t, invalid[0], "record 2: expected 2 fields, got 1")
+	})
+}
+
+func TestNewReader_BrokenJSONArrayStops(t *testing.T) {
+	r, err := NewReader(strings.NewReader(`[{"name":"a"}, {"name":`), FormatJSON)
+	require.NoError(t, err)
+	_, err = r.Next()
+	require.NoError(t, err)
+	_, err = r.Next()
+	require.Error(t, err)
+	var recErr *RecordError
+	require.False(t, errors.As(err, &recErr))
+
+	_, err = NewReader(strings.NewReader(`{"name":"a"}`), FormatJSON)
+	require.ErrorContains(t, err, "expected an array")
+}
+
+// файлы из репозитория читаются и проходят проверку без ошибок
+func TestRepoSeedFiles(t *testing.T) {
+	cases := []struct {
+		path  string
+		parse func(Row) error
+		count int
+	}{
+		{"../../seed_games_table.json", func(r Row) error { _, err := parseGame(r); return err }, 33},
+		{"../../load-test/games.csv", func(r Row) error { _, err := parseGame(r); return err }, 33},
+		{"../../seed_comments.json", func(r Row) error { _, err := parseComment(r, fixedNow); return err }, 74},
+		{"../../load-test/comments.csv", func(r Row) error { _, err := parseComment(r, fixedNow); return err }, 74},
+		{"../../seed_rating.json", func(r Row) error { _, err := parseRating(r); return err }, 67},
+		{"../../load-test/ratings.csv", func(r Row) error { _, err := parseRating(r); return err }, 67},
+	}
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			f, err := os.Open(tc.path)
+			require.NoError(t, err)
+			defer f.Close()
+
+			r, err := NewReader(f, FormatAuto)
+			require.NoError(t, err)
+			rows, invalid := readAll(t, r)
+			require.Empty(t, invalid)
+			require.Len(t, rows, tc.count)
+			for _, row := range rows {
+				require.NoError(t, tc.parse(row), "record %d", row.Record)
+			}
+		})
+	}
+}
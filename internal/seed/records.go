@@ -0,0 +1,118 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/google/uuid"
+)
+
+// те же ограничения, что у POST /games/{id}/comments и POST /games/{id}/rating
+const (
+	_maxCommentLength = 1000
+	_minRating        = 1
+	_maxRating        = 10
+)
+
+// записи без id получают id от содержимого: повторный seed того же файла
+// в режиме skip ничего не задваивает
+var _seedNamespace = uuid.NewSHA1(uuid.NameSpaceOID, []byte("gameReviewHub/seed"))
+
+func parseGame(row Row) (entity.Game, error) {
+	f := row.Fields
+	game := entity.Game{
+		ID:          strings.TrimSpace(f["id"]),
+		Name:        strings.TrimSpace(f["name"]),
+		Genre:       orDefault(f["genre"], "Unknown"),
+		Creator:     orDefault(f["creator"], "Unknown"),
+		Description: strings.TrimSpace(f["description"]),
+	}
+	if game.Name == "" {
+		return entity.Game{}, errors.New("name is required")
+	}
+	if game.ID == "" {
+		game.ID = uuid.NewSHA1(_seedNamespace, []byte("game\x00"+game.Name)).String()
+	} else if _, err := uuid.Parse(game.ID); err != nil {
+		return entity.Game{}, fmt.Errorf("id %q is not a valid UUID", game.ID)
+	}
+
+	releaseDate, err := time.Parse("2006-01-02", strings.TrimSpace(f["release_date"]))
+	if err != nil {
+		return entity.Game{}, fmt.Errorf("release_date %q must be YYYY-MM-DD", f["release_date"])
+	}
+	game.ReleaseDate = releaseDate
+	return game, nil
+}
+
+// parseComment: created_at необязателен (RFC 3339), без него — время загрузки.
+// Фильтр комментариев seed не проходит: загруженные комментарии сразу видимые.
+func parseComment(row Row, now time.Time) (entity.Comment, error) {
+	f := row.Fields
+	comment := entity.Comment{
+		ID:        strings.TrimSpace(f["id"]),
+		GameID:    strings.TrimSpace(f["game_id"]),
+		UserID:    strings.TrimSpace(f["user_id"]),
+		Text:      f["text"],
+		Status:    entity.CommentVisible,
+		CreatedAt: now,
+	}
+	if err := errors.Join(requireUUID("game_id", comment.GameID), requireUUID("user_id", comment.UserID)); err != nil {
+		return entity.Comment{}, err
+	}
+	if len(comment.Text) == 0 || len(comment.Text) > _maxCommentLength {
+		return entity.Comment{}, fmt.Errorf("text must be 1..%d bytes, got %d", _maxCommentLength, len(comment.Text))
+	}
+	if comment.ID == "" {
+		comment.ID = uuid.NewSHA1(_seedNamespace,
+			[]byte("comment\x00"+comment.GameID+"\x00"+comment.UserID+"\x00"+comment.Text)).String()
+	} else if _, err := uuid.Parse(comment.ID); err != nil {
+		return entity.Comment{}, fmt.Errorf("id %q is not a valid UUID", comment.ID)
+	}
+	if s := strings.TrimSpace(f["created_at"]); s != "" {
+		createdAt, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return entity.Comment{}, fmt.Errorf("created_at %q must be RFC 3339", s)
+		}
+		comment.CreatedAt = createdAt
+	}
+	return comment, nil
+}
+
+func parseRating(row Row) (entity.RatingMessage, error) {
+	f := row.Fields
+	msg := entity.RatingMessage{
+		GameID: strings.TrimSpace(f["game_id"]),
+		UserID: strings.TrimSpace(f["user_id"]),
+	}
+	if err := errors.Join(requireUUID("game_id", msg.GameID), requireUUID("user_id", msg.UserID)); err != nil {
+		return entity.RatingMessage{}, err
+	}
+	rating, err := strconv.ParseInt(strings.TrimSpace(f["rating"]), 10, 32)
+	if err != nil || rating < _minRating || rating > _maxRating {
+		return entity.RatingMessage{}, fmt.Errorf("rating %q must be an integer %d..%d", f["rating"], _minRating, _maxRating)
+	}
+	msg.Rating = int32(rating)
+	return msg, nil
+}
+
+func requireUUID(field, v string) error {
+	if v == "" {
+		return fmt.Errorf("%s is required", field)
+	}
+	if _, err := uuid.Parse(v); err != nil {
+		return fmt.Errorf("%s %q is not a valid UUID", field, v)
+	}
+	return nil
+}
+
+// orDefault — как DEFAULT 'Unknown' у колонок games
+func orDefault(v, def string) string {
+	if v = strings.TrimSpace(v); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,238 @@
+// Package seed проверяет и массово загружает игры, комментарии и оценки из файлов (cmd/seed).
+// Игры и комментарии идут в Postgres через COPY, оценки — через RatingProducer, как из POST /games/{id}/rating.
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"go.uber.org/zap"
+)
+
+// виды загружаемых записей
+const (
+	KindGames    = "games"
+	KindComments = "comments"
+	KindRatings  = "ratings"
+)
+
+type Store interface {
+	CopyGames(ctx context.Context, games []entity.Game, onConflict string) (int64, error)
+	CopyComments(ctx context.Context, comments []entity.Comment, onConflict string) (int64, error)
+	GetGameInfo(ctx context.Context, ids []string) ([]entity.GameInList, error)
+}
+
+// RatingProducer — то же, что usecase.RatingProducer
+type RatingProducer interface {
+	PublishRating(ctx context.Context, msg entity.RatingMessage) error
+}
+
+// Stats — итог загрузки; Read = Valid + Invalid, Valid = Written + Skipped
+type Stats struct {
+	Read    int
+	Valid   int
+	Invalid int
+	// записано в БД или отправлено в Kafka; в dry-run всегда 0
+	Written int
+	// дубликаты внутри батча, уже существующие записи и записи к несуществующим играм
+	Skipped int
+	Batches int
+	Elapsed time.Duration
+}
+
+// Progress — состояние после очередного батча
+type Progress struct {
+	Kind string
+	Stats
+}
+
+type Loader struct {
+	store    Store
+	producer RatingProducer
+	logger   *zap.Logger
+
+	batchSize  int
+	onConflict string
+	dryRun     bool
+	onProgress func(Progress)
+	onInvalid  func(kind string, err *RecordError)
+}
+
+// New: в dry-run store и producer не нужны и могут быть nil
+func New(store Store, producer RatingProducer, logger *zap.Logger, opts ...Option) *Loader {
+	l := &Loader{
+		store:    store,
+		producer: producer,
+		logger:   logger.With(zap.String("component", "seed")),
+	}
+	defaults(l)
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+func (l *Loader) LoadGames(ctx context.Context, src Reader) (Stats, error) {
+	return load(ctx, l, KindGames, src, parseGame,
+		func(g entity.Game) string { return g.ID },
+		func(ctx context.Context, batch []entity.Game) (int, error) {
+			n, err := l.store.CopyGames(ctx, batch, l.onConflict)
+			return int(n), err
+		})
+}
+
+func (l *Loader) LoadComments(ctx context.Context, src Reader) (Stats, error) {
+	now := time.Now().UTC()
+	return load(ctx, l, KindComments, src,
+		func(row Row) (entity.Comment, error) { return parseComment(row, now) },
+		func(c entity.Comment) string { return c.ID },
+		func(ctx context.Context, batch []entity.Comment) (int, error) {
+			n, err := l.store.CopyComments(ctx, batch, l.onConflict)
+			return int(n), err
+		})
+}
+
+// LoadRatings публикует оценки по одной. Оценки к играм, которых нет в БД, пропускаются
+// (в режиме error — прерывают загрузку), как и в usecase.PostRating.
+func (l *Loader) LoadRatings(ctx context.Context, src Reader) (Stats, error) {
+	return load(ctx, l, KindRatings, src, parseRating,
+		// одна оценка на пользователя и игру
+		func(m entity.RatingMessage) string { return m.GameID + "/" + m.UserID },
+		l.publishRatings)
+}
+
+func (l *Loader) publishRatings(ctx context.Context, batch []entity.RatingMessage) (int, error) {
+	// 1) какие игры из батча существуют
+	ids := make([]string, 0, len(batch))
+	seen := make(map[string]bool, len(batch))
+	for _, m := range batch {
+		if !seen[m.GameID] {
+			seen[m.GameID] = true
+			ids = append(ids, m.GameID)
+		}
+	}
+	games, err := l.store.GetGameInfo(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("check games: %w", err)
+	}
+	exists := make(map[string]bool, len(games))
+	for _, g := range games {
+		exists[g.ID] = true
+	}
+
+	// 2) публикация
+	published := 0
+	for _, m := range batch {
+		if !exists[m.GameID] {
+			if l.onConflict == entity.ConflictError {
+				return published, fmt.Errorf("%w: %s", entity.ErrGameNotFound, m.GameID)
+			}
+			continue
+		}
+		if err := l.producer.PublishRating(ctx, m); err != nil {
+			return published, fmt.Errorf("publish rating %s/%s: %w", m.GameID, m.UserID, err)
+		}
+		published++
+	}
+	return published, nil
+}
+
+// load читает src, пропускает некорректные записи и сбрасывает корректные батчами в flush.
+// Батчи, записанные до ошибки, остаются в БД: повторный запуск в режиме skip догрузит остальное.
+func load[T any](ctx context.Context, l *Loader, kind string, src Reader, parse func(Row) (T, error),
+	key func(T) string, flush func(context.Context, []T) (int, error)) (Stats, error) {
+
+	logger := l.logger.With(zap.String("kind", kind), zap.Bool("dry_run", l.dryRun),
+		zap.String("on_conflict", l.onConflict))
+	start := time.Now()
+
+	var (
+		stats Stats
+		batch = make([]T, 0, l.batchSize)
+		// позиция записи в батче по ключу — для дубликатов внутри батча
+		index = make(map[string]int, l.batchSize)
+	)
+
+	// 1) сброс батча
+	commit := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		stats.Batches++
+		if !l.dryRun {
+			written, err := flush(ctx, batch)
+			stats.Written += written
+			if err != nil {
+				logger.Error("batch failed", zap.Int("batch", stats.Batches), zap.Error(err))
+				return fmt.Errorf("%s batch %d: %w", kind, stats.Batches, err)
+			}
+			stats.Skipped += len(batch) - written
+		}
+		stats.Elapsed = time.Since(start)
+		l.onProgress(Progress{Kind: kind, Stats: stats})
+
+		batch = batch[:0]
+		clear(index)
+		return nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		// 2) чтение и проверка записи
+		row, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var item T
+		if err == nil {
+			if item, err = parse(row); err != nil {
+				err = &RecordError{Record: row.Record, Err: err}
+			}
+		}
+		var recErr *RecordError
+		switch {
+		case errors.As(err, &recErr):
+			stats.Read++
+			stats.Invalid++
+			l.onInvalid(kind, recErr)
+			continue
+		case err != nil:
+			return stats, fmt.Errorf("read %s: %w", kind, err)
+		}
+		stats.Read++
+		stats.Valid++
+
+		// 3) дубликат внутри батча: skip оставляет первую запись, upsert — последнюю,
+		// error отдаёт обе в БД, и батч падает на конфликте
+		if i, dup := index[key(item)]; dup && l.onConflict != entity.ConflictError {
+			stats.Skipped++
+			if l.onConflict == entity.ConflictUpsert {
+				batch[i] = item
+			}
+			continue
+		}
+		index[key(item)] = len(batch)
+		batch = append(batch, item)
+		if len(batch) >= l.batchSize {
+			if err := commit(); err != nil {
+				return stats, err
+			}
+		}
+	}
+
+	// 4) остаток
+	if err := commit(); err != nil {
+		return stats, err
+	}
+	stats.Elapsed = time.Since(start)
+	logger.Info("seed finished", zap.Int("read", stats.Read), zap.Int("invalid", stats.Invalid),
+		zap.Int("written", stats.Written), zap.Int("skipped", stats.Skipped), zap.Duration("took", stats.Elapsed))
+	return stats, nil
+}
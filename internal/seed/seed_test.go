@@ -0,0 +1,298 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var fixedNow = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+const (
+	game1 = "4b825dc6-1e82-4f38-9f8e-3a2f1b3c4d5e"
+	game2 = "9c8ef1a2-3b4c-4d5e-8f9a-1bc2d3e4f5a6"
+	user1 = "11111111-1111-1111-1111-111111111111"
+	user2 = "22222222-2222-2222-2222-222222222222"
+)
+
+// fakeStore ведёт себя как CopyGames/CopyComments в режиме skip: существующие id не перезаписываются
+type fakeStore struct {
+	games    map[string]entity.Game
+	comments map[string]entity.Comment
+	batches  []int
+	fail     error
+}
+
+func newFakeStore(gameIDs ...string) *fakeStore {
+	s := &fakeStore{games: map[string]entity.Game{}, comments: map[string]entity.Comment{}}
+	for _, id := range gameIDs {
+		s.games[id] = entity.Game{ID: id}
+	}
+	return s
+}
+
+func (s *fakeStore) CopyGames(_ context.Context, games []entity.Game, onConflict string) (int64, error) {
+	s.batches = append(s.batches, len(games))
+	if s.fail != nil {
+		return 0, s.fail
+	}
+	var n int64
+	for _, g := range games {
+		if _, ok := s.games[g.ID]; ok && onConflict != entity.ConflictUpsert {
+			continue
+		}
+		s.games[g.ID] = g
+		n++
+	}
+	return n, nil
+}
+
+func (s *fakeStore) CopyComments(_ context.Context, comments []entity.Comment, _ string) (int64, error) {
+	s.batches = append(s.batches, len(comments))
+	var n int64
+	for _, c := range comments {
+		if _, ok := s.games[c.GameID]; !ok {
+			continue
+		}
+		if _, ok := s.comments[c.ID]; ok {
+			continue
+		}
+		s.comments[c.ID] = c
+		n++
+	}
+	return n, nil
+}
+
+func (s *fakeStore) GetGameInfo(_ context.Context, ids []string) ([]entity.GameInList, error) {
+	var out []entity.GameInList
+	for _, id := range ids {
+		if _, ok := s.games[id]; ok {
+			out = append(out, entity.GameInList{ID: id})
+		}
+	}
+	return out, nil
+}
+
+type fakeProducer struct {
+	published []entity.RatingMessage
+	fail      error
+}
+
+func (p *fakeProducer) PublishRating(_ context.Context, msg entity.RatingMessage) error {
+	if p.fail != nil {
+		return p.fail
+	}
+	p.published = append(p.published, msg)
+	return nil
+}
+
+func jsonl(lines ...string) Reader {
+	r, _ := NewReader(strings.NewReader(strings.Join(lines, "\n")), FormatJSONL)
+	return r
+}
+
+func gameLine(id, name string) string {
+	return fmt.Sprintf(`{"id":%q,"name":%q,"genre":"Shooter","creator":"Valve","release_date":"2004-11-16"}`, id, name)
+}
+
+func TestLoadGames_BatchesAndInvalidRecords(t *testing.T) {
+	store := newFakeStore()
+	var (
+		progress []Progress
+		invalid  []*RecordError
+	)
+	loader := New(store, nil, zap.NewNop(), BatchSize(2),
+		OnProgress(func(p Progress) { progress = append(progress, p) }),
+		OnInvalid(func(_ string, err *RecordError) { invalid = append(invalid, err) }))
+
+	stats, err := loader.LoadGames(context.Background(), jsonl(
+		gameLine(game1, "Half-Life 2"),
+		`{"name":"No Date"}`,
+		gameLine("not-a-uuid", "Portal"),
+		`{"name":"Minecraft","release_date":"2011-11-18"}`,
+		gameLine(game2, "Valorant"),
+	))
+	require.NoError(t, err)
+
+	require.Equal(t, Stats{Read: 5, Valid: 3, Invalid: 2, Written: 3, Batches: 2, Elapsed: stats.Elapsed}, stats)
+	require.Equal(t, []int{2, 1}, store.batches)
+	require.Len(t, progress, 2)
+	require.Equal(t, 2, progress[0].Written)
+
+	require.Len(t, invalid, 2)
+	require.Equal(t, 2, invalid[0].Record)
+	require.ErrorContains(t, invalid[0], "release_date")
+	require.ErrorContains(t, invalid[1], `id "not-a-uuid" is not a valid UUID`)
+
+	// игра без id получила стабильный id и значения по умолчанию
+	var minecraft entity.Game
+	for _, g := range store.games {
+		if g.Name == "Minecraft" {
+			minecraft = g
+		}
+	}
+	require.NotEmpty(t, minecraft.ID)
+	require.Equal(t, "Unknown", minecraft.Genre)
+	again, err := parseGame(Row{Fields: map[string]string{"name": "Minecraft", "release_date": "2011-11-18"}})
+	require.NoError(t, err)
+	require.Equal(t, minecraft.ID, again.ID)
+}
+
+func TestLoadGames_DuplicatesInBatch(t *testing.T) {
+	for mode, wantGenre := range map[string]string{
+		entity.ConflictSkip:   "Shooter",
+		entity.ConflictUpsert: "Tactical shooter",
+	} {
+		t.Run(mode, func(t *testing.T) {
+			store := newFakeStore()
+			loader := New(store, nil, zap.NewNop(), OnConflict(mode))
+
+			stats, err := loader.LoadGames(context.Background(), jsonl(
+				gameLine(game1, "Valorant"),
+				`{"id":"`+game1+`","name":"Valorant","genre":"Tactical shooter","release_date":"2020-06-02"}`,
+			))
+			require.NoError(t, err)
+			require.Equal(t, 1, stats.Written)
+			require.Equal(t, 1, stats.Skipped)
+			require.Equal(t, []int{1}, store.batches)
+			require.Equal(t, wantGenre, store.games[game1].Genre)
+		})
+	}
+
+	t.Run(entity.ConflictError, func(t *testing.T) {
+		store := newFakeStore()
+		loader := New(store, nil, zap.NewNop(), OnConflict(entity.ConflictError))
+		_, err := loader.LoadGames(context.Background(), jsonl(gameLine(game1, "a"), gameLine(game1, "b")))
+		require.NoError(t, err)
+		// оба дубликата ушли в БД — конфликт ловит она
+		require.Equal(t, []int{2}, store.batches)
+	})
+}
+
+func TestLoadGames_RerunSkipsExisting(t *testing.T) {
+	store := newFakeStore()
+	loader := New(store, nil, zap.NewNop())
+	input := []string{gameLine(game1, "Half-Life 2"), gameLine(game2, "Valorant")}
+
+	_, err := loader.LoadGames(context.Background(), jsonl(input...))
+	require.NoError(t, err)
+	stats, err := loader.LoadGames(context.Background(), jsonl(input...))
+	require.NoError(t, err)
+	require.Equal(t, 0, stats.Written)
+	require.Equal(t, 2, stats.Skipped)
+}
+
+func TestLoadGames_BatchErrorStops(t *testing.T) {
+	store := newFakeStore()
+	store.fail = entity.ErrGameAlreadyExists
+	loader := New(store, nil, zap.NewNop(), BatchSize(1))
+
+	stats, err := loader.LoadGames(context.Background(), jsonl(gameLine(game1, "a"), gameLine(game2, "b")))
+	require.ErrorIs(t, err, entity.ErrGameAlreadyExists)
+	require.ErrorContains(t, err, "games batch 1")
+	require.Equal(t, 1, stats.Read)
+	require.Zero(t, stats.Skipped)
+}
+
+func TestLoadGames_DryRunWritesNothing(t *testing.T) {
+	var progress int
+	loader := New(nil, nil, zap.NewNop(), DryRun(true), BatchSize(1),
+		OnProgress(func(Progress) { progress++ }))
+
+	stats, err := loader.LoadGames(context.Background(), jsonl(gameLine(game1, "a"), `{"name":"b"}`, gameLine(game2, "c")))
+	require.NoError(t, err)
+	require.Equal(t, 3, stats.Read)
+	require.Equal(t, 2, stats.Valid)
+	require.Equal(t, 1, stats.Invalid)
+	require.Zero(t, stats.Written)
+	require.Equal(t, 2, progress)
+}
+
+func TestLoadComments_SkipsUnknownGamesAndDuplicates(t *testing.T) {
+	store := newFakeStore(game1)
+	loader := New(store, nil, zap.NewNop())
+	input := []string{
+		`{"game_id":"` + game1 + `","user_id":"` + user1 + `","text":"gg"}`,
+		`{"game_id":"` + game2 + `","user_id":"` + user1 + `","text":"unknown game"}`,
+		`{"game_id":"` + game1 + `","user_id":"` + user2 + `","text":"nice","created_at":"2024-01-02T03:04:05Z"}`,
+		`{"game_id":"` + game1 + `","user_id":"` + user2 + `","text":""}`,
+	}
+
+	stats, err := loader.LoadComments(context.Background(), jsonl(input...))
+	require.NoError(t, err)
+	require.Equal(t, Stats{Read: 4, Valid: 3, Invalid: 1, Written: 2, Skipped: 1, Batches: 1, Elapsed: stats.Elapsed}, stats)
+
+	for _, c := range store.comments {
+		require.Equal(t, entity.CommentVisible, c.Status)
+		if c.Text == "nice" {
+			require.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), c.CreatedAt)
+		}
+	}
+
+	// у комментариев без id id стабильный — повторная загрузка ничего не задваивает
+	stats, err = loader.LoadComments(context.Background(), jsonl(input...))
+	require.NoError(t, err)
+	require.Zero(t, stats.Written)
+	require.Len(t, store.comments, 2)
+}
+
+func TestLoadRatings(t *testing.T) {
+	input := []string{
+		`{"game_id":"` + game1 + `","user_id":"` + user1 + `","rating":7}`,
+		`{"game_id":"` + game2 + `","user_id":"` + user1 + `","rating":5}`,
+		`{"game_id":"` + game1 + `","user_id":"` + user1 + `","rating":9}`,
+		`{"game_id":"` + game1 + `","user_id":"` + user2 + `","rating":11}`,
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		producer := &fakeProducer{}
+		loader := New(newFakeStore(game1), producer, zap.NewNop())
+
+		stats, err := loader.LoadRatings(context.Background(), jsonl(input...))
+		require.NoError(t, err)
+		// вторая оценка того же пользователя и оценка к неизвестной игре пропущены, 11 — вне диапазона
+		require.Equal(t, Stats{Read: 4, Valid: 3, Invalid: 1, Written: 1, Skipped: 2, Batches: 1, Elapsed: stats.Elapsed}, stats)
+		require.Equal(t, []entity.RatingMessage{{GameID: game1, UserID: user1, Rating: 7}}, producer.published)
+	})
+
+	t.Run("upsert keeps the last rating", func(t *testing.T) {
+		producer := &fakeProducer{}
+		loader := New(newFakeStore(game1), producer, zap.NewNop(), OnConflict(entity.ConflictUpsert))
+
+		_, err := loader.LoadRatings(context.Background(), jsonl(input...))
+		require.NoError(t, err)
+		require.Equal(t, []entity.RatingMessage{{GameID: game1, UserID: user1, Rating: 9}}, producer.published)
+	})
+
+	t.Run("error on unknown game", func(t *testing.T) {
+		loader := New(newFakeStore(game1), &fakeProducer{}, zap.NewNop(), OnConflict(entity.ConflictError))
+
+		_, err := loader.LoadRatings(context.Background(), jsonl(input...))
+		require.ErrorIs(t, err, entity.ErrGameNotFound)
+	})
+
+	t.Run("producer failure", func(t *testing.T) {
+		loader := New(newFakeStore(game1), &fakeProducer{fail: errors.New("broker down")}, zap.NewNop())
+
+		stats, err := loader.LoadRatings(context.Background(), jsonl(input...))
+		require.ErrorContains(t, err, "broker down")
+		require.Zero(t, stats.Written)
+	})
+}
+
+func TestLoad_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	store := newFakeStore()
+	_, err := New(store, nil, zap.NewNop()).LoadGames(ctx, jsonl(gameLine(game1, "a")))
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, store.batches)
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeFilter struct {
+	verdict entity.FilterVerdict
+	err     error
+}
+
+func (f *fakeFilter) Check(ctx context.Context, c entity.CommentDraft) (entity.FilterVerdict, error) {
+	return f.verdict, f.err
+}
+
+// statusRepo запоминает статус, с которым сохранили комментарий
+type statusRepo struct {
+	mockRepo
+	statuses []string
+}
+
+func (s *statusRepo) AddComment(ctx context.Context, gameID, userID, text, status string) (string, error) {
+	s.statuses = append(s.statuses, status)
+	return "comment-1", nil
+}
+
+func TestAddComment_FilterRejects(t *testing.T) {
+	repo := &statusRepo{}
+	rejection := &entity.CommentRejection{Rule: "link_limit", Code: "too_many_links", Message: "too many links"}
+	uc := New(nil, repo, zap.NewNop(), nil, nil, WithCommentFilter(&fakeFilter{err: rejection}))
+
+	_, err := uc.AddComment(context.Background(), "game-1", "user-1", "spam")
+	require.ErrorIs(t, err, entity.ErrCommentRejected)
+	require.Same(t, rejection, err)
+	require.Empty(t, repo.statuses)
+}
+
+func TestAddComment_FilterSendsToModeration(t *testing.T) {
+	repo := &statusRepo{}
+	events := &fakeEventPublisher{}
+	feed := &fakeCommentFeed{}
+	uc := New(nil, repo, zap.NewNop(), nil, nil,
+		WithCommentFilter(&fakeFilter{verdict: entity.FilterVerdict{Moderate: true, Reasons: []string{"banned_words"}}}),
+		WithEvents(events), WithCommentFeed(feed, nil))
+
+	c, err := uc.AddComment(context.Background(), "game-1", "user-1", "hmm")
+	require.NoError(t, err)
+	require.Equal(t, entity.CommentPending, c.Status)
+	require.Equal(t, []string{entity.CommentPending}, repo.statuses)
+
+	// до одобрения комментарий никому не рассылается
+	require.Empty(t, events.events)
+	require.Empty(t, feed.published)
+}
+
+func TestApprove_PublishesCommentAdded(t *testing.T) {
+	modRepo := &fakeModerationRepo{status: map[string]string{moderatedID: entity.CommentPending}}
+	events := &fakeEventPublisher{}
+	uc := New(nil, &mockRepo{}, zap.NewNop(), nil, nil,
+		WithModeration(modRepo), WithTransactor(&fakeTx{}), WithEvents(events))
+
+	_, err := uc.ModerateComment(moderatorCtx("alice"), moderatedID, entity.ModerationApprove, "")
+	require.NoError(t, err)
+	require.Len(t, events.events, 1)
+	require.Equal(t, entity.EventCommentAdded, events.events[0].Type)
+	require.Equal(t, []bool{true}, events.inTx)
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/RozmiDan/gameReviewHub/pkg/tracing"
+)
+
+// inTx выполняет запись и публикацию события атомарно, если есть Transactor
+func (u *Usecase) inTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if u.tx == nil {
+		return fn(ctx)
+	}
+	return u.tx.WithinTx(ctx, fn)
+}
+
+// publishEvent собирает событие и отдаёт его в outbox и в очередь вебхуков;
+// если ни то ни другое не подключено, ничего не делает
+func (u *Usecase) publishEvent(ctx context.Context, eventType, aggregate, aggregateID, gameID string, data any) error {
+	if u.events == nil && u.webhooks == nil {
+		return nil
+	}
+	evt, err := entity.NewDomainEvent(eventType, aggregate, aggregateID, gameID, data)
+	if err != nil {
+		return err
+	}
+	evt.RequestID, _ = ctx.Value(entity.RequestIDKey{}).(string)
+	evt.TraceParent = tracing.TraceParent(ctx)
+
+	if u.events != nil {
+		if err := u.events.PublishEvent(ctx, evt); err != nil {
+			return err
+		}
+	}
+	if u.webhooks != nil {
+		return u.webhooks.EnqueueWebhookDeliveries(ctx, evt)
+	}
+	return nil
+}
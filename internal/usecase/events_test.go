@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type txMarker struct{}
+
+// fakeTx помечает ctx, чтобы проверить, что публикация идёт внутри транзакции
+type fakeTx struct {
+	calls int
+}
+
+func (f *fakeTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.calls++
+	return fn(context.WithValue(ctx, txMarker{}, true))
+}
+
+type fakeEventPublisher struct {
+	events []entity.DomainEvent
+	inTx   []bool
+	err    error
+}
+
+func (f *fakeEventPublisher) PublishEvent(ctx context.Context, evt entity.DomainEvent) error {
+	inTx, _ := ctx.Value(txMarker{}).(bool)
+	f.inTx = append(f.inTx, inTx)
+	f.events = append(f.events, evt)
+	return f.err
+}
+
+func TestCreateGameTopic_PublishesEvent(t *testing.T) {
+	tx := &fakeTx{}
+	pub := &fakeEventPublisher{}
+	uc := New(nil, &mockGameRepo{returnID: "game-1"}, zap.NewNop(), nil, nil, WithTransactor(tx), WithEvents(pub))
+
+	ctx := context.WithValue(context.Background(), entity.RequestIDKey{}, "req-1")
+	id, err := uc.CreateGameTopic(ctx, &entity.Game{Name: "Test Game", Genre: "RPG"})
+	require.NoError(t, err)
+	require.Equal(t, "game-1", id)
+
+	require.Equal(t, 1, tx.calls)
+	require.Len(t, pub.events, 1)
+	require.Equal(t, []bool{true}, pub.inTx)
+
+	evt := pub.events[0]
+	require.Equal(t, entity.EventGameCreated, evt.Type)
+	require.Equal(t, entity.AggregateGame, evt.Aggregate)
+	require.Equal(t, "game-1", evt.GameID)
+	require.Equal(t, "req-1", evt.RequestID)
+	require.NotEmpty(t, evt.ID)
+
+	var data entity.GameEventData
+	require.NoError(t, json.Unmarshal(evt.Data, &data))
+	require.Equal(t, "Test Game", data.Name)
+}
+
+func TestAddComment_PublishesEvent(t *testing.T) {
+	pub := &fakeEventPublisher{}
+	uc := New(nil, &mockRepo{returnID: "comment-1"}, zap.NewNop(), nil, nil, WithTransactor(&fakeTx{}), WithEvents(pub))
+
+	_, err := uc.AddComment(context.Background(), "game-1", "user-1", "nice")
+	require.NoError(t, err)
+
+	require.Len(t, pub.events, 1)
+	require.Equal(t, entity.EventCommentAdded, pub.events[0].Type)
+	require.Equal(t, entity.AggregateComment, pub.events[0].Aggregate)
+	require.Equal(t, "comment-1", pub.events[0].AggregateID)
+	require.Equal(t, "game-1", pub.events[0].GameID)
+}
+
+func TestEvents_FailureFailsWrite(t *testing.T) {
+	pub := &fakeEventPublisher{err: errors.New("outbox insert failed")}
+	uc := New(nil, &mockGameRepo{returnID: "game-1"}, zap.NewNop(), nil, nil, WithTransactor(&fakeTx{}), WithEvents(pub))
+
+	// транзакция откатится, клиент получает внутреннюю ошибку
+	_, err := uc.CreateGameTopic(context.Background(), &entity.Game{Name: "Test Game"})
+	require.ErrorIs(t, err, entity.ErrInternal)
+}
+
+func TestEvents_NotPublishedOnRepoError(t *testing.T) {
+	pub := &fakeEventPublisher{}
+	uc := New(nil, &mockGameRepo{returnErr: entity.ErrGameAlreadyExists}, zap.NewNop(), nil, nil, WithTransactor(&fakeTx{}), WithEvents(pub))
+
+	_, err := uc.CreateGameTopic(context.Background(), &entity.Game{Name: "Test Game"})
+	require.ErrorIs(t, err, entity.ErrGameAlreadyExists)
+	require.Empty(t, pub.events)
+}
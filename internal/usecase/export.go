@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"go.uber.org/zap"
+)
+
+// строк в одном FETCH курсора и в одном запросе рейтингов
+const exportBatchSize = 500
+
+// ExportRepository — выгрузки серверным курсором; рейтинги берутся только из снапшотов (game_rating_snapshot),
+// а не из rating service: запрос на каждую игру каталога положил бы его на первой же выгрузке.
+// Снапшоты пишет консьюмер rating-updates (kafka.topic_rating_updates), и только для игр, оценённых
+// после его включения: без снапшота рейтинг выгружается как неизвестный (null), а не нулевой.
+type ExportRepository interface {
+	ExportGames(ctx context.Context, limit, offset int32, batchSize int, fn func([]entity.Game) error) error
+	ExportComments(ctx context.Context, gameID string, batchSize int, fn func([]entity.Comment) error) error
+	GetRatingSnapshots(ctx context.Context, ids []string) ([]entity.GameRating, error)
+}
+
+// ExportGames отдаёт каталог батчами в fn, к каждому батчу добавлены рейтинги из снапшотов.
+// limit <= 0 — весь каталог. Ошибка fn (клиент ушёл) прерывает выгрузку.
+func (u *Usecase) ExportGames(ctx context.Context, limit, offset int32, fn func([]entity.ExportedGame) error) error {
+	// 1) забираем request_id
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+
+	// 2) оборачиваем логгер
+	logger := u.logger.With(zap.String("func", "ExportGames"))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	if u.export == nil {
+		return entity.ErrExportDisabled
+	}
+
+	// 3) батч из курсора → снапшоты одним запросом → fn
+	var unknown int
+	err := u.export.ExportGames(ctx, limit, offset, exportBatchSize, func(games []entity.Game) error {
+		ids := make([]string, len(games))
+		for i, g := range games {
+			ids[i] = g.ID
+		}
+		ratings, err := u.export.GetRatingSnapshots(ctx, ids)
+		if err != nil {
+			logger.Error("failed to fetch rating snapshots", zap.Error(err))
+			return err
+		}
+
+		byGame := make(map[string]entity.GameRating, len(ratings))
+		for _, r := range ratings {
+			byGame[r.GameID] = r
+		}
+		rows := make([]entity.ExportedGame, len(games))
+		for i, g := range games {
+			rows[i].Game = g
+			// игра без снапшота — рейтинг неизвестен (Rating == nil)
+			if r, ok := byGame[g.ID]; ok {
+				rows[i].Game.Rating = r
+				rows[i].Rating = &rows[i].Game.Rating
+			} else {
+				unknown++
+			}
+		}
+		return fn(rows)
+	})
+
+	// 4) много неизвестных рейтингов — скорее всего, консьюмер rating-updates выключен или отстаёт
+	if unknown > 0 {
+		logger.Warn("games exported without rating snapshot", zap.Int("count", unknown))
+	}
+	return err
+}
+
+// ExportComments отдаёт видимые комментарии игры батчами в fn
+func (u *Usecase) ExportComments(ctx context.Context, gameID string, fn func([]entity.Comment) error) error {
+	// 1) забираем request_id
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+
+	// 2) оборачиваем логгер
+	logger := u.logger.With(zap.String("func", "ExportComments"), zap.String("game_id", gameID))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	if u.export == nil {
+		return entity.ErrExportDisabled
+	}
+
+	// 3) игра должна существовать: пустой файл и 404 — разные ответы
+	if _, err := u.gameHubRepo.GetGameTopic(ctx, gameID); err != nil {
+		if errors.Is(err, entity.ErrGameNotFound) {
+			logger.Info("game not found")
+			return entity.ErrGameNotFound
+		}
+		logger.Error("failed to check game", zap.Error(err))
+		return entity.ErrInternal
+	}
+
+	return u.export.ExportComments(ctx, gameID, exportBatchSize, fn)
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeExportRepo struct {
+	games    [][]entity.Game
+	comments []entity.Comment
+	ratings  []entity.GameRating
+
+	batchSize int
+}
+
+func (f *fakeExportRepo) ExportGames(ctx context.Context, limit, offset int32, batchSize int, fn func([]entity.Game) error) error {
+	f.batchSize = batchSize
+	for _, batch := range f.games {
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeExportRepo) ExportComments(ctx context.Context, gameID string, batchSize int, fn func([]entity.Comment) error) error {
+	return fn(f.comments)
+}
+
+func (f *fakeExportRepo) GetRatingSnapshots(ctx context.Context, ids []string) ([]entity.GameRating, error) {
+	var out []entity.GameRating
+	for _, r := range f.ratings {
+		for _, id := range ids {
+			if r.GameID == id {
+				out = append(out, r)
+			}
+		}
+	}
+	return out, nil
+}
+
+func exportAll(t *testing.T, uc *Usecase) []entity.ExportedGame {
+	t.Helper()
+	var got []entity.ExportedGame
+	err := uc.ExportGames(context.Background(), 0, 0, func(batch []entity.ExportedGame) error {
+		got = append(got, batch...)
+		return nil
+	})
+	require.NoError(t, err)
+	return got
+}
+
+func TestUsecase_ExportGames_MergesRatings(t *testing.T) {
+	repo := &fakeExportRepo{
+		games:   [][]entity.Game{{{ID: "g1"}, {ID: "g2"}}, {{ID: "g3"}}},
+		ratings: []entity.GameRating{{GameID: "g1", AverageRating: 7, RatingsCount: 2}, {GameID: "g3", AverageRating: 9, RatingsCount: 1}},
+	}
+	// rating client nil: выгрузка в rating service не ходит
+	uc := New(nil, &fakeTopicRepo{}, zap.NewNop(), nil, nil, WithExport(repo))
+
+	got := exportAll(t, uc)
+	require.Equal(t, exportBatchSize, repo.batchSize)
+	require.Len(t, got, 3)
+	require.Equal(t, 7.0, got[0].Rating.AverageRating)
+	require.Equal(t, 7.0, got[0].Game.Rating.AverageRating)
+	// без снапшота — рейтинг неизвестен, а не нулевой
+	require.Nil(t, got[1].Rating)
+	require.EqualValues(t, 1, got[2].Rating.RatingsCount)
+}
+
+func TestUsecase_ExportComments(t *testing.T) {
+	repo := &fakeExportRepo{comments: []entity.Comment{{ID: "c1"}}}
+	noop := func([]entity.Comment) error { return nil }
+
+	// выгрузки не подключены
+	uc := New(nil, &fakeTopicRepo{game: &entity.Game{}}, zap.NewNop(), nil, nil)
+	require.ErrorIs(t, uc.ExportComments(context.Background(), "g1", noop), entity.ErrExportDisabled)
+
+	uc = New(nil, &fakeTopicRepo{err: entity.ErrGameNotFound}, zap.NewNop(), nil, nil, WithExport(repo))
+	require.ErrorIs(t, uc.ExportComments(context.Background(), "g1", noop), entity.ErrGameNotFound)
+
+	uc = New(nil, &fakeTopicRepo{game: &entity.Game{}}, zap.NewNop(), nil, nil, WithExport(repo))
+	var got []entity.Comment
+	err := uc.ExportComments(context.Background(), "g1", func(batch []entity.Comment) error {
+		got = append(got, batch...)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+}
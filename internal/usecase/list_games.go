@@ -7,9 +7,21 @@ import (
 	"fmt"
 
 	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	prom_metrics "github.com/RozmiDan/gameReviewHub/pkg/metrics"
 	"go.uber.org/zap"
 )
 
+// ключи кэша главной страницы: listgames:<поколение>:<limit>:<offset>. Обновление рейтингов
+// увеличивает поколение (INCR listgames:gen) — старые ключи больше не читаются и уходят по TTL,
+// без SCAN по всему Redis
+const (
+	listGamesCachePrefix = "listgames:"
+	listGamesGenKey      = listGamesCachePrefix + "gen"
+)
+
+// имя кэша в метрике gamehub_cache_requests_total
+const listGamesCacheName = "games_list"
+
 // ListGames получает топ-N игр с учётом пагинации
 func (u *Usecase) GetListGames(ctx context.Context, limit, offset int32) ([]entity.GameInList, error) {
 	//(cache(?) → RPC → БД → merge → cache(?))
@@ -20,21 +32,42 @@ func (u *Usecase) GetListGames(ctx context.Context, limit, offset int32) ([]enti
 	}
 	logger = logger.With(zap.String("func", "GetListGames"))
 
-	// Cache
-	cacheKey := fmt.Sprintf("listgames:%d:%d", limit, offset)
-
-	if cachedJSON, err := u.redis.Get(ctx, cacheKey); err == nil {
-		var cachedData []entity.GameInList
-		if errUnm := json.Unmarshal([]byte(cachedJSON), &cachedData); errUnm == nil {
-			logger.Info("GetListGames: cache hit", zap.String("key", cacheKey))
-			return cachedData, nil
+	// Cache: ключ от текущего поколения; поколение читаем до RPC — если рейтинги обновятся,
+	// пока собираем ответ, он запишется под старым поколением и читаться не будет
+	var cacheKey string
+	if u.redis != nil {
+		gen, err := u.redis.Get(ctx, listGamesGenKey)
+		switch {
+		case errors.Is(err, entity.ErrCacheMiss):
+			cacheKey = fmt.Sprintf("%s0:%d:%d", listGamesCachePrefix, limit, offset)
+		case err != nil:
+			logger.Warn("GetListGames: cant read cache generation, cache skipped", zap.Error(err))
+			countCache(listGamesCacheName, cacheError)
+		default:
+			cacheKey = fmt.Sprintf("%s%s:%d:%d", listGamesCachePrefix, gen, limit, offset)
 		}
-		logger.Error("cant unmarshall data from redis")
-	} else {
-		if !errors.Is(err, entity.ErrCacheMiss) {
-			logger.Warn("GetListGames: unexpected redis GET error", zap.String("key", cacheKey), zap.Error(err))
+	}
+
+	if cacheKey != "" {
+		if cachedJSON, err := u.redis.Get(ctx, cacheKey); err == nil {
+			var cachedData []entity.GameInList
+			if errUnm := json.Unmarshal([]byte(cachedJSON), &cachedData); errUnm == nil {
+				logger.Info("GetListGames: cache hit", zap.String("key", cacheKey))
+				countCache(listGamesCacheName, cacheHit)
+				return cachedData, nil
+			}
+			logger.Error("cant unmarshall data from redis")
+			// битая запись всё равно перезапишется — для hit ratio это промах
+			countCache(listGamesCacheName, cacheMiss)
+		} else {
+			if !errors.Is(err, entity.ErrCacheMiss) {
+				logger.Warn("GetListGames: unexpected redis GET error", zap.String("key", cacheKey), zap.Error(err))
+				countCache(listGamesCacheName, cacheError)
+			} else {
+				countCache(listGamesCacheName, cacheMiss)
+			}
+			u.logger.Info("cache miss", zap.String("key", cacheKey))
 		}
-		u.logger.Info("cache miss", zap.String("key", cacheKey))
 	}
 
 	// RPC
@@ -75,16 +108,31 @@ func (u *Usecase) GetListGames(ctx context.Context, limit, offset int32) ([]enti
 	}
 
 	// Push data to cache
-	if b, err := json.Marshal(out); err == nil {
-		if errSet := u.redis.Set(ctx, cacheKey, string(b)); errSet != nil {
-			logger.Error("failed to set cache", zap.Error(errSet), zap.String("key", cacheKey))
+	if cacheKey != "" {
+		if b, err := json.Marshal(out); err == nil {
+			if errSet := u.redis.Set(ctx, cacheKey, string(b)); errSet != nil {
+				logger.Error("failed to set cache", zap.Error(errSet), zap.String("key", cacheKey))
+			} else {
+				logger.Info("cache set", zap.String("key", cacheKey))
+			}
 		} else {
-			logger.Info("cache set", zap.String("key", cacheKey))
+			logger.Error("Cant marshall data")
 		}
-	} else {
-		logger.Error("Cant marshall data")
 	}
 
 	logger.Info("completed", zap.Int("returned", len(out)))
 	return out, nil
 }
+
+const (
+	cacheHit   = "hit"
+	cacheMiss  = "miss"
+	cacheError = "error"
+)
+
+// countCache считает обращение к кэшу для hit ratio; до prom_metrics.Init (в тестах) — no-op
+func countCache(cache, result string) {
+	if prom_metrics.CacheRequests != nil {
+		prom_metrics.CacheRequests.WithLabelValues(cache, result).Inc()
+	}
+}
@@ -36,10 +36,10 @@ func (f *fakeGameRepo) GetGameTopic(ctx context.Context, gameID string) (*entity
 func (f *fakeGameRepo) GetGameInfo(ctx context.Context, ids []string) ([]entity.GameInList, error) {
 	return f.metas, f.err
 }
-func (f *fakeGameRepo) GetCommentsGame(ctx context.Context, gameID string, limit, offset int32) ([]entity.Comment, error) {
+func (f *fakeGameRepo) GetCommentsGame(ctx context.Context, gameID string, limit, offset int32, allStatuses bool) ([]entity.Comment, error) {
 	return nil, nil
 }
-func (f *fakeGameRepo) AddComment(ctx context.Context, gameID, userID, text string) (string, error) {
+func (f *fakeGameRepo) AddComment(ctx context.Context, gameID, userID, text, status string) (string, error) {
 	return "", nil
 }
 func (f *fakeGameRepo) AddGameTopic(ctx context.Context, gameInfo *entity.Game) (string, error) {
@@ -121,3 +121,39 @@ func TestGetListGames(t *testing.T) {
 		})
 	}
 }
+
+func TestGetListGames_CacheGeneration(t *testing.T) {
+	ctx := context.Background()
+	rc := &fakeRatingClient{topGames: []entity.GameRating{{GameID: "g1", AverageRating: 5.5}}}
+	cache := newFakeCache()
+	uc := New(rc, &fakeGameRepo{metas: []entity.GameInList{{ID: "g1", Name: "One"}}}, zap.NewNop(), nil, cache,
+		WithRatingSnapshots(&fakeSnapshotRepo{applied: true}))
+
+	out, err := uc.GetListGames(ctx, 10, 0)
+	assert.NoError(t, err)
+	assert.Contains(t, cache.values, "listgames:0:10:0")
+
+	// из кэша, даже если рейтинг уже поменялся
+	rc.topGames = []entity.GameRating{{GameID: "g1", AverageRating: 9}}
+	cached, err := uc.GetListGames(ctx, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, out, cached)
+
+	// обновление рейтинга — новое поколение, старый ключ больше не читается
+	assert.NoError(t, uc.ApplyRatingUpdate(ctx, entity.RatingUpdate{GameID: "g1", AverageRating: 9}))
+	fresh, err := uc.GetListGames(ctx, 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 9.0, fresh[0].Rating)
+	assert.Contains(t, cache.values, "listgames:1:10:0")
+}
+
+func TestGetListGames_CacheDown(t *testing.T) {
+	cache := newFakeCache()
+	cache.err = errors.New("redis down")
+	uc := New(&fakeRatingClient{topGames: []entity.GameRating{{GameID: "g1", AverageRating: 5.5}}},
+		&fakeGameRepo{metas: []entity.GameInList{{ID: "g1", Name: "One"}}}, zap.NewNop(), nil, cache)
+
+	out, err := uc.GetListGames(context.Background(), 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, out, 1)
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"go.uber.org/zap"
+)
+
+// moderationTransition — из каких статусов допустимо действие и во что оно переводит
+type moderationTransition struct {
+	from         []string
+	to           string
+	reasonNeeded bool
+}
+
+var moderationTransitions = map[string]moderationTransition{
+	entity.ModerationApprove: {from: []string{entity.CommentPending}, to: entity.CommentVisible},
+	entity.ModerationHide:    {from: []string{entity.CommentVisible, entity.CommentPending}, to: entity.CommentHidden, reasonNeeded: true},
+	entity.ModerationRestore: {from: []string{entity.CommentHidden}, to: entity.CommentVisible},
+}
+
+// ModerationRepository — очередь модерации, смена статуса и журнал
+type ModerationRepository interface {
+	ListCommentsByStatus(ctx context.Context, status string, limit, offset int32) ([]entity.Comment, error)
+	SetCommentStatus(ctx context.Context, commentID string, from []string, to, moderator, reason string) (*entity.Comment, string, error)
+	AddModerationAudit(ctx context.Context, a entity.ModerationAudit) error
+	ListModerationAudit(ctx context.Context, commentID string) ([]entity.ModerationAudit, error)
+}
+
+// ListPendingComments — комментарии, ожидающие проверки, старые первыми
+func (u *Usecase) ListPendingComments(ctx context.Context, limit, offset int32) ([]entity.Comment, error) {
+	if u.moderation == nil {
+		return nil, entity.ErrInternal
+	}
+	return u.moderation.ListCommentsByStatus(ctx, entity.CommentPending, limit, offset)
+}
+
+// ModerateComment применяет действие модератора (approve | hide | restore).
+// Смена статуса, запись в журнал и событие для outbox/вебхуков — в одной транзакции.
+func (u *Usecase) ModerateComment(ctx context.Context, commentID, action, reason string) (*entity.Comment, error) {
+	// 1) забираем request_id
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+
+	// 2) оборачиваем логгер
+	logger := u.logger.With(zap.String("func", "ModerateComment"), zap.String("comment_id", commentID))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	if u.moderation == nil {
+		return nil, entity.ErrInternal
+	}
+
+	// 3) кто модерирует и что делает
+	moderator, _ := ctx.Value(entity.ModeratorKey{}).(string)
+	if moderator == "" {
+		return nil, entity.ErrModeratorRequired
+	}
+	tr, ok := moderationTransitions[action]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown action %q", entity.ErrInvalidModeration, action)
+	}
+	reason = strings.TrimSpace(reason)
+	if tr.reasonNeeded && reason == "" {
+		return nil, entity.ErrModerationReason
+	}
+
+	// 4) смена статуса + журнал
+	var comment *entity.Comment
+	err := u.inTx(ctx, func(ctx context.Context) error {
+		c, prev, err := u.moderation.SetCommentStatus(ctx, commentID, tr.from, tr.to, moderator, reason)
+		if err != nil {
+			if errors.Is(err, entity.ErrInvalidModeration) {
+				return fmt.Errorf("%w: cannot %s a %s comment", entity.ErrInvalidModeration, action, prev)
+			}
+			return err
+		}
+		comment = c
+		if err := u.publishVisibilityChange(ctx, *c, prev, tr.to); err != nil {
+			return err
+		}
+		return u.moderation.AddModerationAudit(ctx, entity.ModerationAudit{
+			CommentID:  c.ID,
+			GameID:     c.GameID,
+			Action:     action,
+			FromStatus: prev,
+			ToStatus:   tr.to,
+			Moderator:  moderator,
+			Reason:     reason,
+		})
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrCommentNotFound), errors.Is(err, entity.ErrInvalidModeration):
+			logger.Info("moderation rejected", zap.String("action", action), zap.Error(err))
+			return nil, err
+		default:
+			logger.Error("moderation failed", zap.String("action", action), zap.Error(err))
+			return nil, entity.ErrInternal
+		}
+	}
+
+	logger.Info("comment moderated", zap.String("action", action), zap.String("moderator", moderator))
+
+	// после коммита — в живую ленту, как новый комментарий
+	if tr.to == entity.CommentVisible {
+		u.publishToFeed(ctx, logger, *comment)
+	}
+
+	return comment, nil
+}
+
+// publishVisibilityChange — событие для потребителей outbox и вебхуков, когда комментарий
+// становится публичным (CommentAdded: одобрен или восстановлен) или перестаёт им быть (CommentDeleted).
+// Переходы между непубличными статусами (pending → hidden) наружу не видны.
+func (u *Usecase) publishVisibilityChange(ctx context.Context, c entity.Comment, from, to string) error {
+	wasVisible, isVisible := from == entity.CommentVisible, to == entity.CommentVisible
+	switch {
+	case !wasVisible && isVisible:
+		return u.publishCommentAdded(ctx, c)
+	case wasVisible && !isVisible:
+		return u.publishEvent(ctx, entity.EventCommentDeleted, entity.AggregateComment, c.ID, c.GameID, entity.CommentEventData{
+			ID:     c.ID,
+			GameID: c.GameID,
+			UserID: c.UserID,
+		})
+	}
+	return nil
+}
+
+// GetModerationAudit — журнал действий по комментарию
+func (u *Usecase) GetModerationAudit(ctx context.Context, commentID string) ([]entity.ModerationAudit, error) {
+	if u.moderation == nil {
+		return nil, entity.ErrInternal
+	}
+	return u.moderation.ListModerationAudit(ctx, commentID)
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const moderatedID = "c-1"
+
+// fakeModerationRepo держит статусы комментариев в памяти
+type fakeModerationRepo struct {
+	status   map[string]string
+	audit    []entity.ModerationAudit
+	auditTx  []bool
+	auditErr error
+}
+
+func (f *fakeModerationRepo) ListCommentsByStatus(ctx context.Context, status string, limit, offset int32) ([]entity.Comment, error) {
+	var out []entity.Comment
+	for id, s := range f.status {
+		if s == status {
+			out = append(out, entity.Comment{ID: id, Status: s})
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeModerationRepo) SetCommentStatus(ctx context.Context, commentID string, from []string, to,
+	moderator, reason string) (*entity.Comment, string, error) {
+
+	prev, ok := f.status[commentID]
+	if !ok {
+		return nil, "", entity.ErrCommentNotFound
+	}
+	if !slices.Contains(from, prev) {
+		return nil, prev, entity.ErrInvalidModeration
+	}
+	f.status[commentID] = to
+	return &entity.Comment{ID: commentID, GameID: "game-1", Text: "text", Status: to,
+		ModeratedBy: moderator, ModerationReason: reason}, prev, nil
+}
+
+func (f *fakeModerationRepo) AddModerationAudit(ctx context.Context, a entity.ModerationAudit) error {
+	inTx, _ := ctx.Value(txMarker{}).(bool)
+	f.auditTx = append(f.auditTx, inTx)
+	if f.auditErr != nil {
+		return f.auditErr
+	}
+	f.audit = append(f.audit, a)
+	return nil
+}
+
+func (f *fakeModerationRepo) ListModerationAudit(ctx context.Context, commentID string) ([]entity.ModerationAudit, error) {
+	return f.audit, nil
+}
+
+func moderatorCtx(name string) context.Context {
+	return context.WithValue(context.Background(), entity.ModeratorKey{}, name)
+}
+
+func newModerationUsecase(status string) (*Usecase, *fakeModerationRepo, *fakeCommentFeed) {
+	repo := &fakeModerationRepo{status: map[string]string{moderatedID: status}}
+	feed := &fakeCommentFeed{}
+	uc := New(nil, &mockRepo{}, zap.NewNop(), nil, nil,
+		WithModeration(repo), WithTransactor(&fakeTx{}), WithCommentFeed(feed, nil))
+	return uc, repo, feed
+}
+
+func TestModerateComment_Transitions(t *testing.T) {
+	cases := []struct {
+		name, from, action, reason, to string
+	}{
+		{"approve pending", entity.CommentPending, entity.ModerationApprove, "", entity.CommentVisible},
+		{"hide visible", entity.CommentVisible, entity.ModerationHide, "spam", entity.CommentHidden},
+		{"hide pending", entity.CommentPending, entity.ModerationHide, "spam", entity.CommentHidden},
+		{"restore hidden", entity.CommentHidden, entity.ModerationRestore, "", entity.CommentVisible},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc, repo, _ := newModerationUsecase(tc.from)
+
+			c, err := uc.ModerateComment(moderatorCtx("alice"), moderatedID, tc.action, tc.reason)
+			require.NoError(t, err)
+			require.Equal(t, tc.to, c.Status)
+			require.Equal(t, tc.to, repo.status[moderatedID])
+
+			require.Len(t, repo.audit, 1)
+			require.Equal(t, entity.ModerationAudit{
+				CommentID:  moderatedID,
+				GameID:     "game-1",
+				Action:     tc.action,
+				FromStatus: tc.from,
+				ToStatus:   tc.to,
+				Moderator:  "alice",
+				Reason:     tc.reason,
+			}, repo.audit[0])
+			require.Equal(t, []bool{true}, repo.auditTx)
+		})
+	}
+}
+
+func TestModerateComment_Rejected(t *testing.T) {
+	uc, repo, _ := newModerationUsecase(entity.CommentVisible)
+
+	_, err := uc.ModerateComment(context.Background(), moderatedID, entity.ModerationHide, "spam")
+	require.ErrorIs(t, err, entity.ErrModeratorRequired)
+
+	_, err = uc.ModerateComment(moderatorCtx("alice"), moderatedID, "delete", "")
+	require.ErrorIs(t, err, entity.ErrInvalidModeration)
+
+	_, err = uc.ModerateComment(moderatorCtx("alice"), moderatedID, entity.ModerationHide, "  ")
+	require.ErrorIs(t, err, entity.ErrModerationReason)
+
+	// visible нельзя одобрить ещё раз
+	_, err = uc.ModerateComment(moderatorCtx("alice"), moderatedID, entity.ModerationApprove, "")
+	require.ErrorIs(t, err, entity.ErrInvalidModeration)
+	require.Contains(t, err.Error(), "cannot approve a visible comment")
+
+	_, err = uc.ModerateComment(moderatorCtx("alice"), "missing", entity.ModerationHide, "spam")
+	require.ErrorIs(t, err, entity.ErrCommentNotFound)
+
+	require.Empty(t, repo.audit)
+	require.Equal(t, entity.CommentVisible, repo.status[moderatedID])
+}
+
+func TestModerateComment_AuditFailure(t *testing.T) {
+	uc, repo, _ := newModerationUsecase(entity.CommentVisible)
+	repo.auditErr = errors.New("db down")
+
+	_, err := uc.ModerateComment(moderatorCtx("alice"), moderatedID, entity.ModerationHide, "spam")
+	require.ErrorIs(t, err, entity.ErrInternal)
+}
+
+func TestModerateComment_ApprovedGoesToLiveFeed(t *testing.T) {
+	uc, _, feed := newModerationUsecase(entity.CommentPending)
+
+	_, err := uc.ModerateComment(moderatorCtx("alice"), moderatedID, entity.ModerationApprove, "")
+	require.NoError(t, err)
+	require.Len(t, feed.published, 1)
+	require.Equal(t, moderatedID, feed.published[0].ID)
+	// поля модерации в публичную ленту не попадают
+	require.Empty(t, feed.published[0].ModeratedBy)
+
+	uc, _, feed = newModerationUsecase(entity.CommentVisible)
+	_, err = uc.ModerateComment(moderatorCtx("alice"), moderatedID, entity.ModerationHide, "spam")
+	require.NoError(t, err)
+	require.Empty(t, feed.published)
+}
+
+func TestModerateComment_PublishesVisibilityEvents(t *testing.T) {
+	cases := []struct {
+		name, from, action, reason, event string
+	}{
+		{"approve pending", entity.CommentPending, entity.ModerationApprove, "", entity.EventCommentAdded},
+		{"hide visible", entity.CommentVisible, entity.ModerationHide, "spam", entity.EventCommentDeleted},
+		{"restore hidden", entity.CommentHidden, entity.ModerationRestore, "", entity.EventCommentAdded},
+		// pending публично не виден — скрывать наружу нечего
+		{"hide pending", entity.CommentPending, entity.ModerationHide, "spam", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc, _, _ := newModerationUsecase(tc.from)
+			pub := &fakeEventPublisher{}
+			WithEvents(pub)(uc)
+
+			_, err := uc.ModerateComment(moderatorCtx("alice"), moderatedID, tc.action, tc.reason)
+			require.NoError(t, err)
+
+			if tc.event == "" {
+				require.Empty(t, pub.events)
+				return
+			}
+			require.Len(t, pub.events, 1)
+			require.Equal(t, tc.event, pub.events[0].Type)
+			require.Equal(t, moderatedID, pub.events[0].AggregateID)
+			require.Equal(t, "game-1", pub.events[0].GameID)
+			require.Equal(t, []bool{true}, pub.inTx)
+		})
+	}
+}
+
+func TestModerateComment_EventFailureRollsBack(t *testing.T) {
+	uc, _, feed := newModerationUsecase(entity.CommentVisible)
+	WithEvents(&fakeEventPublisher{err: errors.New("outbox down")})(uc)
+
+	_, err := uc.ModerateComment(moderatorCtx("alice"), moderatedID, entity.ModerationHide, "spam")
+	require.ErrorIs(t, err, entity.ErrInternal)
+	require.Empty(t, feed.published)
+}
+
+func TestModeration_NotConfigured(t *testing.T) {
+	uc := New(nil, &mockRepo{}, zap.NewNop(), nil, nil)
+
+	_, err := uc.ListPendingComments(context.Background(), 10, 0)
+	require.ErrorIs(t, err, entity.ErrInternal)
+	_, err = uc.ModerateComment(moderatorCtx("alice"), moderatedID, entity.ModerationHide, "spam")
+	require.ErrorIs(t, err, entity.ErrInternal)
+}
+
+type statusesRepo struct {
+	mockRepo
+	allStatuses []bool
+}
+
+func (s *statusesRepo) GetCommentsGame(ctx context.Context, gameID string, limit, offset int32, allStatuses bool) ([]entity.Comment, error) {
+	s.allStatuses = append(s.allStatuses, allStatuses)
+	return nil, nil
+}
+
+func TestGetListComments_ModeratorSeesAllStatuses(t *testing.T) {
+	repo := &statusesRepo{}
+	uc := New(nil, repo, zap.NewNop(), nil, nil)
+
+	_, err := uc.GetListComments(context.Background(), "game-1", 10, 0)
+	require.NoError(t, err)
+	_, err = uc.GetListComments(moderatorCtx("alice"), "game-1", 10, 0)
+	require.NoError(t, err)
+
+	require.Equal(t, []bool{false, true}, repo.allStatuses)
+}
@@ -0,0 +1,80 @@
+package usecase
+
+// Option — необязательные зависимости usecase
+type Option func(*Usecase)
+
+// WithRatingSnapshots -.
+func WithRatingSnapshots(repo RatingSnapshotRepository) Option {
+	return func(u *Usecase) {
+		u.snapshots = repo
+	}
+}
+
+// WithTransactor — запись и её события выполняются в одной транзакции
+func WithTransactor(tx Transactor) Option {
+	return func(u *Usecase) {
+		u.tx = tx
+	}
+}
+
+// WithEvents включает публикацию доменных событий (outbox → Kafka).
+// Без WithTransactor событие публикуется после записи без общей транзакции.
+func WithEvents(pub EventPublisher) Option {
+	return func(u *Usecase) {
+		u.events = pub
+	}
+}
+
+// WithWebhooks включает подписки на события и постановку доставок в очередь;
+// guard проверяет адрес получателя при создании подписки
+func WithWebhooks(repo WebhookRepository, guard WebhookGuard) Option {
+	return func(u *Usecase) {
+		u.webhooks = repo
+		u.webhookGuard = guard
+	}
+}
+
+// WithCommentFeed включает живую ленту комментариев; history нужен для resume по Last-Event-ID
+func WithCommentFeed(feed CommentFeed, history CommentHistory) Option {
+	return func(u *Usecase) {
+		u.commentFeed = feed
+		u.commentLog = history
+	}
+}
+
+// WithRatingFeed — применённые обновления рейтинга уходят живым подписчикам
+func WithRatingFeed(feed RatingFeed) Option {
+	return func(u *Usecase) {
+		u.ratingFeed = feed
+	}
+}
+
+// WithModeration включает очередь модерации и действия модераторов
+func WithModeration(repo ModerationRepository) Option {
+	return func(u *Usecase) {
+		u.moderation = repo
+	}
+}
+
+// WithCommentFilter включает проверку комментариев перед записью
+func WithCommentFilter(f CommentFilter) Option {
+	return func(u *Usecase) {
+		u.filter = f
+	}
+}
+
+// WithReports включает жалобы на комментарии; на threshold-й жалобе комментарий уходит на модерацию
+// (нужен WithModeration), threshold <= 0 — без автоскрытия
+func WithReports(repo ReportRepository, threshold int) Option {
+	return func(u *Usecase) {
+		u.reports = repo
+		u.reportThreshold = threshold
+	}
+}
+
+// WithExport включает выгрузки каталога и комментариев (CSV, NDJSON)
+func WithExport(repo ExportRepository) Option {
+	return func(u *Usecase) {
+		u.export = repo
+	}
+}
@@ -3,12 +3,16 @@ package usecase
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/RozmiDan/gameReviewHub/internal/entity"
 	"go.uber.org/zap"
 )
 
-func (u *Usecase) AddComment(ctx context.Context, gameID, userID, text string) (string, error) {
+// AddComment проверяет комментарий фильтром и сохраняет его.
+// Отказ фильтра — *entity.CommentRejection; сомнительный комментарий сохраняется со статусом pending
+// и до одобрения модератором не виден в ленте и не рассылается.
+func (u *Usecase) AddComment(ctx context.Context, gameID, userID, text string) (*entity.Comment, error) {
 	// 1) забираем request_id
 	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
 
@@ -18,12 +22,39 @@ func (u *Usecase) AddComment(ctx context.Context, gameID, userID, text string) (
 		logger = logger.With(zap.String("request_id", reqID))
 	}
 
-	commId, err := u.gameHubRepo.AddComment(ctx, gameID, userID, text)
+	// 3) фильтр: отказ или очередь модерации
+	status := entity.CommentVisible
+	if u.filter != nil {
+		verdict, err := u.filter.Check(ctx, entity.CommentDraft{GameID: gameID, UserID: userID, Text: text})
+		if err != nil {
+			logger.Info("comment rejected by filter", zap.String("user_id", userID), zap.Error(err))
+			return nil, err
+		}
+		if verdict.Moderate {
+			status = entity.CommentPending
+			logger.Info("comment sent to moderation", zap.String("user_id", userID),
+				zap.Strings("reasons", verdict.Reasons))
+		}
+	}
+
+	// 4) запись и событие CommentAdded — в одной транзакции; для pending событие будет при одобрении
+	var commId string
+	err := u.inTx(ctx, func(ctx context.Context) error {
+		id, err := u.gameHubRepo.AddComment(ctx, gameID, userID, text, status)
+		if err != nil {
+			return err
+		}
+		commId = id
+		if status != entity.CommentVisible {
+			return nil
+		}
+		return u.publishCommentAdded(ctx, entity.Comment{ID: id, GameID: gameID, UserID: userID, Text: text})
+	})
 	if err != nil {
 		switch {
 		case errors.Is(err, entity.ErrGameNotFound):
 			logger.Info("game not found, cannot add comment", zap.String("game_id", gameID))
-			return "", entity.ErrGameNotFound
+			return nil, entity.ErrGameNotFound
 
 		case errors.Is(err, entity.ErrInsertComment):
 			logger.Error("failed to insert comment into database",
@@ -31,15 +62,57 @@ func (u *Usecase) AddComment(ctx context.Context, gameID, userID, text string) (
 				zap.String("user_id", userID),
 				zap.Error(err),
 			)
-			return "", entity.ErrInsertComment
+			return nil, entity.ErrInsertComment
 
 		default:
 			logger.Error("cannot add comment: unexpected error", zap.Error(err))
-			return "", entity.ErrInternal
+			return nil, entity.ErrInternal
 		}
 	}
 
-	logger.Info("comment added successfully", zap.String("comment_id", commId))
+	logger.Info("comment added successfully", zap.String("comment_id", commId), zap.String("status", status))
+
+	comment := &entity.Comment{
+		ID:        commId,
+		GameID:    gameID,
+		UserID:    userID,
+		Text:      text,
+		CreatedAt: time.Now().UTC(),
+		Status:    status,
+	}
 
-	return commId, nil
+	// живая лента — после коммита; ошибка Redis не отменяет добавленный комментарий,
+	// подписчики доберут его по Last-Event-ID при переподключении
+	if status == entity.CommentVisible {
+		u.publishToFeed(ctx, logger, *comment)
+	}
+
+	return comment, nil
+}
+
+// publishCommentAdded — событие CommentAdded (outbox, вебхуки) для ставшего публичным комментария
+func (u *Usecase) publishCommentAdded(ctx context.Context, c entity.Comment) error {
+	return u.publishEvent(ctx, entity.EventCommentAdded, entity.AggregateComment, c.ID, c.GameID, entity.CommentEventData{
+		ID:     c.ID,
+		GameID: c.GameID,
+		UserID: c.UserID,
+		Text:   c.Text,
+	})
+}
+
+// publishToFeed отдаёт публичный комментарий живой ленте; без полей модерации
+func (u *Usecase) publishToFeed(ctx context.Context, logger *zap.Logger, c entity.Comment) {
+	if u.commentFeed == nil {
+		return
+	}
+	err := u.commentFeed.PublishComment(ctx, entity.Comment{
+		ID:        c.ID,
+		GameID:    c.GameID,
+		UserID:    c.UserID,
+		Text:      c.Text,
+		CreatedAt: c.CreatedAt,
+	})
+	if err != nil {
+		logger.Warn("failed to publish comment to live feed", zap.Error(err))
+	}
 }
@@ -21,10 +21,10 @@ func (m *mockRepo) GetGameTopic(ctx context.Context, gameID string) (*entity.Gam
 func (m *mockRepo) GetGameInfo(ctx context.Context, ids []string) ([]entity.GameInList, error) {
 	panic("not implemented")
 }
-func (m *mockRepo) GetCommentsGame(ctx context.Context, gameID string, limit, offset int32) ([]entity.Comment, error) {
+func (m *mockRepo) GetCommentsGame(ctx context.Context, gameID string, limit, offset int32, allStatuses bool) ([]entity.Comment, error) {
 	panic("not implemented")
 }
-func (m *mockRepo) AddComment(ctx context.Context, gameID, userID, text string) (string, error) {
+func (m *mockRepo) AddComment(ctx context.Context, gameID, userID, text, status string) (string, error) {
 	return m.returnID, m.returnErr
 }
 func (m *mockRepo) AddGameTopic(ctx context.Context, gameInfo *entity.Game) (string, error) {
@@ -86,13 +86,14 @@ func TestAddComment(t *testing.T) {
 				nopCache,
 			)
 
-			gotID, gotErr := uc.AddComment(ctx, gameID, userID, text)
+			got, gotErr := uc.AddComment(ctx, gameID, userID, text)
 			if tc.wantErr != nil {
 				assert.Equal(t, tc.wantErr, gotErr)
-				assert.Empty(t, gotID)
+				assert.Nil(t, got)
 			} else {
 				assert.NoError(t, gotErr)
-				assert.Equal(t, tc.wantID, gotID)
+				assert.Equal(t, tc.wantID, got.ID)
+				assert.Equal(t, entity.CommentVisible, got.Status)
 			}
 		})
 	}
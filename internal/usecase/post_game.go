@@ -18,7 +18,23 @@ func (u *Usecase) CreateGameTopic(ctx context.Context, game *entity.Game) (strin
 		logger = logger.With(zap.String("request_id", reqID))
 	}
 
-	gameId, err := u.gameHubRepo.AddGameTopic(ctx, game)
+	// запись и событие GameCreated — в одной транзакции
+	var gameId string
+	err := u.inTx(ctx, func(ctx context.Context) error {
+		id, err := u.gameHubRepo.AddGameTopic(ctx, game)
+		if err != nil {
+			return err
+		}
+		gameId = id
+		return u.publishEvent(ctx, entity.EventGameCreated, entity.AggregateGame, id, id, entity.GameEventData{
+			ID:          id,
+			Name:        game.Name,
+			Genre:       game.Genre,
+			Creator:     game.Creator,
+			Description: game.Description,
+			ReleaseDate: game.ReleaseDate,
+		})
+	})
 	if err != nil {
 		switch {
 		case errors.Is(err, entity.ErrGameAlreadyExists):
@@ -23,10 +23,10 @@ func (m *mockGameRepo) GetGameTopic(ctx context.Context, gameID string) (*entity
 func (m *mockGameRepo) GetGameInfo(ctx context.Context, ids []string) ([]entity.GameInList, error) {
 	panic("not implemented")
 }
-func (m *mockGameRepo) GetCommentsGame(ctx context.Context, gameID string, limit, offset int32) ([]entity.Comment, error) {
+func (m *mockGameRepo) GetCommentsGame(ctx context.Context, gameID string, limit, offset int32, allStatuses bool) ([]entity.Comment, error) {
 	panic("not implemented")
 }
-func (m *mockGameRepo) AddComment(ctx context.Context, gameID, userID, text string) (string, error) {
+func (m *mockGameRepo) AddComment(ctx context.Context, gameID, userID, text, status string) (string, error) {
 	panic("not implemented")
 }
 func (m *mockGameRepo) AddGameTopic(ctx context.Context, game *entity.Game) (string, error) {
@@ -23,10 +23,10 @@ func (f *fakeRepo) GetGameInfo(ctx context.Context, ids []string) ([]entity.Game
 func (f *fakeRepo) GetGameTopic(context.Context, string) (*entity.Game, error) {
 	panic("not used")
 }
-func (f *fakeRepo) GetCommentsGame(context.Context, string, int32, int32) ([]entity.Comment, error) {
+func (f *fakeRepo) GetCommentsGame(context.Context, string, int32, int32, bool) ([]entity.Comment, error) {
 	panic("not used")
 }
-func (f *fakeRepo) AddComment(context.Context, string, string, string) (string, error) {
+func (f *fakeRepo) AddComment(context.Context, string, string, string, string) (string, error) {
 	panic("not used")
 }
 func (f *fakeRepo) AddGameTopic(context.Context, *entity.Game) (string, error) {
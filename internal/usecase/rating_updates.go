@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"go.uber.org/zap"
+)
+
+// ApplyRatingUpdate обрабатывает событие об изменении агрегата рейтинга:
+// обновляет локальный снапшот и сбрасывает кэш главной страницы.
+// Безопасна для повторной доставки: снапшот не откатывается на старые значения,
+// а лишний сброс кэша стоит только одного промаха. Redis некритичен: сбой сброса кэша
+// не повод стопорить партицию — устаревание ограничено TTL кэша.
+func (u *Usecase) ApplyRatingUpdate(ctx context.Context, upd entity.RatingUpdate) error {
+	// 1) забираем request_id
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+
+	// 2) оборачиваем логгер
+	logger := u.logger.With(zap.String("func", "ApplyRatingUpdate"), zap.String("game_id", upd.GameID))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	// 3) обновляем снапшот
+	fresh := true
+	if u.snapshots != nil {
+		applied, err := u.snapshots.UpsertRatingSnapshot(ctx, upd)
+		switch {
+		case errors.Is(err, entity.ErrGameNotFound):
+			// игры у нас нет — обновлять нечего, событие не повторяем
+			logger.Info("rating update for unknown game, skipping")
+			return nil
+		case err != nil:
+			logger.Error("failed to upsert rating snapshot", zap.Error(err))
+			return err
+		case !applied:
+			fresh = false
+			logger.Debug("stale or duplicate rating update")
+		}
+	}
+
+	// 4) сбрасываем кэш всегда: прошлая попытка могла упасть после записи снапшота
+	if u.redis != nil {
+		gen, err := u.redis.Incr(ctx, listGamesGenKey)
+		if err != nil {
+			logger.Warn("failed to invalidate list cache, stale until TTL", zap.Error(err))
+		} else {
+			logger.Info("rating update applied", zap.Int64("cache_generation", gen))
+		}
+	}
+
+	// 5) живым клиентам — только новое; сбой рассылки не повод перечитывать событие из Kafka
+	if u.ratingFeed != nil && fresh {
+		if err := u.ratingFeed.PublishRatingUpdate(ctx, upd); err != nil {
+			logger.Warn("failed to publish rating update to live feed", zap.Error(err))
+		}
+	}
+
+	return nil
+}
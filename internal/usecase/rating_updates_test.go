@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeSnapshotRepo struct {
+	applied bool
+	err     error
+	calls   int
+}
+
+func (f *fakeSnapshotRepo) UpsertRatingSnapshot(ctx context.Context, upd entity.RatingUpdate) (bool, error) {
+	f.calls++
+	return f.applied, f.err
+}
+
+// fakeCache — Redis в памяти; err — сбой любой операции
+type fakeCache struct {
+	values map[string]string
+	incrs  []string
+	err    error
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{values: map[string]string{}}
+}
+
+func (f *fakeCache) Get(ctx context.Context, key string) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	v, ok := f.values[key]
+	if !ok {
+		return "", entity.ErrCacheMiss
+	}
+	return v, nil
+}
+func (f *fakeCache) Set(ctx context.Context, key, value string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.values[key] = value
+	return nil
+}
+func (f *fakeCache) Incr(ctx context.Context, key string) (int64, error) {
+	f.incrs = append(f.incrs, key)
+	if f.err != nil {
+		return 0, f.err
+	}
+	n, _ := strconv.ParseInt(f.values[key], 10, 64)
+	n++
+	f.values[key] = strconv.FormatInt(n, 10)
+	return n, nil
+}
+
+func TestUsecase_ApplyRatingUpdate(t *testing.T) {
+	upd := entity.RatingUpdate{
+		GameID:        "game-1",
+		AverageRating: 7.5,
+		RatingsCount:  4,
+		UpdatedAt:     time.Now(),
+	}
+
+	tests := []struct {
+		name           string
+		applied        bool
+		repoErr        error
+		cacheErr       error
+		wantErr        bool
+		wantInvalidate bool
+	}{
+		{
+			name:           "fresh update",
+			applied:        true,
+			wantInvalidate: true,
+		},
+		{
+			name:           "duplicate delivery still invalidates",
+			applied:        false,
+			wantInvalidate: true,
+		},
+		{
+			name:    "unknown game is skipped",
+			repoErr: entity.ErrGameNotFound,
+		},
+		{
+			name:    "repository failure is retried",
+			repoErr: entity.ErrInternal,
+			wantErr: true,
+		},
+		{
+			// Redis некритичен: партицию не стопорим, кэш устареет максимум на TTL
+			name:           "cache failure is not retried",
+			applied:        true,
+			cacheErr:       errors.New("redis down"),
+			wantInvalidate: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakeSnapshotRepo{applied: tc.applied, err: tc.repoErr}
+			cache := newFakeCache()
+			cache.err = tc.cacheErr
+			uc := New(nil, nil, zap.NewNop(), nil, cache, WithRatingSnapshots(repo))
+
+			err := uc.ApplyRatingUpdate(context.Background(), upd)
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, 1, repo.calls)
+			if tc.wantInvalidate {
+				require.Equal(t, []string{listGamesGenKey}, cache.incrs)
+			} else {
+				require.Empty(t, cache.incrs)
+			}
+		})
+	}
+}
+
+type fakeRatingFeed struct {
+	published []entity.RatingUpdate
+}
+
+func (f *fakeRatingFeed) PublishRatingUpdate(ctx context.Context, upd entity.RatingUpdate) error {
+	f.published = append(f.published, upd)
+	return nil
+}
+
+func TestUsecase_ApplyRatingUpdate_PublishesOnlyFresh(t *testing.T) {
+	upd := entity.RatingUpdate{GameID: "game-1", AverageRating: 8, RatingsCount: 2, UpdatedAt: time.Now()}
+
+	for _, applied := range []bool{true, false} {
+		feed := &fakeRatingFeed{}
+		uc := New(nil, nil, zap.NewNop(), nil, newFakeCache(),
+			WithRatingSnapshots(&fakeSnapshotRepo{applied: applied}), WithRatingFeed(feed))
+
+		require.NoError(t, uc.ApplyRatingUpdate(context.Background(), upd))
+		if applied {
+			require.Equal(t, []entity.RatingUpdate{upd}, feed.published)
+		} else {
+			require.Empty(t, feed.published, "stale update must not reach live clients")
+		}
+	}
+}
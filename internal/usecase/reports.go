@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"go.uber.org/zap"
+)
+
+const maxReportDetails = 500
+
+var reportReasons = map[string]bool{
+	entity.ReportSpam:       true,
+	entity.ReportHarassment: true,
+	entity.ReportSpoiler:    true,
+	entity.ReportOther:      true,
+}
+
+// ReportRepository — жалобы пользователей на комментарии
+type ReportRepository interface {
+	AddCommentReport(ctx context.Context, rep entity.CommentReport) (int, error)
+	ListReportedComments(ctx context.Context, status string, limit, offset int32) ([]entity.ReportedComment, error)
+}
+
+// ReportComment принимает жалобу. Когда жалоб становится ровно threshold, видимый комментарий
+// уходит в очередь модерации (pending) — дальше решает модератор; одобренный повторно сам не скроется.
+func (u *Usecase) ReportComment(ctx context.Context, rep entity.CommentReport) error {
+	// 1) забираем request_id
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+
+	// 2) оборачиваем логгер
+	logger := u.logger.With(zap.String("func", "ReportComment"), zap.String("comment_id", rep.CommentID))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	if u.reports == nil {
+		return entity.ErrInternal
+	}
+
+	// 3) валидация
+	rep.Details = strings.TrimSpace(rep.Details)
+	if !reportReasons[rep.Reason] {
+		return fmt.Errorf("%w: reason must be one of spam, harassment, spoiler, other", entity.ErrInvalidReport)
+	}
+	if utf8.RuneCountInString(rep.Details) > maxReportDetails {
+		return fmt.Errorf("%w: details must be at most %d characters", entity.ErrInvalidReport, maxReportDetails)
+	}
+
+	// 4) жалоба и, если дошли до порога, автоскрытие с событием CommentDeleted — в одной транзакции
+	var autoHidden bool
+	err := u.inTx(ctx, func(ctx context.Context) error {
+		count, err := u.reports.AddCommentReport(ctx, rep)
+		if err != nil {
+			return err
+		}
+		if u.moderation == nil || u.reportThreshold <= 0 || count != u.reportThreshold {
+			return nil
+		}
+
+		reason := fmt.Sprintf("auto-hidden after %d reports", count)
+		c, prev, err := u.moderation.SetCommentStatus(ctx, rep.CommentID,
+			[]string{entity.CommentVisible}, entity.CommentPending, entity.SystemModerator, reason)
+		if errors.Is(err, entity.ErrInvalidModeration) {
+			// уже на модерации или скрыт — трогать нечего
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		autoHidden = true
+		// для потребителей outbox и вебхуков комментарий пропал, как при скрытии модератором
+		if err := u.publishVisibilityChange(ctx, *c, prev, entity.CommentPending); err != nil {
+			return err
+		}
+		return u.moderation.AddModerationAudit(ctx, entity.ModerationAudit{
+			CommentID:  c.ID,
+			GameID:     c.GameID,
+			Action:     entity.ModerationAutoHide,
+			FromStatus: prev,
+			ToStatus:   entity.CommentPending,
+			Moderator:  entity.SystemModerator,
+			Reason:     reason,
+		})
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrCommentNotFound), errors.Is(err, entity.ErrDuplicateReport):
+			logger.Info("report rejected", zap.Error(err))
+			return err
+		default:
+			logger.Error("failed to report comment", zap.Error(err))
+			return entity.ErrInternal
+		}
+	}
+
+	logger.Info("comment reported", zap.String("reason", rep.Reason), zap.Bool("auto_hidden", autoHidden))
+	return nil
+}
+
+// ListReportedComments — сводка жалоб для модераторов
+func (u *Usecase) ListReportedComments(ctx context.Context, status string, limit, offset int32) ([]entity.ReportedComment, error) {
+	if u.reports == nil {
+		return nil, entity.ErrInternal
+	}
+	return u.reports.ListReportedComments(ctx, status, limit, offset)
+}
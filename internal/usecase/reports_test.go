@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeReportRepo — жалобы в памяти, уникальные по (комментарий, пользователь)
+type fakeReportRepo struct {
+	reports map[string]map[string]string
+}
+
+func (f *fakeReportRepo) AddCommentReport(ctx context.Context, rep entity.CommentReport) (int, error) {
+	if rep.CommentID == "missing" {
+		return 0, entity.ErrCommentNotFound
+	}
+	byUser := f.reports[rep.CommentID]
+	if byUser == nil {
+		byUser = map[string]string{}
+		f.reports[rep.CommentID] = byUser
+	}
+	if _, ok := byUser[rep.UserID]; ok {
+		return 0, entity.ErrDuplicateReport
+	}
+	byUser[rep.UserID] = rep.Reason
+	return len(byUser), nil
+}
+
+func (f *fakeReportRepo) ListReportedComments(ctx context.Context, status string, limit, offset int32) ([]entity.ReportedComment, error) {
+	return nil, nil
+}
+
+func newReportsUsecase(threshold int) (*Usecase, *fakeModerationRepo) {
+	mod := &fakeModerationRepo{status: map[string]string{moderatedID: entity.CommentVisible}}
+	uc := New(nil, &mockRepo{}, zap.NewNop(), nil, nil,
+		WithModeration(mod), WithTransactor(&fakeTx{}),
+		WithReports(&fakeReportRepo{reports: map[string]map[string]string{}}, threshold))
+	return uc, mod
+}
+
+func report(userID, reason string) entity.CommentReport {
+	return entity.CommentReport{CommentID: moderatedID, GameID: "game-1", UserID: userID, Reason: reason}
+}
+
+func TestReportComment_Validation(t *testing.T) {
+	uc, _ := newReportsUsecase(3)
+	ctx := context.Background()
+
+	require.ErrorIs(t, uc.ReportComment(ctx, report("u1", "boring")), entity.ErrInvalidReport)
+
+	long := report("u1", entity.ReportOther)
+	long.Details = string(make([]rune, maxReportDetails+1))
+	require.ErrorIs(t, uc.ReportComment(ctx, long), entity.ErrInvalidReport)
+
+	missing := report("u1", entity.ReportSpam)
+	missing.CommentID = "missing"
+	require.ErrorIs(t, uc.ReportComment(ctx, missing), entity.ErrCommentNotFound)
+}
+
+func TestReportComment_DedupAndThreshold(t *testing.T) {
+	uc, mod := newReportsUsecase(2)
+	ctx := context.Background()
+
+	require.NoError(t, uc.ReportComment(ctx, report("u1", entity.ReportSpam)))
+	require.ErrorIs(t, uc.ReportComment(ctx, report("u1", entity.ReportSpoiler)), entity.ErrDuplicateReport)
+	require.Equal(t, entity.CommentVisible, mod.status[moderatedID])
+
+	// вторая жалоба от другого пользователя — порог
+	require.NoError(t, uc.ReportComment(ctx, report("u2", entity.ReportHarassment)))
+	require.Equal(t, entity.CommentPending, mod.status[moderatedID])
+	require.Len(t, mod.audit, 1)
+	require.Equal(t, entity.ModerationAutoHide, mod.audit[0].Action)
+	require.Equal(t, entity.SystemModerator, mod.audit[0].Moderator)
+	require.Equal(t, []bool{true}, mod.auditTx)
+
+	// модератор одобрил — новые жалобы выше порога сами не скрывают
+	_, err := uc.ModerateComment(moderatorCtx("alice"), moderatedID, entity.ModerationApprove, "")
+	require.NoError(t, err)
+	require.NoError(t, uc.ReportComment(ctx, report("u3", entity.ReportSpam)))
+	require.Equal(t, entity.CommentVisible, mod.status[moderatedID])
+}
+
+func TestReportComment_AutoHidePublishesCommentDeleted(t *testing.T) {
+	uc, _ := newReportsUsecase(2)
+	pub := &fakeEventPublisher{}
+	WithEvents(pub)(uc)
+	ctx := context.Background()
+
+	require.NoError(t, uc.ReportComment(ctx, report("u1", entity.ReportSpam)))
+	require.Empty(t, pub.events)
+
+	require.NoError(t, uc.ReportComment(ctx, report("u2", entity.ReportSpam)))
+	require.Len(t, pub.events, 1)
+	require.Equal(t, entity.EventCommentDeleted, pub.events[0].Type)
+	require.Equal(t, moderatedID, pub.events[0].AggregateID)
+	require.Equal(t, []bool{true}, pub.inTx)
+
+	// одобрение после автоскрытия возвращает комментарий потребителям
+	_, err := uc.ModerateComment(moderatorCtx("alice"), moderatedID, entity.ModerationApprove, "")
+	require.NoError(t, err)
+	require.Len(t, pub.events, 2)
+	require.Equal(t, entity.EventCommentAdded, pub.events[1].Type)
+}
+
+func TestReportComment_NoAutoHideWithoutThreshold(t *testing.T) {
+	uc, mod := newReportsUsecase(0)
+
+	for _, u := range []string{"u1", "u2", "u3"} {
+		require.NoError(t, uc.ReportComment(context.Background(), report(u, entity.ReportSpam)))
+	}
+	require.Equal(t, entity.CommentVisible, mod.status[moderatedID])
+	require.Empty(t, mod.audit)
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"go.uber.org/zap"
+)
+
+// размер страницы при догрузке пропущенных комментариев
+const commentBackfillPage = 100
+
+// StreamComments отдаёт новые комментарии игры, пока не отменён ctx.
+// С lastEventID сначала догружаются комментарии после него (keyset), затем — живые.
+// Канал закрывается, если подписчик отстал или догрузка не удалась: клиент переподключается
+// с последним полученным id и ничего не теряет.
+func (u *Usecase) StreamComments(ctx context.Context, gameID, lastEventID string) (<-chan entity.Comment, error) {
+	// 1) забираем request_id
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+
+	// 2) оборачиваем логгер
+	logger := u.logger.With(zap.String("func", "StreamComments"), zap.String("game_id", gameID))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	if u.commentFeed == nil {
+		return nil, entity.ErrStreamDisabled
+	}
+
+	// 3) игра должна существовать
+	if _, err := u.gameHubRepo.GetGameTopic(ctx, gameID); err != nil {
+		if errors.Is(err, entity.ErrGameNotFound) {
+			logger.Info("game not found")
+			return nil, entity.ErrGameNotFound
+		}
+		logger.Error("failed to check game", zap.Error(err))
+		return nil, entity.ErrInternal
+	}
+
+	// 4) подписываемся до догрузки, чтобы не потерять комментарии между запросом в БД и подпиской
+	live := u.commentFeed.SubscribeComments(ctx, gameID)
+
+	out := make(chan entity.Comment)
+	go func() {
+		defer close(out)
+
+		send := func(c entity.Comment) bool {
+			select {
+			case out <- c:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		// 5) догрузка по keyset; seen — чтобы не отдать дважды то, что пришло и из БД, и из Redis
+		seen := make(map[string]struct{})
+		if lastEventID != "" && u.commentLog != nil {
+			after := lastEventID
+			for {
+				page, err := u.commentLog.GetCommentsAfter(ctx, gameID, after, commentBackfillPage)
+				if errors.Is(err, entity.ErrCommentNotFound) {
+					logger.Info("unknown Last-Event-ID, resume skipped", zap.String("last_event_id", lastEventID))
+					break
+				}
+				if err != nil {
+					logger.Error("failed to backfill comments", zap.Error(err))
+					return
+				}
+				for _, c := range page {
+					if !send(c) {
+						return
+					}
+					seen[c.ID] = struct{}{}
+				}
+				if len(page) < commentBackfillPage {
+					break
+				}
+				after = page[len(page)-1].ID
+			}
+			logger.Info("comments backfilled", zap.Int("count", len(seen)))
+		}
+
+		// 6) живые комментарии
+		for c := range live {
+			if _, dup := seen[c.ID]; dup {
+				continue
+			}
+			if !send(c) {
+				return
+			}
+		}
+		if ctx.Err() == nil {
+			logger.Info("live feed closed, subscriber dropped")
+		}
+	}()
+
+	return out, nil
+}
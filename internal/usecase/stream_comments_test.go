@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type streamRepo struct {
+	mockRepo
+	gameErr error
+}
+
+func (s *streamRepo) GetGameTopic(ctx context.Context, gameID string) (*entity.Game, error) {
+	if s.gameErr != nil {
+		return nil, s.gameErr
+	}
+	return &entity.Game{ID: gameID}, nil
+}
+
+type fakeCommentFeed struct {
+	live      chan entity.Comment
+	published []entity.Comment
+}
+
+func (f *fakeCommentFeed) PublishComment(ctx context.Context, c entity.Comment) error {
+	f.published = append(f.published, c)
+	return nil
+}
+
+func (f *fakeCommentFeed) SubscribeComments(ctx context.Context, gameID string) <-chan entity.Comment {
+	return f.live
+}
+
+// fakeHistory хранит комментарии по порядку и режет их страницами после afterID
+type fakeHistory struct {
+	comments []entity.Comment
+	pages    int
+}
+
+func (f *fakeHistory) GetCommentsAfter(ctx context.Context, gameID, afterID string, limit int32) ([]entity.Comment, error) {
+	f.pages++
+	for i, c := range f.comments {
+		if c.ID == afterID {
+			rest := f.comments[i+1:]
+			if len(rest) > int(limit) {
+				rest = rest[:limit]
+			}
+			return rest, nil
+		}
+	}
+	return nil, entity.ErrCommentNotFound
+}
+
+func commentIDs(n int) []entity.Comment {
+	out := make([]entity.Comment, n)
+	for i := range out {
+		out[i] = entity.Comment{ID: fmt.Sprintf("c-%03d", i), GameID: "game-1"}
+	}
+	return out
+}
+
+func collect(t *testing.T, ch <-chan entity.Comment) []string {
+	t.Helper()
+	var ids []string
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case c, ok := <-ch:
+			if !ok {
+				return ids
+			}
+			ids = append(ids, c.ID)
+		case <-timeout:
+			t.Fatal("stream was not closed")
+		}
+	}
+}
+
+func TestStreamComments_ResumeThenLive(t *testing.T) {
+	history := &fakeHistory{comments: commentIDs(commentBackfillPage + 5)}
+	feed := &fakeCommentFeed{live: make(chan entity.Comment, 3)}
+	// последний комментарий из БД пришёл и через Redis — дубль должен отброситься
+	feed.live <- history.comments[len(history.comments)-1]
+	feed.live <- entity.Comment{ID: "c-live"}
+	close(feed.live)
+
+	uc := New(nil, &streamRepo{}, zap.NewNop(), nil, nil, WithCommentFeed(feed, history))
+
+	ch, err := uc.StreamComments(context.Background(), "game-1", "c-002")
+	require.NoError(t, err)
+
+	ids := collect(t, ch)
+	require.Len(t, ids, commentBackfillPage+2+1)
+	require.Equal(t, "c-003", ids[0])
+	require.Equal(t, fmt.Sprintf("c-%03d", commentBackfillPage+4), ids[len(ids)-2])
+	require.Equal(t, "c-live", ids[len(ids)-1])
+	require.Equal(t, 2, history.pages)
+}
+
+func TestStreamComments_UnknownCursorStreamsLive(t *testing.T) {
+	feed := &fakeCommentFeed{live: make(chan entity.Comment, 1)}
+	feed.live <- entity.Comment{ID: "c-live"}
+	close(feed.live)
+
+	uc := New(nil, &streamRepo{}, zap.NewNop(), nil, nil, WithCommentFeed(feed, &fakeHistory{}))
+
+	ch, err := uc.StreamComments(context.Background(), "game-1", "deleted-comment")
+	require.NoError(t, err)
+	require.Equal(t, []string{"c-live"}, collect(t, ch))
+}
+
+func TestStreamComments_Errors(t *testing.T) {
+	uc := New(nil, &streamRepo{}, zap.NewNop(), nil, nil)
+	_, err := uc.StreamComments(context.Background(), "game-1", "")
+	require.ErrorIs(t, err, entity.ErrStreamDisabled)
+
+	feed := &fakeCommentFeed{live: make(chan entity.Comment)}
+	uc = New(nil, &streamRepo{gameErr: entity.ErrGameNotFound}, zap.NewNop(), nil, nil, WithCommentFeed(feed, nil))
+	_, err = uc.StreamComments(context.Background(), "game-1", "")
+	require.ErrorIs(t, err, entity.ErrGameNotFound)
+}
+
+func TestAddComment_PublishesToLiveFeed(t *testing.T) {
+	feed := &fakeCommentFeed{}
+	uc := New(nil, &mockRepo{returnID: "comment-1"}, zap.NewNop(), nil, nil, WithCommentFeed(feed, nil))
+
+	_, err := uc.AddComment(context.Background(), "game-1", "user-1", "nice")
+	require.NoError(t, err)
+
+	require.Len(t, feed.published, 1)
+	require.Equal(t, "comment-1", feed.published[0].ID)
+	require.Equal(t, "game-1", feed.published[0].GameID)
+	require.False(t, feed.published[0].CreatedAt.IsZero())
+}
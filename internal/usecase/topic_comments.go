@@ -18,8 +18,9 @@ func (u *Usecase) GetListComments(ctx context.Context, gameID string, limit, off
 		logger = logger.With(zap.String("request_id", reqID))
 	}
 
-	// 3) получаем комментарии
-	commentsList, err := u.gameHubRepo.GetCommentsGame(ctx, gameID, limit, offset)
+	// 3) получаем комментарии; модератор видит и скрытые, и ожидающие проверки
+	moderator, _ := ctx.Value(entity.ModeratorKey{}).(string)
+	commentsList, err := u.gameHubRepo.GetCommentsGame(ctx, gameID, limit, offset, moderator != "")
 	if err != nil {
 		// timeout
 		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
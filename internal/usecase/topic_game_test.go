@@ -21,10 +21,10 @@ func (f *fakeTopicRepo) GetGameTopic(ctx context.Context, id string) (*entity.Ga
 func (f *fakeTopicRepo) GetGameInfo(ctx context.Context, ids []string) ([]entity.GameInList, error) {
 	panic("not used")
 }
-func (f *fakeTopicRepo) GetCommentsGame(ctx context.Context, gameID string, limit, offset int32) ([]entity.Comment, error) {
+func (f *fakeTopicRepo) GetCommentsGame(ctx context.Context, gameID string, limit, offset int32, allStatuses bool) ([]entity.Comment, error) {
 	panic("not used")
 }
-func (f *fakeTopicRepo) AddComment(ctx context.Context, gameID, userID, text string) (string, error) {
+func (f *fakeTopicRepo) AddComment(ctx context.Context, gameID, userID, text, status string) (string, error) {
 	panic("not used")
 }
 func (f *fakeTopicRepo) AddGameTopic(ctx context.Context, game *entity.Game) (string, error) {
@@ -13,6 +13,21 @@ type Usecase struct {
 	logger       *zap.Logger
 	kafka        RatingProducer
 	redis        CacheClient
+	snapshots    RatingSnapshotRepository
+	tx           Transactor
+	events       EventPublisher
+	webhooks     WebhookRepository
+	webhookGuard WebhookGuard
+	commentFeed  CommentFeed
+	commentLog   CommentHistory
+	ratingFeed   RatingFeed
+	moderation   ModerationRepository
+	filter       CommentFilter
+
+	reports         ReportRepository
+	reportThreshold int
+
+	export ExportRepository
 }
 
 type RatingClient interface {
@@ -24,8 +39,8 @@ type RatingClient interface {
 type GameRepository interface {
 	GetGameTopic(ctx context.Context, gameID string) (*entity.Game, error)
 	GetGameInfo(ctx context.Context, ids []string) ([]entity.GameInList, error)
-	GetCommentsGame(ctx context.Context, gameID string, limit, offset int32) ([]entity.Comment, error)
-	AddComment(ctx context.Context, gameID, userID, text string) (string, error)
+	GetCommentsGame(ctx context.Context, gameID string, limit, offset int32, allStatuses bool) ([]entity.Comment, error)
+	AddComment(ctx context.Context, gameID, userID, text, status string) (string, error)
 	AddGameTopic(ctx context.Context, gameInfo *entity.Game) (string, error)
 }
 
@@ -33,19 +48,79 @@ type RatingProducer interface {
 	PublishRating(ctx context.Context, msg entity.RatingMessage) error
 }
 
+// EventPublisher публикует доменные события (GameCreated, CommentAdded, ...).
+// Реализация через outbox пишет событие в ту же транзакцию, что и основную запись.
+type EventPublisher interface {
+	PublishEvent(ctx context.Context, evt entity.DomainEvent) error
+}
+
+// Transactor выполняет fn атомарно; репозиторий берёт транзакцию из ctx
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// WebhookRepository — подписки партнёров; чтение и изменение — только в пределах владельца
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, sub *entity.WebhookSubscription) (string, error)
+	ListWebhooks(ctx context.Context, owner string) ([]entity.WebhookSubscription, error)
+	GetWebhook(ctx context.Context, id, owner string) (*entity.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, id, owner string) error
+	EnableWebhook(ctx context.Context, id, owner string) error
+	ListWebhookAttempts(ctx context.Context, subID string, limit, offset int32) ([]entity.WebhookAttempt, error)
+	EnqueueWebhookDeliveries(ctx context.Context, evt entity.DomainEvent) error
+}
+
+// WebhookGuard отклоняет адреса получателей во внутренней сети (webhook.DestinationGuard)
+type WebhookGuard interface {
+	CheckHost(ctx context.Context, host string) error
+}
+
+// CommentFeed рассылает новые комментарии подписчикам на всех репликах (Redis Pub/Sub).
+// Канал подписки закрывается при отмене ctx или если читатель не успевает.
+type CommentFeed interface {
+	PublishComment(ctx context.Context, c entity.Comment) error
+	SubscribeComments(ctx context.Context, gameID string) <-chan entity.Comment
+}
+
+// CommentHistory — keyset по (created_at, id) для догрузки пропущенного по Last-Event-ID
+type CommentHistory interface {
+	GetCommentsAfter(ctx context.Context, gameID, afterID string, limit int32) ([]entity.Comment, error)
+}
+
+// RatingFeed раздаёт применённые обновления рейтинга всем репликам (живые клиенты главной страницы)
+type RatingFeed interface {
+	PublishRatingUpdate(ctx context.Context, upd entity.RatingUpdate) error
+}
+
+// CommentFilter проверяет комментарий до записи: *entity.CommentRejection — отказ,
+// Moderate в вердикте — сохранить со статусом pending
+type CommentFilter interface {
+	Check(ctx context.Context, c entity.CommentDraft) (entity.FilterVerdict, error)
+}
+
+type RatingSnapshotRepository interface {
+	UpsertRatingSnapshot(ctx context.Context, upd entity.RatingUpdate) (bool, error)
+}
+
 type CacheClient interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key, value string) error
+	Incr(ctx context.Context, key string) (int64, error)
 }
 
-func New(ratingClient RatingClient, gameRepo GameRepository, logger *zap.Logger, ratingProd RatingProducer, cache CacheClient) *Usecase {
+func New(ratingClient RatingClient, gameRepo GameRepository, logger *zap.Logger, ratingProd RatingProducer,
+	cache CacheClient, opts ...Option) *Usecase {
 
 	logger = logger.With(zap.String("layer", "mainUsecase"))
-	return &Usecase{
+	uc := &Usecase{
 		ratingClient: ratingClient,
 		gameHubRepo:  gameRepo,
 		logger:       logger,
 		kafka:        ratingProd,
 		redis:        cache,
 	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
 }
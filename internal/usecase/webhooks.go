@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const minWebhookSecretLen = 16
+
+var webhookEventTypes = map[string]bool{
+	entity.EventGameCreated:    true,
+	entity.EventCommentAdded:   true,
+	entity.EventCommentDeleted: true,
+}
+
+// CreateWebhook создаёт подписку от имени партнёра из ctx (entity.PartnerKey)
+func (u *Usecase) CreateWebhook(ctx context.Context, sub *entity.WebhookSubscription) (string, error) {
+	// 1) забираем request_id
+	reqID, _ := ctx.Value(entity.RequestIDKey{}).(string)
+
+	// 2) оборачиваем логгер
+	logger := u.logger.With(zap.String("func", "CreateWebhook"))
+	if reqID != "" {
+		logger = logger.With(zap.String("request_id", reqID))
+	}
+
+	// без guard подписку не создаём: иначе партнёр может направить доставки во внутреннюю сеть
+	if u.webhooks == nil || u.webhookGuard == nil {
+		return "", entity.ErrInternal
+	}
+
+	// 3) чья подписка
+	owner, err := webhookOwner(ctx)
+	if err != nil {
+		return "", err
+	}
+	sub.Owner = owner
+	logger = logger.With(zap.String("partner", owner))
+
+	// 4) валидация, в том числе адреса получателя
+	if err := u.validateWebhook(ctx, sub); err != nil {
+		logger.Info("invalid webhook subscription", zap.Error(err))
+		return "", err
+	}
+
+	id, err := u.webhooks.CreateWebhook(ctx, sub)
+	if err != nil {
+		if errors.Is(err, entity.ErrGameNotFound) {
+			return "", entity.ErrGameNotFound
+		}
+		logger.Error("failed to create webhook", zap.Error(err))
+		return "", entity.ErrInternal
+	}
+
+	logger.Info("webhook created", zap.String("webhook_id", id), zap.Strings("event_types", sub.EventTypes))
+	return id, nil
+}
+
+func (u *Usecase) ListWebhooks(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	if u.webhooks == nil {
+		return nil, entity.ErrInternal
+	}
+	owner, err := webhookOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.webhooks.ListWebhooks(ctx, owner)
+}
+
+func (u *Usecase) GetWebhook(ctx context.Context, id string) (*entity.WebhookSubscription, error) {
+	if u.webhooks == nil {
+		return nil, entity.ErrInternal
+	}
+	owner, err := webhookOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.webhooks.GetWebhook(ctx, id, owner)
+}
+
+func (u *Usecase) DeleteWebhook(ctx context.Context, id string) error {
+	if u.webhooks == nil {
+		return entity.ErrInternal
+	}
+	owner, err := webhookOwner(ctx)
+	if err != nil {
+		return err
+	}
+	return u.webhooks.DeleteWebhook(ctx, id, owner)
+}
+
+func (u *Usecase) EnableWebhook(ctx context.Context, id string) error {
+	if u.webhooks == nil {
+		return entity.ErrInternal
+	}
+	owner, err := webhookOwner(ctx)
+	if err != nil {
+		return err
+	}
+	return u.webhooks.EnableWebhook(ctx, id, owner)
+}
+
+func (u *Usecase) ListWebhookAttempts(ctx context.Context, id string, limit, offset int32) ([]entity.WebhookAttempt, error) {
+	if u.webhooks == nil {
+		return nil, entity.ErrInternal
+	}
+	// 404 для несуществующей или чужой подписки, а не пустой журнал
+	if _, err := u.GetWebhook(ctx, id); err != nil {
+		return nil, err
+	}
+	return u.webhooks.ListWebhookAttempts(ctx, id, limit, offset)
+}
+
+// webhookOwner — партнёр из ctx; без него подписки недоступны
+func webhookOwner(ctx context.Context) (string, error) {
+	owner, _ := ctx.Value(entity.PartnerKey{}).(string)
+	if owner == "" {
+		return "", entity.ErrPartnerRequired
+	}
+	return owner, nil
+}
+
+func (u *Usecase) validateWebhook(ctx context.Context, sub *entity.WebhookSubscription) error {
+	parsed, err := url.Parse(sub.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", entity.ErrInvalidWebhook)
+	}
+	if parsed.User != nil {
+		return fmt.Errorf("%w: url must not contain credentials", entity.ErrInvalidWebhook)
+	}
+
+	if len(sub.Secret) < minWebhookSecretLen {
+		return fmt.Errorf("%w: secret must be at least %d characters", entity.ErrInvalidWebhook, minWebhookSecretLen)
+	}
+	if len(sub.EventTypes) == 0 {
+		return fmt.Errorf("%w: event_types must not be empty", entity.ErrInvalidWebhook)
+	}
+	for _, t := range sub.EventTypes {
+		if !webhookEventTypes[t] {
+			return fmt.Errorf("%w: unknown event type %q", entity.ErrInvalidWebhook, t)
+		}
+	}
+	if sub.GameID != "" {
+		if _, err := uuid.Parse(sub.GameID); err != nil {
+			return fmt.Errorf("%w: game_id is not a valid UUID", entity.ErrInvalidWebhook)
+		}
+	}
+	// последним: резолвит имя хоста
+	if err := u.webhookGuard.CheckHost(ctx, parsed.Hostname()); err != nil {
+		return fmt.Errorf("%w: url host is not allowed: %v", entity.ErrInvalidWebhook, err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeWebhookRepo struct {
+	created  []*entity.WebhookSubscription
+	enqueued []entity.DomainEvent
+	owners   []string
+}
+
+func (f *fakeWebhookRepo) CreateWebhook(ctx context.Context, sub *entity.WebhookSubscription) (string, error) {
+	f.created = append(f.created, sub)
+	return "wh-1", nil
+}
+func (f *fakeWebhookRepo) ListWebhooks(ctx context.Context, owner string) ([]entity.WebhookSubscription, error) {
+	f.owners = append(f.owners, owner)
+	return nil, nil
+}
+func (f *fakeWebhookRepo) GetWebhook(ctx context.Context, id, owner string) (*entity.WebhookSubscription, error) {
+	f.owners = append(f.owners, owner)
+	return nil, entity.ErrWebhookNotFound
+}
+func (f *fakeWebhookRepo) DeleteWebhook(ctx context.Context, id, owner string) error {
+	f.owners = append(f.owners, owner)
+	return nil
+}
+func (f *fakeWebhookRepo) EnableWebhook(ctx context.Context, id, owner string) error {
+	f.owners = append(f.owners, owner)
+	return nil
+}
+func (f *fakeWebhookRepo) ListWebhookAttempts(ctx context.Context, subID string, limit, offset int32) ([]entity.WebhookAttempt, error) {
+	return nil, nil
+}
+func (f *fakeWebhookRepo) EnqueueWebhookDeliveries(ctx context.Context, evt entity.DomainEvent) error {
+	f.enqueued = append(f.enqueued, evt)
+	return nil
+}
+
+// fakeGuard пропускает всё, кроме перечисленных хостов
+type fakeGuard struct {
+	forbidden map[string]bool
+}
+
+func (g fakeGuard) CheckHost(ctx context.Context, host string) error {
+	if g.forbidden[host] {
+		return errors.New("destination is not a public address")
+	}
+	return nil
+}
+
+func partnerCtx(name string) context.Context {
+	return context.WithValue(context.Background(), entity.PartnerKey{}, name)
+}
+
+func TestCreateWebhook_Validation(t *testing.T) {
+	valid := func() *entity.WebhookSubscription {
+		return &entity.WebhookSubscription{
+			URL:        "https://partner.example.com/hooks",
+			Secret:     "0123456789abcdef",
+			EventTypes: []string{entity.EventCommentAdded},
+		}
+	}
+
+	cases := []struct {
+		name    string
+		mutate  func(s *entity.WebhookSubscription)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(s *entity.WebhookSubscription) {}},
+		{name: "game filter", mutate: func(s *entity.WebhookSubscription) { s.GameID = "3f2c1a9e-1b7d-4c55-9a0e-2d4b8f6e7a10" }},
+		{name: "relative url", mutate: func(s *entity.WebhookSubscription) { s.URL = "/hooks" }, wantErr: true},
+		{name: "ftp url", mutate: func(s *entity.WebhookSubscription) { s.URL = "ftp://example.com" }, wantErr: true},
+		{name: "short secret", mutate: func(s *entity.WebhookSubscription) { s.Secret = "short" }, wantErr: true},
+		{name: "no events", mutate: func(s *entity.WebhookSubscription) { s.EventTypes = nil }, wantErr: true},
+		{name: "unknown event", mutate: func(s *entity.WebhookSubscription) { s.EventTypes = []string{"RatingAdded"} }, wantErr: true},
+		// GameUpdated никто не публикует — подписка на него была бы мёртвой
+		{name: "never published event", mutate: func(s *entity.WebhookSubscription) { s.EventTypes = []string{"GameUpdated"} }, wantErr: true},
+		{name: "bad game id", mutate: func(s *entity.WebhookSubscription) { s.GameID = "nope" }, wantErr: true},
+		{name: "credentials in url", mutate: func(s *entity.WebhookSubscription) { s.URL = "https://u:p@partner.example.com/" }, wantErr: true},
+		{name: "internal host", mutate: func(s *entity.WebhookSubscription) { s.URL = "http://169.254.169.254/latest" }, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &fakeWebhookRepo{}
+			uc := New(nil, nil, zap.NewNop(), nil, nil,
+				WithWebhooks(repo, fakeGuard{forbidden: map[string]bool{"169.254.169.254": true}}))
+
+			sub := valid()
+			tc.mutate(sub)
+			id, err := uc.CreateWebhook(partnerCtx("acme"), sub)
+			if tc.wantErr {
+				require.ErrorIs(t, err, entity.ErrInvalidWebhook)
+				require.Empty(t, repo.created)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "wh-1", id)
+			require.Equal(t, "acme", repo.created[0].Owner)
+		})
+	}
+}
+
+func TestWebhooks_ScopedToPartner(t *testing.T) {
+	repo := &fakeWebhookRepo{}
+	uc := New(nil, nil, zap.NewNop(), nil, nil, WithWebhooks(repo, fakeGuard{}))
+	sub := &entity.WebhookSubscription{URL: "https://partner.example.com/hooks", Secret: "0123456789abcdef",
+		EventTypes: []string{entity.EventCommentAdded}}
+
+	// без партнёра в ctx — ничего
+	_, err := uc.CreateWebhook(context.Background(), sub)
+	require.ErrorIs(t, err, entity.ErrPartnerRequired)
+	_, err = uc.ListWebhooks(context.Background())
+	require.ErrorIs(t, err, entity.ErrPartnerRequired)
+	require.ErrorIs(t, uc.DeleteWebhook(context.Background(), "wh-1"), entity.ErrPartnerRequired)
+	require.Empty(t, repo.created)
+	require.Empty(t, repo.owners)
+
+	// репозиторий получает владельца в каждой операции
+	ctx := partnerCtx("acme")
+	_, err = uc.ListWebhooks(ctx)
+	require.NoError(t, err)
+	require.NoError(t, uc.DeleteWebhook(ctx, "wh-1"))
+	require.NoError(t, uc.EnableWebhook(ctx, "wh-1"))
+	_, err = uc.ListWebhookAttempts(ctx, "wh-1", 10, 0)
+	require.ErrorIs(t, err, entity.ErrWebhookNotFound)
+	require.Equal(t, []string{"acme", "acme", "acme", "acme"}, repo.owners)
+}
+
+func TestAddComment_EnqueuesWebhooks(t *testing.T) {
+	repo := &fakeWebhookRepo{}
+	tx := &fakeTx{}
+	uc := New(nil, &mockRepo{returnID: "comment-1"}, zap.NewNop(), nil, nil, WithTransactor(tx), WithWebhooks(repo, fakeGuard{}))
+
+	_, err := uc.AddComment(context.Background(), "game-1", "user-1", "nice")
+	require.NoError(t, err)
+
+	require.Equal(t, 1, tx.calls)
+	require.Len(t, repo.enqueued, 1)
+	require.Equal(t, entity.EventCommentAdded, repo.enqueued[0].Type)
+	require.Equal(t, "game-1", repo.enqueued[0].GameID)
+}
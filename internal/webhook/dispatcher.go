@@ -0,0 +1,231 @@
+// Package webhook доставляет доменные события подписчикам по HTTP.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"go.uber.org/zap"
+)
+
+// Заголовки запроса к получателю
+const (
+	HeaderEvent     = "X-GameHub-Event"
+	HeaderDelivery  = "X-GameHub-Delivery"
+	HeaderTimestamp = "X-GameHub-Timestamp"
+	HeaderSignature = "X-GameHub-Signature"
+)
+
+// ограничиваем то, что пишем в журнал из ответа получателя
+const maxErrorBody = 512
+
+type Store interface {
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error)
+	RecordWebhookAttempt(ctx context.Context, res entity.WebhookAttemptResult, disableAfter int) (bool, error)
+}
+
+type Dispatcher struct {
+	store  Store
+	client *http.Client
+	guard  *DestinationGuard
+	logger *zap.Logger
+
+	pollInterval   time.Duration
+	requestTimeout time.Duration
+	batchSize      int
+	concurrency    int
+	maxAttempts    int
+	backoffBase    time.Duration
+	backoffMax     time.Duration
+	disableAfter   int
+
+	now func() time.Time
+}
+
+func New(store Store, logger *zap.Logger, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		store:          store,
+		guard:          NewGuard(false),
+		logger:         logger.With(zap.String("component", "webhook-dispatcher")),
+		pollInterval:   _defaultPollInterval,
+		requestTimeout: _defaultRequestTimeout,
+		batchSize:      _defaultBatchSize,
+		concurrency:    _defaultConcurrency,
+		maxAttempts:    _defaultMaxAttempts,
+		backoffBase:    _defaultBackoffBase,
+		backoffMax:     _defaultBackoffMax,
+		disableAfter:   _defaultDisableAfter,
+		now:            time.Now,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.client == nil {
+		d.client = newClient(d.guard, d.requestTimeout)
+	}
+	return d
+}
+
+// newClient — клиент без прокси и редиректов: каждое соединение проходит через guard,
+// 3xx считается неудачной доставкой, как у большинства вебхук-провайдеров
+func newClient(guard *DestinationGuard, timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second, Control: guard.control}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+}
+
+// Sign возвращает подпись тела: hex(HMAC-SHA256(secret, "<timestamp>.<body>")).
+// Получатель считает то же самое и сравнивает с заголовком X-GameHub-Signature ("sha256=<hex>").
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Run опрашивает очередь доставок, пока не отменён ctx
+func (d *Dispatcher) Run(ctx context.Context) {
+	d.logger.Info("webhook dispatcher started")
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		n, err := d.Flush(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.logger.Warn("webhook flush failed", zap.Error(err))
+		}
+		if err == nil && n == d.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			d.logger.Info("webhook dispatcher stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Flush отправляет одну пачку доставок и возвращает её размер
+func (d *Dispatcher) Flush(ctx context.Context) (int, error) {
+	// lease с запасом на таймаут запроса: пока пачка в работе, другие воркеры её не возьмут
+	lease := d.client.Timeout*2 + time.Minute
+	deliveries, err := d.store.ClaimWebhookDeliveries(ctx, d.batchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	sem := make(chan struct{}, d.concurrency)
+	var wg sync.WaitGroup
+	for _, del := range deliveries {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(del entity.WebhookDelivery) {
+			defer func() { <-sem; wg.Done() }()
+			d.deliver(ctx, del)
+		}(del)
+	}
+	wg.Wait()
+	return len(deliveries), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, del entity.WebhookDelivery) {
+	logger := d.logger.With(
+		zap.Int64("delivery_id", del.ID),
+		zap.String("webhook_id", del.SubscriptionID),
+		zap.String("event_id", del.EventID),
+	)
+
+	started := d.now()
+	status, sendErr := d.send(ctx, del, started)
+	res := entity.WebhookAttemptResult{
+		Delivery:   del,
+		StatusCode: status,
+		Duration:   d.now().Sub(started),
+		Success:    sendErr == nil,
+	}
+	if sendErr != nil {
+		attempt := del.Attempts + 1
+		res.Error = sendErr.Error()
+		res.GiveUp = attempt >= d.maxAttempts
+		res.NextAttemptAt = started.Add(d.backoff(attempt))
+		logger.Warn("webhook delivery failed", zap.Error(sendErr), zap.Int("attempt", attempt),
+			zap.Bool("give_up", res.GiveUp))
+	}
+
+	// запись результата не должна зависеть от отмены ctx посреди попытки
+	recCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	disabled, err := d.store.RecordWebhookAttempt(recCtx, res, d.disableAfter)
+	if err != nil {
+		logger.Error("failed to record webhook attempt", zap.Error(err))
+		return
+	}
+	if disabled {
+		logger.Warn("webhook subscription disabled after repeated failures",
+			zap.Int("disable_after", d.disableAfter))
+	}
+}
+
+// send возвращает HTTP-статус (0, если ответа не было) и ошибку для всего, кроме 2xx
+func (d *Dispatcher) send(ctx context.Context, del entity.WebhookDelivery, now time.Time) (int, error) {
+	ts := now.Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, del.URL, bytes.NewReader(del.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GameHub-Webhooks/1")
+	req.Header.Set(HeaderEvent, del.EventType)
+	req.Header.Set(HeaderDelivery, del.EventID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, "sha256="+Sign(del.Secret, ts, del.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return resp.StatusCode, fmt.Errorf("receiver responded %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// backoff для попытки n (с 1): base * 2^(n-1), но не больше max
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	delay := d.backoffBase
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= d.backoffMax {
+			return d.backoffMax
+		}
+	}
+	return delay
+}
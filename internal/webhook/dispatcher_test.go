@@ -0,0 +1,181 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeStore struct {
+	mu       sync.Mutex
+	queue    []entity.WebhookDelivery
+	results  []entity.WebhookAttemptResult
+	failures int
+}
+
+func (f *fakeStore) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]entity.WebhookDelivery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := f.queue
+	f.queue = nil
+	return out, nil
+}
+
+func (f *fakeStore) RecordWebhookAttempt(ctx context.Context, res entity.WebhookAttemptResult, disableAfter int) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results = append(f.results, res)
+	if res.Success {
+		f.failures = 0
+		return false, nil
+	}
+	f.failures++
+	return f.failures == disableAfter, nil
+}
+
+const testSecret = "0123456789abcdef-secret"
+
+// получатели в тестах — httptest на 127.0.0.1
+var localhost = Destinations(NewGuard(true))
+
+func delivery(url string, attempts int) entity.WebhookDelivery {
+	return entity.WebhookDelivery{
+		ID:             1,
+		SubscriptionID: "sub-1",
+		URL:            url,
+		Secret:         testSecret,
+		EventID:        "evt-1",
+		EventType:      entity.EventCommentAdded,
+		Payload:        []byte(`{"event_id":"evt-1","type":"CommentAdded"}`),
+		Attempts:       attempts,
+	}
+}
+
+func TestDispatcher_DeliversSignedRequest(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	store := &fakeStore{queue: []entity.WebhookDelivery{delivery(receiver.URL, 0)}}
+	n, err := New(store, zap.NewNop(), localhost).Flush(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	require.Equal(t, entity.EventCommentAdded, got.Header.Get(HeaderEvent))
+	require.Equal(t, "evt-1", got.Header.Get(HeaderDelivery))
+
+	// получатель проверяет подпись тем же секретом
+	ts, err := strconv.ParseInt(got.Header.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	require.Equal(t, "sha256="+Sign(testSecret, ts, body), got.Header.Get(HeaderSignature))
+	require.JSONEq(t, `{"event_id":"evt-1","type":"CommentAdded"}`, string(body))
+
+	require.Len(t, store.results, 1)
+	require.True(t, store.results[0].Success)
+	require.Equal(t, http.StatusNoContent, store.results[0].StatusCode)
+}
+
+func TestDispatcher_RetriesWithBackoffAndGivesUp(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := New(&fakeStore{}, zap.NewNop(), localhost, Backoff(3, time.Second, 3*time.Second))
+	d.now = func() time.Time { return now }
+
+	cases := []struct {
+		attempts  int
+		wantDelay time.Duration
+		giveUp    bool
+	}{
+		{attempts: 0, wantDelay: time.Second},
+		{attempts: 1, wantDelay: 2 * time.Second},
+		{attempts: 2, wantDelay: 3 * time.Second, giveUp: true},
+	}
+	for _, tc := range cases {
+		store := &fakeStore{queue: []entity.WebhookDelivery{delivery(receiver.URL, tc.attempts)}}
+		d.store = store
+		_, err := d.Flush(context.Background())
+		require.NoError(t, err)
+
+		res := store.results[0]
+		require.False(t, res.Success)
+		require.Equal(t, http.StatusInternalServerError, res.StatusCode)
+		require.Contains(t, res.Error, "boom")
+		require.Equal(t, tc.giveUp, res.GiveUp)
+		require.Equal(t, now.Add(tc.wantDelay), res.NextAttemptAt)
+	}
+}
+
+func TestDispatcher_UnreachableReceiver(t *testing.T) {
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	url := receiver.URL
+	receiver.Close()
+
+	store := &fakeStore{queue: []entity.WebhookDelivery{delivery(url, 0)}}
+	_, err := New(store, zap.NewNop(), localhost, RequestTimeout(time.Second)).Flush(context.Background())
+	require.NoError(t, err)
+
+	require.False(t, store.results[0].Success)
+	require.Zero(t, store.results[0].StatusCode)
+	require.NotEmpty(t, store.results[0].Error)
+}
+
+func TestDispatcher_RefusesPrivateDestinations(t *testing.T) {
+	hit := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer receiver.Close()
+
+	// по умолчанию соединение с 127.0.0.1 отклоняется при dial, даже если подписка уже в базе
+	store := &fakeStore{queue: []entity.WebhookDelivery{delivery(receiver.URL, 0)}}
+	_, err := New(store, zap.NewNop()).Flush(context.Background())
+	require.NoError(t, err)
+
+	require.False(t, hit)
+	require.False(t, store.results[0].Success)
+	require.Contains(t, store.results[0].Error, ErrForbiddenDestination.Error())
+}
+
+func TestDispatcher_DoesNotFollowRedirects(t *testing.T) {
+	hit := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer target.Close()
+	receiver := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer receiver.Close()
+
+	store := &fakeStore{queue: []entity.WebhookDelivery{delivery(receiver.URL, 0)}}
+	_, err := New(store, zap.NewNop(), localhost).Flush(context.Background())
+	require.NoError(t, err)
+
+	require.False(t, hit)
+	require.False(t, store.results[0].Success)
+	require.Equal(t, http.StatusTemporaryRedirect, store.results[0].StatusCode)
+}
+
+func TestSign(t *testing.T) {
+	// эталон для сверки с реализациями получателей:
+	// python3 -c "import hmac,hashlib;print(hmac.new(b'secret',b'1700000000.{}',hashlib.sha256).hexdigest())"
+	require.Equal(t, "b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		Sign("secret", 1700000000, []byte(`{}`)))
+	require.NotEqual(t, Sign("secret", 1700000000, []byte(`{}`)), Sign("secret", 1700000001, []byte(`{}`)))
+}
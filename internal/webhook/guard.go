@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+var ErrForbiddenDestination = errors.New("destination is not a public address")
+
+// адреса, которые не относятся к private/loopback/link-local по классификации netip, но тоже внутренние:
+// CGNAT (там же metadata некоторых облаков, 100.100.100.200), "этот" сеть 0.0.0.0/8 и служебные диапазоны
+var forbiddenPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// DestinationGuard не пускает вебхуки во внутреннюю сеть: postgres, redis и metadata облака (169.254.169.254)
+// доступны из контейнера, а URL задаёт партнёр. Адрес проверяется дважды: при создании подписки
+// (CheckHost — понятная ошибка сразу) и при каждом соединении (Control у net.Dialer) — DNS мог смениться
+// после проверки (DNS rebinding), а редирект — увести на другой хост.
+type DestinationGuard struct {
+	allowPrivate bool
+	resolver     *net.Resolver
+}
+
+// NewGuard; allowPrivate отключает проверку — для локальной разработки и тестов с получателем на 127.0.0.1
+func NewGuard(allowPrivate bool) *DestinationGuard {
+	return &DestinationGuard{allowPrivate: allowPrivate, resolver: net.DefaultResolver}
+}
+
+// CheckHost проверяет хост из URL подписки: IP-литерал или все адреса, в которые резолвится имя
+func (g *DestinationGuard) CheckHost(ctx context.Context, host string) error {
+	if g.allowPrivate {
+		return nil
+	}
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrForbiddenDestination, host)
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return checkAddr(addr)
+	}
+
+	addrs, err := g.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("cannot resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if err := checkAddr(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// control — net.Dialer.Control: вызывается с уже выбранным IP перед каждым соединением
+func (g *DestinationGuard) control(network, address string, _ syscall.RawConn) error {
+	if g.allowPrivate {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenDestination, address)
+	}
+	return checkAddr(addr)
+}
+
+func checkAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast() {
+		return fmt.Errorf("%w: %s", ErrForbiddenDestination, addr)
+	}
+	for _, p := range forbiddenPrefixes {
+		if p.Contains(addr) {
+			return fmt.Errorf("%w: %s", ErrForbiddenDestination, addr)
+		}
+	}
+	return nil
+}
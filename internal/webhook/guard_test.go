@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAddr(t *testing.T) {
+	for addr, allowed := range map[string]bool{
+		"93.184.216.34":          true,
+		"2606:2800:220:1::":      true,
+		"127.0.0.1":              false,
+		"10.1.2.3":               false,
+		"172.18.0.5":             false, // compose-сеть
+		"192.168.1.1":            false,
+		"169.254.169.254":        false, // metadata облака
+		"100.100.100.200":        false, // metadata в CGNAT
+		"0.0.0.0":                false,
+		"255.255.255.255":        false,
+		"224.0.0.1":              false,
+		"::1":                    false,
+		"fe80::1":                false,
+		"fd00:ec2::254":          false,
+		"::ffff:127.0.0.1":       false, // IPv4-mapped
+		"::ffff:169.254.169.254": false,
+	} {
+		err := checkAddr(netip.MustParseAddr(addr))
+		if allowed {
+			require.NoError(t, err, addr)
+		} else {
+			require.ErrorIs(t, err, ErrForbiddenDestination, addr)
+		}
+	}
+}
+
+func TestGuard_CheckHost(t *testing.T) {
+	g := NewGuard(false)
+	ctx := context.Background()
+
+	require.NoError(t, g.CheckHost(ctx, "93.184.216.34"))
+	require.ErrorIs(t, g.CheckHost(ctx, "10.0.0.1"), ErrForbiddenDestination)
+	require.ErrorIs(t, g.CheckHost(ctx, "::1"), ErrForbiddenDestination)
+	require.ErrorIs(t, g.CheckHost(ctx, "localhost"), ErrForbiddenDestination)
+	require.ErrorIs(t, g.CheckHost(ctx, "api.localhost."), ErrForbiddenDestination)
+
+	// локальная разработка
+	require.NoError(t, NewGuard(true).CheckHost(ctx, "127.0.0.1"))
+}
+
+func TestGuard_Control(t *testing.T) {
+	g := NewGuard(false)
+	require.NoError(t, g.control("tcp4", "93.184.216.34:443", nil))
+	require.ErrorIs(t, g.control("tcp4", "127.0.0.1:5432", nil), ErrForbiddenDestination)
+	require.ErrorIs(t, g.control("tcp6", "[::1]:6379", nil), ErrForbiddenDestination)
+	require.NoError(t, NewGuard(true).control("tcp4", "127.0.0.1:5432", nil))
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	_defaultPollInterval   = time.Second
+	_defaultBatchSize      = 50
+	_defaultConcurrency    = 4
+	_defaultRequestTimeout = 5 * time.Second
+	_defaultMaxAttempts    = 8
+	_defaultBackoffBase    = 10 * time.Second
+	_defaultBackoffMax     = time.Hour
+	_defaultDisableAfter   = 15
+)
+
+// Option -.
+type Option func(*Dispatcher)
+
+// PollInterval — как часто проверять очередь, когда она пуста
+func PollInterval(d time.Duration) Option {
+	return func(w *Dispatcher) {
+		if d > 0 {
+			w.pollInterval = d
+		}
+	}
+}
+
+// BatchSize и Concurrency — сколько доставок брать за раз и сколько слать параллельно
+func BatchSize(n, concurrency int) Option {
+	return func(w *Dispatcher) {
+		if n > 0 {
+			w.batchSize = n
+		}
+		if concurrency > 0 {
+			w.concurrency = concurrency
+		}
+	}
+}
+
+// RequestTimeout — таймаут одного HTTP-запроса к получателю
+func RequestTimeout(d time.Duration) Option {
+	return func(w *Dispatcher) {
+		if d > 0 {
+			w.requestTimeout = d
+		}
+	}
+}
+
+// Backoff — экспоненциальная задержка между попытками: base, 2*base, 4*base ... не больше max.
+// После maxAttempts доставка помечается failed.
+func Backoff(maxAttempts int, base, max time.Duration) Option {
+	return func(w *Dispatcher) {
+		if maxAttempts > 0 {
+			w.maxAttempts = maxAttempts
+		}
+		if base > 0 {
+			w.backoffBase = base
+		}
+		if max > 0 {
+			w.backoffMax = max
+		}
+	}
+}
+
+// DisableAfter — число неудачных попыток подряд, после которого подписка отключается
+func DisableAfter(n int) Option {
+	return func(w *Dispatcher) {
+		if n > 0 {
+			w.disableAfter = n
+		}
+	}
+}
+
+// Destinations — общая с usecase проверка адресов получателей (см. DestinationGuard)
+func Destinations(g *DestinationGuard) Option {
+	return func(w *Dispatcher) {
+		if g != nil {
+			w.guard = g
+		}
+	}
+}
+
+// HTTPClient подменяет клиент (тесты, прокси); проверки адресов при соединении у него нет
+func HTTPClient(c *http.Client) Option {
+	return func(w *Dispatcher) {
+		if c != nil {
+			w.client = c
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/config"
+	"github.com/RozmiDan/gameReviewHub/pkg/tracing"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+const (
+	_defaultRetryBackoff    = 200 * time.Millisecond
+	_defaultMaxRetryBackoff = 10 * time.Second
+)
+
+// Handler обрабатывает одно сообщение. Ошибка означает «повторить позже»:
+// оффсет не коммитится, пока обработчик не вернёт nil.
+type Handler func(ctx context.Context, msg kafka.Message) error
+
+type Consumer struct {
+	reader *kafka.Reader
+	logger *zap.Logger
+}
+
+// NewConsumer создаёт участника consumer group из конфига для указанного топика.
+func NewConsumer(cfg *config.KafkaConfig, topic string, logger *zap.Logger) *Consumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     cfg.Brokers,
+		GroupID:     cfg.ConsumerGroup,
+		Topic:       topic,
+		StartOffset: kafka.FirstOffset,
+		// CommitInterval = 0 — синхронный коммит, только после обработки
+		CommitInterval: 0,
+	})
+
+	logger = logger.With(zap.String("component", "kafka-consumer"), zap.String("topic", topic))
+	return &Consumer{reader: reader, logger: logger}
+}
+
+// Run читает сообщения, пока не отменён ctx. Каждое сообщение обрабатывается
+// с повторами до успеха, после чего коммитится его оффсет.
+func (c *Consumer) Run(ctx context.Context, handle Handler) error {
+	c.logger.Info("consumer started")
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				c.logger.Info("consumer stopped")
+				return nil
+			}
+			c.logger.Error("failed to fetch message", zap.Error(err))
+			return err
+		}
+
+		msgCtx, span := tracing.StartConsumer(ctx, msg)
+		handled := c.handleWithRetry(msgCtx, handle, msg)
+		span.End()
+		if !handled {
+			c.logger.Info("consumer stopped")
+			return nil
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			// сообщение будет доставлено повторно — обработчики идемпотентны
+			c.logger.Error("failed to commit offset", zap.Error(err),
+				zap.Int("partition", msg.Partition), zap.Int64("offset", msg.Offset))
+		}
+	}
+}
+
+// handleWithRetry возвращает false, только если ctx отменили до успешной обработки
+func (c *Consumer) handleWithRetry(ctx context.Context, handle Handler, msg kafka.Message) bool {
+	backoff := _defaultRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := handle(ctx, msg)
+		if err == nil {
+			return true
+		}
+
+		c.logger.Warn("message handling failed, will retry",
+			zap.Error(err),
+			zap.Int("attempt", attempt),
+			zap.Int("partition", msg.Partition),
+			zap.Int64("offset", msg.Offset),
+		)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > _defaultMaxRetryBackoff {
+			backoff = _defaultMaxRetryBackoff
+		}
+	}
+}
+
+// Close закрывает консьюмера и выходит из группы.
+func (c *Consumer) Close() error {
+	return c.reader.Close()
+}
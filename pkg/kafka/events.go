@@ -0,0 +1,90 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/config"
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	prom_metrics "github.com/RozmiDan/gameReviewHub/pkg/metrics"
+	"github.com/RozmiDan/gameReviewHub/pkg/tracing"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// EventProducer отправляет доменные события: топик по агрегату, ключ — game_id.
+// Пишет синхронно: outbox relay помечает событие отправленным только после подтверждения брокера.
+type EventProducer struct {
+	writer *kafka.Writer
+	logger *zap.Logger
+	topics map[string]string
+}
+
+// NewEventProducer создаёт продьюсера доменных событий по конфигу.
+func NewEventProducer(cfg *config.KafkaConfig, logger *zap.Logger) *EventProducer {
+	logger = logger.With(zap.String("component", "kafka-event-producer"))
+	return &EventProducer{
+		writer: newWriter(cfg, "", false, nil, logger),
+		logger: logger,
+		topics: map[string]string{
+			entity.AggregateGame:    cfg.TopicGameEvents,
+			entity.AggregateComment: cfg.TopicCommentEvents,
+		},
+	}
+}
+
+// PublishEvent публикует событие и ждёт подтверждения.
+func (p *EventProducer) PublishEvent(ctx context.Context, evt entity.DomainEvent) error {
+	topic, ok := p.topics[evt.Aggregate]
+	if !ok || topic == "" {
+		return fmt.Errorf("%w: no topic for aggregate %q", entity.ErrInvalidEvent, evt.Aggregate)
+	}
+
+	// трейс продолжает запрос, породивший событие, а не тик relay
+	ctx, span := tracing.StartProducer(tracing.ContextWithTraceParent(ctx, evt.TraceParent), topic)
+	defer span.End()
+
+	value, err := json.Marshal(evt)
+	if err != nil {
+		p.logger.Error("failed to marshal event", zap.Error(err), zap.String("event_id", evt.ID))
+		return err
+	}
+
+	headers := []kafka.Header{
+		{Key: HeaderEventID, Value: []byte(evt.ID)},
+		{Key: HeaderEventType, Value: []byte(evt.Type)},
+		{Key: HeaderContentType, Value: []byte("application/json")},
+		{Key: HeaderProducedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339Nano))},
+	}
+	if evt.RequestID != "" {
+		headers = append(headers, kafka.Header{Key: HeaderRequestID, Value: []byte(evt.RequestID)})
+	}
+	headers = tracing.InjectKafka(ctx, headers)
+
+	err = p.writer.WriteMessages(ctx, kafka.Message{
+		Topic:   topic,
+		Key:     []byte(evt.GameID),
+		Value:   value,
+		Headers: headers,
+	})
+	if err != nil {
+		span.RecordError(err)
+		p.logger.Error("failed to publish event", zap.Error(err),
+			zap.String("topic", topic), zap.String("event_id", evt.ID), zap.String("type", evt.Type))
+		if prom_metrics.KafkaPublishErrors != nil {
+			prom_metrics.KafkaPublishErrors.WithLabelValues(topic).Inc()
+		}
+		return err
+	}
+
+	p.logger.Debug("event published", zap.String("topic", topic),
+		zap.String("event_id", evt.ID), zap.String("type", evt.Type))
+	return nil
+}
+
+// Close закрывает продьюсера.
+func (p *EventProducer) Close() error {
+	return p.writer.Close()
+}
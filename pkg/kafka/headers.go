@@ -0,0 +1,44 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/RozmiDan/gameReviewHub/pkg/tracing"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+)
+
+// Заголовки, которые продьюсер проставляет каждому сообщению,
+// чтобы консьюмеры могли склеить логи/трейсы с исходным HTTP-запросом
+const (
+	HeaderRequestID  = "request-id"
+	HeaderEventID    = "event-id"
+	HeaderProducedAt = "produced-at"
+	HeaderEventType  = "event-type"
+)
+
+// metaHeaders собирает request-id (если есть в ctx), новый event-id, время отправки и trace context.
+// event-id остаётся неизменным при повторах writer'а — по нему консьюмеры отсекают дубли.
+func metaHeaders(ctx context.Context, now time.Time) []kafka.Header {
+	headers := make([]kafka.Header, 0, 4)
+	if reqID, _ := ctx.Value(entity.RequestIDKey{}).(string); reqID != "" {
+		headers = append(headers, kafka.Header{Key: HeaderRequestID, Value: []byte(reqID)})
+	}
+	headers = append(headers,
+		kafka.Header{Key: HeaderEventID, Value: []byte(uuid.NewString())},
+		kafka.Header{Key: HeaderProducedAt, Value: []byte(now.UTC().Format(time.RFC3339Nano))},
+	)
+	return tracing.InjectKafka(ctx, headers)
+}
+
+// HeaderValue возвращает значение заголовка или пустую строку
+func HeaderValue(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
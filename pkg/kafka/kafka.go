@@ -2,49 +2,83 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/RozmiDan/gameReviewHub/internal/config"
 	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	prom_metrics "github.com/RozmiDan/gameReviewHub/pkg/metrics"
+	"github.com/RozmiDan/gameReviewHub/pkg/tracing"
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
 )
 
+const _deadLetterSaveTimeout = 5 * time.Second
+
 type Producer struct {
-	writer *kafka.Writer
-	logger *zap.Logger
+	writer      *kafka.Writer
+	logger      *zap.Logger
+	encoding    string
+	async       bool
+	deadLetters DeadLetterSink
 }
 
-// NewProducer создаёт нового продьюсера по конфигу.
-func NewProducer(cfg *config.KafkaConfig, logger *zap.Logger) *Producer {
-	writer := kafka.NewWriter(kafka.WriterConfig{
-		Brokers:      cfg.Brokers,
-		Topic:        cfg.TopicRatings,
-		RequiredAcks: int(kafka.RequireOne),
-		Async:        true,
-		BatchTimeout: 0,
-	})
+// DeadLetterSink сохраняет сообщения, которые не удалось доставить, для последующего replay
+type DeadLetterSink interface {
+	SaveDeadLetter(ctx context.Context, dl entity.DeadLetter) error
+}
+
+// ProducerOption -.
+type ProducerOption func(*Producer)
 
+// WithDeadLetters — недоставленные сообщения уходят в sink вместо потери
+func WithDeadLetters(sink DeadLetterSink) ProducerOption {
+	return func(p *Producer) {
+		p.deadLetters = sink
+	}
+}
+
+// NewProducer создаёт нового продьюсера по конфигу.
+func NewProducer(cfg *config.KafkaConfig, logger *zap.Logger, opts ...ProducerOption) *Producer {
 	logger = logger.With(zap.String("component", "kafka-producer"))
-	return &Producer{writer: writer, logger: logger}
+	async := cfg.Producer.Async
+
+	p := &Producer{logger: logger, encoding: cfg.RatingEncoding, async: async}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.writer = newWriter(cfg, cfg.TopicRatings, async, p.onCompletion, logger)
+	return p
 }
 
 // PublishRating публикует сообщение с оценкой в Kafka.
+// В async-режиме ошибка доставки сюда не вернётся — её залогирует и посчитает onCompletion.
 func (p *Producer) PublishRating(ctx context.Context, msg entity.RatingMessage) error {
-	bytes, err := json.Marshal(msg)
+	ctx, span := tracing.StartProducer(ctx, p.writer.Topic)
+	defer span.End()
+
+	bytes, headers, err := EncodeRating(msg, p.encoding)
 	if err != nil {
 		p.logger.Error("failed to marshal rating message", zap.Error(err))
 		return err
 	}
 
 	kmsg := kafka.Message{
-		Key:   []byte(msg.GameID),
-		Value: bytes,
+		Key:     []byte(msg.GameID),
+		Value:   bytes,
+		Headers: append(headers, metaHeaders(ctx, time.Now())...),
 	}
 
 	if err := p.writer.WriteMessages(ctx, kmsg); err != nil {
+		span.RecordError(err)
 		p.logger.Error("failed to write message to kafka", zap.Error(err),
 			zap.String("topic", p.writer.Topic))
+		p.countError()
+		// сообщение сохранено — оценка не потеряна, её переотправит cmd/replay
+		if p.saveDeadLetter(kmsg, err) {
+			return nil
+		}
 		return err
 	}
 
@@ -52,11 +86,157 @@ func (p *Producer) PublishRating(ctx context.Context, msg entity.RatingMessage)
 		zap.String("game_id", msg.GameID),
 		zap.String("user_id", msg.UserID),
 		zap.Int32("rating", msg.Rating),
+		zap.String("event_id", HeaderValue(kmsg, HeaderEventID)),
 	)
 	return nil
 }
 
-// Close закрывает продьюсера.
+// onCompletion вызывается writer'ом после каждой попытки доставить батч (уже с учётом повторов).
+// В sync-режиме ошибку получает (и сохраняет в dead-letter) PublishRating, поэтому здесь — только async.
+func (p *Producer) onCompletion(messages []kafka.Message, err error) {
+	if err == nil {
+		return
+	}
+	for _, m := range messages {
+		p.logger.Error("kafka delivery failed",
+			zap.Error(err),
+			zap.String("topic", m.Topic),
+			zap.ByteString("key", m.Key),
+			zap.String("event_id", HeaderValue(m, HeaderEventID)),
+			zap.String("request_id", HeaderValue(m, HeaderRequestID)),
+		)
+		if p.async {
+			p.countError()
+			p.saveDeadLetter(m, err)
+		}
+	}
+}
+
+// saveDeadLetter возвращает true, если сообщение удалось сохранить
+func (p *Producer) saveDeadLetter(m kafka.Message, cause error) bool {
+	if p.deadLetters == nil {
+		return false
+	}
+
+	topic := m.Topic
+	if topic == "" {
+		topic = p.writer.Topic
+	}
+	headers := make(map[string]string, len(m.Headers))
+	for _, h := range m.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+
+	// контекст запроса к этому моменту может быть уже отменён
+	ctx, cancel := context.WithTimeout(context.Background(), _deadLetterSaveTimeout)
+	defer cancel()
+
+	err := p.deadLetters.SaveDeadLetter(ctx, entity.DeadLetter{
+		Topic:   topic,
+		Key:     m.Key,
+		Payload: m.Value,
+		Headers: headers,
+		Error:   cause.Error(),
+	})
+	if err != nil {
+		p.logger.Error("failed to save dead letter, message lost", zap.Error(err),
+			zap.String("event_id", headers[HeaderEventID]))
+		return false
+	}
+	p.logger.Warn("message stored in dead-letter store",
+		zap.String("topic", topic), zap.String("event_id", headers[HeaderEventID]))
+	return true
+}
+
+func (p *Producer) countError() {
+	if prom_metrics.KafkaPublishErrors != nil {
+		prom_metrics.KafkaPublishErrors.WithLabelValues(p.writer.Topic).Inc()
+	}
+}
+
+// newWriter настраивает writer по общим параметрам продьюсера.
+// Пустой topic — топик берётся из каждого сообщения.
+func newWriter(cfg *config.KafkaConfig, topic string, async bool,
+	completion func([]kafka.Message, error), logger *zap.Logger) *kafka.Writer {
+
+	pc := cfg.Producer
+	return &kafka.Writer{
+		Addr:  kafka.TCP(cfg.Brokers...),
+		Topic: topic,
+		// ключ — game_id: все сообщения игры попадают в одну партицию и не переупорядочиваются
+		Balancer:        &kafka.Hash{},
+		RequiredAcks:    parseAcks(pc.Acks, logger),
+		Compression:     parseCompression(pc.Compression, logger),
+		BatchSize:       pc.BatchSize,
+		BatchBytes:      pc.BatchBytes,
+		BatchTimeout:    pc.BatchTimeout,
+		MaxAttempts:     pc.MaxAttempts,
+		WriteBackoffMin: pc.RetryBackoffMin,
+		WriteBackoffMax: pc.RetryBackoffMax,
+		WriteTimeout:    cfg.WriteTimeout,
+		Async:           async,
+		Completion:      completion,
+		Transport: &kafka.Transport{
+			DialTimeout: cfg.DialTimeout,
+		},
+	}
+}
+
+func parseAcks(s string, logger *zap.Logger) kafka.RequiredAcks {
+	switch strings.ToLower(s) {
+	case "none", "0":
+		return kafka.RequireNone
+	case "one", "1":
+		return kafka.RequireOne
+	case "all", "-1", "":
+		return kafka.RequireAll
+	}
+	logger.Warn("unknown kafka acks, using all", zap.String("acks", s))
+	return kafka.RequireAll
+}
+
+func parseCompression(s string, logger *zap.Logger) kafka.Compression {
+	switch strings.ToLower(s) {
+	case "none", "":
+		return 0
+	case "gzip":
+		return kafka.Gzip
+	case "snappy":
+		return kafka.Snappy
+	case "lz4":
+		return kafka.Lz4
+	case "zstd":
+		return kafka.Zstd
+	}
+	logger.Warn("unknown kafka compression, disabled", zap.String("compression", s))
+	return 0
+}
+
+// Close дожидается отправки буфера и закрывает продьюсера.
 func (p *Producer) Close() error {
 	return p.writer.Close()
 }
+
+// Ping — проверка для /readyz: отвечает ли хоть один брокер
+func Ping(ctx context.Context, brokers []string) error {
+	var err error
+	for _, b := range brokers {
+		var conn *kafka.Conn
+		conn, err = kafka.DialContext(ctx, "tcp", b)
+		if err != nil {
+			continue
+		}
+		if dl, ok := ctx.Deadline(); ok {
+			conn.SetDeadline(dl)
+		}
+		_, err = conn.Brokers()
+		conn.Close()
+		if err == nil {
+			return nil
+		}
+	}
+	if err == nil {
+		err = errors.New("no kafka brokers configured")
+	}
+	return err
+}
@@ -0,0 +1,95 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/config"
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	prom_metrics "github.com/RozmiDan/gameReviewHub/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMetaHeaders(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	ctx := context.WithValue(context.Background(), entity.RequestIDKey{}, "req-42")
+	msg := kafka.Message{Headers: metaHeaders(ctx, now)}
+	require.Equal(t, "req-42", HeaderValue(msg, HeaderRequestID))
+	require.NotEmpty(t, HeaderValue(msg, HeaderEventID))
+	require.Equal(t, "2025-05-01T12:00:00Z", HeaderValue(msg, HeaderProducedAt))
+
+	// без request_id заголовок не пишем, а event-id у каждого сообщения свой
+	other := kafka.Message{Headers: metaHeaders(context.Background(), now)}
+	require.Empty(t, HeaderValue(other, HeaderRequestID))
+	require.NotEqual(t, HeaderValue(msg, HeaderEventID), HeaderValue(other, HeaderEventID))
+}
+
+func TestProducer_CompletionCountsAsyncFailures(t *testing.T) {
+	prev := prom_metrics.KafkaPublishErrors
+	prom_metrics.KafkaPublishErrors = prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_publish_errors"}, []string{"topic"})
+	t.Cleanup(func() { prom_metrics.KafkaPublishErrors = prev })
+
+	cfg := &config.KafkaConfig{Brokers: []string{"localhost:9092"}, TopicRatings: "ratings"}
+	cfg.Producer.Async = true
+	p := NewProducer(cfg, zap.NewNop())
+
+	batch := []kafka.Message{{Topic: "ratings"}, {Topic: "ratings"}}
+	p.onCompletion(batch, nil)
+	p.onCompletion(batch, errors.New("leader not available"))
+	require.Equal(t, 2.0, testutil.ToFloat64(prom_metrics.KafkaPublishErrors.WithLabelValues("ratings")))
+
+	// в sync-режиме ошибку уже посчитал PublishRating
+	p.async = false
+	p.onCompletion(batch, errors.New("leader not available"))
+	require.Equal(t, 2.0, testutil.ToFloat64(prom_metrics.KafkaPublishErrors.WithLabelValues("ratings")))
+}
+
+func TestParseAcks(t *testing.T) {
+	log := zap.NewNop()
+	require.Equal(t, kafka.RequireAll, parseAcks("all", log))
+	require.Equal(t, kafka.RequireAll, parseAcks("", log))
+	require.Equal(t, kafka.RequireOne, parseAcks("one", log))
+	require.Equal(t, kafka.RequireNone, parseAcks("none", log))
+	require.Equal(t, kafka.RequireAll, parseAcks("bogus", log))
+
+	require.Equal(t, kafka.Zstd, parseCompression("ZSTD", log))
+	require.Equal(t, kafka.Compression(0), parseCompression("none", log))
+}
+
+type memSink struct {
+	letters []entity.DeadLetter
+}
+
+func (s *memSink) SaveDeadLetter(ctx context.Context, dl entity.DeadLetter) error {
+	s.letters = append(s.letters, dl)
+	return nil
+}
+
+func TestProducer_FailedDeliveryGoesToDeadLetters(t *testing.T) {
+	sink := &memSink{}
+	cfg := &config.KafkaConfig{Brokers: []string{"localhost:9092"}, TopicRatings: "ratings"}
+	cfg.Producer.Async = true
+	p := NewProducer(cfg, zap.NewNop(), WithDeadLetters(sink))
+
+	msg := kafka.Message{
+		Key:     []byte("game-1"),
+		Value:   []byte(`{"rating":5}`),
+		Headers: []kafka.Header{{Key: HeaderEventID, Value: []byte("evt-1")}},
+	}
+	p.onCompletion([]kafka.Message{msg}, errors.New("leader not available"))
+
+	require.Len(t, sink.letters, 1)
+	dl := sink.letters[0]
+	require.Equal(t, "ratings", dl.Topic)
+	require.Equal(t, msg.Key, dl.Key)
+	require.Equal(t, msg.Value, dl.Payload)
+	require.Equal(t, "evt-1", dl.Headers[HeaderEventID])
+	require.Equal(t, "leader not available", dl.Error)
+}
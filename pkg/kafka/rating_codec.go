@@ -0,0 +1,103 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	ratingv1 "github.com/RozmiDan/gamehub-protos/gen/go/gamehub"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+)
+
+// Заголовки сообщений с оценками
+const (
+	HeaderSchemaVersion = "schema-version"
+	HeaderContentType   = "content-type"
+)
+
+// Версии схемы RatingMessage. Сообщения без заголовка считаются v1.
+// Новую версию добавляем сюда и в ratingDecoders, старые не удаляем,
+// пока в топике могут оставаться такие сообщения.
+const (
+	RatingSchemaV1 = "1" // JSON entity.RatingMessage
+	RatingSchemaV2 = "2" // protobuf gamehub.rating.SubmitRatingRequest
+)
+
+// Форматы, из которых продьюсер выбирает по конфигу
+const (
+	EncodingJSON     = "json"
+	EncodingProtobuf = "protobuf"
+)
+
+var ratingDecoders = map[string]func([]byte) (entity.RatingMessage, error){
+	RatingSchemaV1: decodeRatingJSON,
+	RatingSchemaV2: decodeRatingProto,
+}
+
+// EncodeRating сериализует оценку в выбранном формате и возвращает заголовки схемы
+func EncodeRating(msg entity.RatingMessage, encoding string) ([]byte, []kafka.Header, error) {
+	switch encoding {
+	case EncodingJSON, "":
+		b, err := json.Marshal(msg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return b, schemaHeaders(RatingSchemaV1, "application/json"), nil
+
+	case EncodingProtobuf:
+		b, err := proto.Marshal(&ratingv1.SubmitRatingRequest{
+			UserId: msg.UserID,
+			GameId: msg.GameID,
+			Rating: msg.Rating,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return b, schemaHeaders(RatingSchemaV2, "application/x-protobuf"), nil
+	}
+	return nil, nil, fmt.Errorf("unknown rating encoding %q", encoding)
+}
+
+// DecodeRating разбирает сообщение любой поддерживаемой версии схемы
+func DecodeRating(msg kafka.Message) (entity.RatingMessage, error) {
+	version := HeaderValue(msg, HeaderSchemaVersion)
+	if version == "" {
+		version = RatingSchemaV1
+	}
+
+	decode, ok := ratingDecoders[version]
+	if !ok {
+		return entity.RatingMessage{}, fmt.Errorf("%w: unsupported rating schema version %q", entity.ErrInvalidEvent, version)
+	}
+	out, err := decode(msg.Value)
+	if err != nil {
+		return entity.RatingMessage{}, fmt.Errorf("%w: schema v%s: %v", entity.ErrInvalidEvent, version, err)
+	}
+	return out, nil
+}
+
+func decodeRatingJSON(b []byte) (entity.RatingMessage, error) {
+	var msg entity.RatingMessage
+	err := json.Unmarshal(b, &msg)
+	return msg, err
+}
+
+func decodeRatingProto(b []byte) (entity.RatingMessage, error) {
+	var pb ratingv1.SubmitRatingRequest
+	if err := proto.Unmarshal(b, &pb); err != nil {
+		return entity.RatingMessage{}, err
+	}
+	return entity.RatingMessage{
+		GameID: pb.GetGameId(),
+		UserID: pb.GetUserId(),
+		Rating: pb.GetRating(),
+	}, nil
+}
+
+func schemaHeaders(version, contentType string) []kafka.Header {
+	return []kafka.Header{
+		{Key: HeaderSchemaVersion, Value: []byte(version)},
+		{Key: HeaderContentType, Value: []byte(contentType)},
+	}
+}
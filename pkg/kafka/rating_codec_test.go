@@ -0,0 +1,89 @@
+package kafka
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+)
+
+// go test ./pkg/kafka -run TestRatingCodec_Golden -update перезаписывает фикстуры ТЕКУЩИХ кодировщиков.
+// Фикстуры прошлых версий не удаляются и не меняются: консьюмеры обязаны их читать.
+var update = flag.Bool("update", false, "rewrite golden fixtures for current encoders")
+
+var goldenRating = entity.RatingMessage{
+	GameID: "3f2c1a9e-1b7d-4c55-9a0e-2d4b8f6e7a10",
+	UserID: "8a6e0b1c-5d2f-4e3a-b7c9-0f1e2d3c4b5a",
+	Rating: 7,
+}
+
+// все когда-либо выпущенные версии схемы
+var goldenFixtures = []struct {
+	name     string
+	file     string
+	headers  []kafka.Header
+	encoding string // пусто — версию больше не пишем, только читаем
+}{
+	{
+		name: "v1 json without headers (legacy producers)",
+		file: "rating_v1.json",
+	},
+	{
+		name:     "v1 json",
+		file:     "rating_v1.json",
+		headers:  schemaHeaders(RatingSchemaV1, "application/json"),
+		encoding: EncodingJSON,
+	},
+	{
+		name:     "v2 protobuf",
+		file:     "rating_v2.pb",
+		headers:  schemaHeaders(RatingSchemaV2, "application/x-protobuf"),
+		encoding: EncodingProtobuf,
+	},
+}
+
+func TestRatingCodec_Golden(t *testing.T) {
+	for _, tc := range goldenFixtures {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join("testdata", tc.file)
+
+			if tc.encoding != "" {
+				payload, headers, err := EncodeRating(goldenRating, tc.encoding)
+				require.NoError(t, err)
+				require.Equal(t, tc.headers, headers)
+
+				if *update {
+					require.NoError(t, os.WriteFile(path, payload, 0o644))
+				}
+				golden, err := os.ReadFile(path)
+				require.NoError(t, err)
+				require.Equal(t, golden, payload, "encoder output changed: add a new schema version instead")
+			}
+
+			payload, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			got, err := DecodeRating(kafka.Message{Value: payload, Headers: tc.headers})
+			require.NoError(t, err)
+			require.Equal(t, goldenRating, got)
+		})
+	}
+}
+
+func TestDecodeRating_Errors(t *testing.T) {
+	_, err := DecodeRating(kafka.Message{
+		Value:   []byte(`{}`),
+		Headers: []kafka.Header{{Key: HeaderSchemaVersion, Value: []byte("99")}},
+	})
+	require.ErrorIs(t, err, entity.ErrInvalidEvent)
+
+	_, err = DecodeRating(kafka.Message{Value: []byte(`{broken`)})
+	require.ErrorIs(t, err, entity.ErrInvalidEvent)
+
+	_, _, err = EncodeRating(goldenRating, "avro")
+	require.Error(t, err)
+}
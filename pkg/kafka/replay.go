@@ -0,0 +1,53 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/config"
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// HeaderReplayedAt проставляется сообщениям, переотправленным из dead-letter store
+const HeaderReplayedAt = "replayed-at"
+
+// Replayer переотправляет сохранённые сообщения в исходный топик, синхронно.
+type Replayer struct {
+	writer *kafka.Writer
+	logger *zap.Logger
+}
+
+func NewReplayer(cfg *config.KafkaConfig, logger *zap.Logger) *Replayer {
+	logger = logger.With(zap.String("component", "kafka-replayer"))
+	return &Replayer{
+		writer: newWriter(cfg, "", false, nil, logger),
+		logger: logger,
+	}
+}
+
+// Republish отправляет сообщение с исходными ключом, телом и заголовками (включая event-id,
+// по которому потребители отсекут дубль, если исходная отправка всё-таки дошла).
+func (r *Replayer) Republish(ctx context.Context, dl entity.DeadLetter) error {
+	headers := make([]kafka.Header, 0, len(dl.Headers)+1)
+	for k, v := range dl.Headers {
+		if k == HeaderReplayedAt {
+			continue
+		}
+		headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	headers = append(headers, kafka.Header{Key: HeaderReplayedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339Nano))})
+
+	return r.writer.WriteMessages(ctx, kafka.Message{
+		Topic:   dl.Topic,
+		Key:     dl.Key,
+		Value:   dl.Payload,
+		Headers: headers,
+	})
+}
+
+// Close закрывает writer.
+func (r *Replayer) Close() error {
+	return r.writer.Close()
+}
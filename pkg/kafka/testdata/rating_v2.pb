@@ -0,0 +1,2 @@
+
+$8a6e0b1c-5d2f-4e3a-b7c9-0f1e2d3c4b5a$3f2c1a9e-1b7d-4c55-9a0e-2d4b8f6e7a10
\ No newline at end of file
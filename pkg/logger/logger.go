@@ -4,50 +4,177 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-// TODO: move to config
-const logPath = "./logs/go.log"
+// по умолчанию — и в stdout контейнера, и в файл, который читает filebeat
+var defaultOutputs = []string{"stdout", "./logs/go.log"}
 
-func NewLogger(env string) *zap.Logger {
-	var cfg zap.Config
+type settings struct {
+	level    string
+	format   string
+	outputs  []string
+	sampling struct {
+		initial, thereafter int
+	}
+	rotation struct {
+		maxSizeMB, maxBackups, maxAgeDays int
+		compress                          bool
+	}
+}
 
-	if err := os.MkdirAll(filepath.Dir(logPath), 0o755); err != nil {
-		fmt.Fprintf(os.Stderr, "cannot create log dir: %v\n", err)
-		os.Exit(1)
+// Option -.
+type Option func(*settings)
+
+// Level — начальный уровень (debug, info, warn, error); пусто — debug для local, info для остальных env
+func Level(level string) Option {
+	return func(s *settings) {
+		s.level = level
 	}
+}
 
-	f, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+// Format — json или console
+func Format(format string) Option {
+	return func(s *settings) {
+		s.format = format
+	}
+}
+
+// Outputs — stdout, stderr или пути к файлам
+func Outputs(paths ...string) Option {
+	return func(s *settings) {
+		if len(paths) > 0 {
+			s.outputs = paths
+		}
+	}
+}
+
+// Sampling — за секунду пишутся первые initial одинаковых сообщений, дальше каждое thereafter-е;
+// initial = 0 выключает сэмплирование
+func Sampling(initial, thereafter int) Option {
+	return func(s *settings) {
+		s.sampling.initial = initial
+		s.sampling.thereafter = thereafter
+	}
+}
+
+// Rotation — ротация файловых выходов по размеру; maxSizeMB = 0 — без ротации
+func Rotation(maxSizeMB, maxBackups, maxAgeDays int, compress bool) Option {
+	return func(s *settings) {
+		s.rotation.maxSizeMB = maxSizeMB
+		s.rotation.maxBackups = maxBackups
+		s.rotation.maxAgeDays = maxAgeDays
+		s.rotation.compress = compress
+	}
+}
+
+// NewLogger собирает логгер по env и опциям. Возвращает и его уровень:
+// zap.AtomicLevel меняется на лету (админский PUT /admin/log-level) без пересборки логгера.
+func NewLogger(env string, opts ...Option) (*zap.Logger, zap.AtomicLevel) {
+	s := settings{format: "json", outputs: defaultOutputs}
+	s.sampling.initial, s.sampling.thereafter = 100, 100
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	level, err := initialLevel(env, s.level)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "cannot open log file %q: %v\n", logPath, err)
+		fmt.Fprintf(os.Stderr, "invalid log level: %v\n", err)
 		os.Exit(1)
 	}
-	f.Close()
 
-	switch env {
-	case "local":
-		cfg = zap.NewDevelopmentConfig()
-		cfg.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
-	default:
-		cfg = zap.NewProductionConfig()
-		cfg.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
+	encoder, err := newEncoder(s.format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid log format: %v\n", err)
+		os.Exit(1)
 	}
-	cfg.Encoding = "json"
-	cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-
-	//cfg.OutputPaths = []string{"stdout", logPath}
-	cfg.OutputPaths = []string{logPath}
 
-	logger, err := cfg.Build()
+	sink, err := openSinks(&s)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to build logger: %v\n", err)
+		fmt.Fprintf(os.Stderr, "cannot open log output: %v\n", err)
 		os.Exit(1)
 	}
 
+	core := zapcore.NewCore(encoder, sink, level)
+	if s.sampling.initial > 0 {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, s.sampling.initial, s.sampling.thereafter)
+	}
+
+	zapOpts := []zap.Option{zap.AddCaller(), zap.ErrorOutput(zapcore.Lock(os.Stderr))}
+	if env == "local" {
+		zapOpts = append(zapOpts, zap.Development(), zap.AddStacktrace(zap.WarnLevel))
+	} else {
+		zapOpts = append(zapOpts, zap.AddStacktrace(zap.ErrorLevel))
+	}
+
+	logger := zap.New(core, zapOpts...)
 	logger = logger.With(zap.String("service", "MainService"))
 
-	return logger
+	return logger, level
+}
+
+func initialLevel(env, level string) (zap.AtomicLevel, error) {
+	if level == "" {
+		if env == "local" {
+			return zap.NewAtomicLevelAt(zap.DebugLevel), nil
+		}
+		return zap.NewAtomicLevelAt(zap.InfoLevel), nil
+	}
+	return zap.ParseAtomicLevel(level)
+}
+
+func newEncoder(format string) (zapcore.Encoder, error) {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	switch format {
+	case "json":
+		return zapcore.NewJSONEncoder(cfg), nil
+	case "console":
+		cfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		return zapcore.NewConsoleEncoder(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (json | console)", format)
+	}
+}
+
+func openSinks(s *settings) (zapcore.WriteSyncer, error) {
+	sinks := make([]zapcore.WriteSyncer, 0, len(s.outputs))
+	for _, path := range s.outputs {
+		switch path {
+		case "stdout":
+			sinks = append(sinks, zapcore.Lock(os.Stdout))
+			continue
+		case "stderr":
+			sinks = append(sinks, zapcore.Lock(os.Stderr))
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("create log dir for %q: %w", path, err)
+		}
+
+		if s.rotation.maxSizeMB > 0 {
+			// lumberjack сам открывает файл и переименовывает его при переполнении
+			sinks = append(sinks, zapcore.AddSync(&lumberjack.Logger{
+				Filename:   path,
+				MaxSize:    s.rotation.maxSizeMB,
+				MaxBackups: s.rotation.maxBackups,
+				MaxAge:     s.rotation.maxAgeDays,
+				Compress:   s.rotation.compress,
+			}))
+			continue
+		}
+
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("open log file %q: %w", path, err)
+		}
+		sinks = append(sinks, zapcore.Lock(f))
+	}
+	return zapcore.NewMultiWriteSyncer(sinks...), nil
 }
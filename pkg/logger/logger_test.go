@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewLogger_FileOutputAndAtomicLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "app.log")
+
+	log, level := NewLogger("prod", Outputs(path), Rotation(1, 1, 1, false))
+	require.Equal(t, zap.InfoLevel, level.Level())
+
+	log.Debug("hidden")
+	log.Info("visible", zap.String("k", "v"))
+
+	// уровень меняется на лету у уже собранного логгера
+	level.SetLevel(zap.DebugLevel)
+	log.Debug("now visible")
+	require.NoError(t, log.Sync())
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	require.Len(t, lines, 2)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	require.Equal(t, "visible", entry["msg"])
+	require.Equal(t, "v", entry["k"])
+	require.Equal(t, "MainService", entry["service"])
+	require.Contains(t, lines[1], "now visible")
+}
+
+func TestNewLogger_LevelFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	_, level := NewLogger("local", Outputs(path))
+	require.Equal(t, zap.DebugLevel, level.Level())
+
+	_, level = NewLogger("local", Outputs(path), Level("warn"))
+	require.Equal(t, zap.WarnLevel, level.Level())
+}
+
+func TestNewLogger_ConsoleFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	log, _ := NewLogger("prod", Outputs(path), Format("console"), Sampling(0, 0))
+	log.Info("plain text")
+	require.NoError(t, log.Sync())
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(raw), "INFO")
+	require.Contains(t, string(raw), "plain text")
+	require.False(t, json.Valid(raw))
+}
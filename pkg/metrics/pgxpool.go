@@ -0,0 +1,92 @@
+package prom_metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolStater — источник статистики пула; *pgxpool.Pool подходит как есть
+type PoolStater interface {
+	Stat() *pgxpool.Stat
+}
+
+// PgxPoolCollector снимает pgxpool.Stat() на каждый scrape: отдельной горутины и
+// устаревших значений нет. Регистрируется после открытия пула:
+// prometheus.MustRegister(prom_metrics.NewPgxPoolCollector(pg.Pool))
+type PgxPoolCollector struct {
+	pool PoolStater
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquires             *prometheus.Desc
+	acquireWait          *prometheus.Desc
+	emptyAcquires        *prometheus.Desc
+	canceledAcquires     *prometheus.Desc
+	newConns             *prometheus.Desc
+	maxLifetimeDestroyed *prometheus.Desc
+	maxIdleDestroyed     *prometheus.Desc
+}
+
+func NewPgxPoolCollector(pool PoolStater) *PgxPoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName("gamehub", "db_pool", name), help, nil, nil)
+	}
+	return &PgxPoolCollector{
+		pool: pool,
+
+		acquiredConns:        desc("acquired_conns", "Соединения, выданные запросам прямо сейчас"),
+		idleConns:            desc("idle_conns", "Простаивающие соединения в пуле"),
+		constructingConns:    desc("constructing_conns", "Соединения, которые открываются прямо сейчас"),
+		totalConns:           desc("total_conns", "Все соединения пула: выданные, простаивающие и открывающиеся"),
+		maxConns:             desc("max_conns", "Размер пула (pg_pool_max)"),
+		acquires:             desc("acquires_total", "Успешные получения соединения из пула"),
+		acquireWait:          desc("acquire_wait_seconds_total", "Суммарное время ожидания соединения из пула"),
+		emptyAcquires:        desc("empty_acquires_total", "Получения соединения, которым пришлось ждать: свободных не было"),
+		canceledAcquires:     desc("canceled_acquires_total", "Ожидания соединения, прерванные отменой контекста"),
+		newConns:             desc("new_conns_total", "Открытые пулом соединения"),
+		maxLifetimeDestroyed: desc("max_lifetime_destroyed_total", "Соединения, закрытые по max_conn_lifetime"),
+		maxIdleDestroyed:     desc("max_idle_destroyed_total", "Соединения, закрытые по max_conn_idle_time"),
+	}
+}
+
+func (c *PgxPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.constructingConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquires
+	ch <- c.acquireWait
+	ch <- c.emptyAcquires
+	ch <- c.canceledAcquires
+	ch <- c.newConns
+	ch <- c.maxLifetimeDestroyed
+	ch <- c.maxIdleDestroyed
+}
+
+func (c *PgxPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.pool.Stat()
+
+	gauge := func(d *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, v)
+	}
+	counter := func(d *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(d, prometheus.CounterValue, v)
+	}
+
+	gauge(c.acquiredConns, float64(s.AcquiredConns()))
+	gauge(c.idleConns, float64(s.IdleConns()))
+	gauge(c.constructingConns, float64(s.ConstructingConns()))
+	gauge(c.totalConns, float64(s.TotalConns()))
+	gauge(c.maxConns, float64(s.MaxConns()))
+	counter(c.acquires, float64(s.AcquireCount()))
+	counter(c.acquireWait, s.AcquireDuration().Seconds())
+	counter(c.emptyAcquires, float64(s.EmptyAcquireCount()))
+	counter(c.canceledAcquires, float64(s.CanceledAcquireCount()))
+	counter(c.newConns, float64(s.NewConnsCount()))
+	counter(c.maxLifetimeDestroyed, float64(s.MaxLifetimeDestroyCount()))
+	counter(c.maxIdleDestroyed, float64(s.MaxIdleDestroyCount()))
+}
@@ -10,6 +10,17 @@ var (
 	HTTPInFlight       *prometheus.GaugeVec
 	DBErrors           *prometheus.CounterVec
 	KafkaPublishErrors *prometheus.CounterVec
+	GRPCBreakerState   *prometheus.GaugeVec
+	GRPCClientRequests *prometheus.CounterVec
+	GRPCClientDuration *prometheus.HistogramVec
+	WSConnections      *prometheus.GaugeVec
+	WSMessagesSent     *prometheus.CounterVec
+	WSClientsDropped   *prometheus.CounterVec
+	CommentsFiltered   *prometheus.CounterVec
+	RateLimitRejected  *prometheus.CounterVec
+	DBDuration         *prometheus.HistogramVec
+	CacheRequests      *prometheus.CounterVec
+	GRPCCallDuration   *prometheus.HistogramVec
 )
 
 func Init() {
@@ -24,8 +35,8 @@ func Init() {
 			Name:      "requests_total",
 			Help:      "Количество HTTP-запросов.",
 		},
-		// []string{"method", "path", "status"},
-		[]string{"method", "status"},
+		// route — шаблон маршрута chi, а не сырой путь: id в пути не плодят серии
+		[]string{"method", "route", "status"},
 	)
 
 	HTTPDuration = prometheus.NewHistogramVec(
@@ -36,8 +47,7 @@ func Init() {
 			Help:      "Распределение длительности HTTP запросов",
 			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
 		},
-		[]string{"method"},
-		//[]string{"method", "path"},
+		[]string{"method", "route"},
 	)
 
 	HTTPInFlight = prometheus.NewGaugeVec(
@@ -47,7 +57,7 @@ func Init() {
 			Name:      "inflight_requests",
 			Help:      "Текущее число обрабатываемых HTTP запросов",
 		},
-		//[]string{"method", "path"},
+		// маршрут до роутинга неизвестен
 		[]string{"method"},
 	)
 	DBErrors = prometheus.NewCounterVec(
@@ -55,10 +65,29 @@ func Init() {
 			Namespace: "gamehub",
 			Subsystem: "repository",
 			Name:      "db_errors_total",
-			Help:      "Число ошибок при запросах в БД",
+			Help:      "Число ошибок при запросах в БД (не считая «не найдено» и конфликтов)",
+		},
+		[]string{"operation"},
+	)
+	DBDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "gamehub",
+			Subsystem: "repository",
+			Name:      "operation_duration_seconds",
+			Help:      "Длительность операций репозитория Postgres",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
 		},
 		[]string{"operation"},
 	)
+	CacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "gamehub",
+			Subsystem: "cache",
+			Name:      "requests_total",
+			Help:      "Обращения к кэшу в Redis (result: hit | miss | error); hit ratio = hit / sum",
+		},
+		[]string{"cache", "result"},
+	)
 	KafkaPublishErrors = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "gamehub",
@@ -68,8 +97,94 @@ func Init() {
 		},
 		[]string{"topic"},
 	)
+	GRPCBreakerState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "gamehub",
+			Subsystem: "grpc_client",
+			Name:      "breaker_state",
+			Help:      "Состояние circuit breaker'а: 0 — closed, 1 — half-open, 2 — open",
+		},
+		[]string{"target"},
+	)
+	GRPCClientRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "gamehub",
+			Subsystem: "grpc_client",
+			Name:      "requests_total",
+			Help:      "Число gRPC-вызовов по бэкендам",
+		},
+		[]string{"backend", "method", "code"},
+	)
+	GRPCClientDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "gamehub",
+			Subsystem: "grpc_client",
+			Name:      "request_duration_seconds",
+			Help:      "Длительность gRPC-вызовов по бэкендам",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		},
+		[]string{"backend", "method"},
+	)
+	GRPCCallDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "gamehub",
+			Subsystem: "grpc_client",
+			Name:      "call_duration_seconds",
+			Help:      "Длительность gRPC-вызова целиком, с ретраями и ожиданием breaker'а",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		},
+		[]string{"method", "code"},
+	)
+
+	WSConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "gamehub",
+			Subsystem: "websocket",
+			Name:      "connections",
+			Help:      "Текущее число WebSocket-подключений",
+		},
+		[]string{"stream"},
+	)
+	WSMessagesSent = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "gamehub",
+			Subsystem: "websocket",
+			Name:      "messages_sent_total",
+			Help:      "Число сообщений, поставленных в очередь WebSocket-клиентам",
+		},
+		[]string{"stream"},
+	)
+	WSClientsDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "gamehub",
+			Subsystem: "websocket",
+			Name:      "clients_dropped_total",
+			Help:      "Число клиентов, отключённых сервером (slow_client — переполнена очередь отправки)",
+		},
+		[]string{"stream", "reason"},
+	)
+	CommentsFiltered = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "gamehub",
+			Subsystem: "comments",
+			Name:      "filtered_total",
+			Help:      "Срабатывания правил фильтра комментариев (action: reject | moderate)",
+		},
+		[]string{"rule", "action"},
+	)
+	RateLimitRejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "gamehub",
+			Subsystem: "http_server",
+			Name:      "rate_limited_total",
+			Help:      "Запросы, отклонённые rate limiter'ом (429), по группам лимитов и маршрутам",
+		},
+		[]string{"group", "route"},
+	)
 
 	prometheus.MustRegister(
-		HTTPRequests, HTTPDuration, HTTPInFlight, DBErrors, KafkaPublishErrors,
+		HTTPRequests, HTTPDuration, HTTPInFlight, DBErrors, DBDuration, CacheRequests, KafkaPublishErrors,
+		GRPCBreakerState, GRPCClientRequests, GRPCClientDuration, GRPCCallDuration,
+		WSConnections, WSMessagesSent, WSClientsDropped, CommentsFiltered, RateLimitRejected,
 	)
 }
@@ -1,6 +1,10 @@
 package postgres
 
-import "time"
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
 
 // Option -.
 type Option func(*Postgres)
@@ -25,3 +29,34 @@ func ConnTimeout(timeout time.Duration) Option {
 		c.connTimeout = timeout
 	}
 }
+
+// MinPoolSize — сколько соединений пул держит открытыми даже без нагрузки
+func MinPoolSize(size int) Option {
+	return func(c *Postgres) {
+		c.minPoolSize = size
+	}
+}
+
+// ConnLifetime — соединение закрывается через maxLifetime после открытия
+// или через maxIdle простоя; 0 оставляет значение pgxpool по умолчанию
+func ConnLifetime(maxLifetime, maxIdle time.Duration) Option {
+	return func(c *Postgres) {
+		c.maxConnLifetime = maxLifetime
+		c.maxConnIdleTime = maxIdle
+	}
+}
+
+// HealthCheckPeriod — как часто пул проверяет простаивающие соединения
+func HealthCheckPeriod(period time.Duration) Option {
+	return func(c *Postgres) {
+		c.healthCheckPeriod = period
+	}
+}
+
+// QueryTracer — трассировка запросов (OpenTelemetry, лог медленных запросов и т.п.).
+// Можно передать несколько раз: трейсеры вызываются по очереди.
+func QueryTracer(tracer pgx.QueryTracer) Option {
+	return func(c *Postgres) {
+		c.tracers = append(c.tracers, tracer)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"log"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/multitracer"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -18,9 +20,14 @@ const (
 
 // Postgres -.
 type Postgres struct {
-	maxPoolSize  int
-	connAttempts int
-	connTimeout  time.Duration
+	maxPoolSize       int
+	minPoolSize       int
+	maxConnLifetime   time.Duration
+	maxConnIdleTime   time.Duration
+	healthCheckPeriod time.Duration
+	connAttempts      int
+	connTimeout       time.Duration
+	tracers           []pgx.QueryTracer
 
 	// Builder squirrel.StatementBuilderType
 	Pool *pgxpool.Pool
@@ -47,6 +54,22 @@ func New(url string, opts ...Option) (*Postgres, error) {
 	}
 
 	poolConfig.MaxConns = int32(pg.maxPoolSize) //nolint:gosec // skip integer overflow conversion int -> int32
+	poolConfig.MinConns = int32(pg.minPoolSize) //nolint:gosec // skip integer overflow conversion int -> int32
+	if pg.maxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = pg.maxConnLifetime
+	}
+	if pg.maxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = pg.maxConnIdleTime
+	}
+	if pg.healthCheckPeriod > 0 {
+		poolConfig.HealthCheckPeriod = pg.healthCheckPeriod
+	}
+
+	if len(pg.tracers) == 1 {
+		poolConfig.ConnConfig.Tracer = pg.tracers[0]
+	} else if len(pg.tracers) > 1 {
+		poolConfig.ConnConfig.Tracer = multitracer.New(pg.tracers...)
+	}
 
 	for pg.connAttempts > 0 {
 		pg.Pool, err = pgxpool.NewWithConfig(context.Background(), poolConfig)
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/RozmiDan/gameReviewHub/pkg/tracing"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// SlowQueryLogger пишет в лог запросы дольше порога: SQL, длительность и число аргументов.
+// Значения аргументов не логируются — в них бывают тексты комментариев и прочие данные пользователей.
+type SlowQueryLogger struct {
+	threshold time.Duration
+	logger    *zap.Logger
+}
+
+// NewSlowQueryLogger — pgx.QueryTracer для postgres.QueryTracer
+func NewSlowQueryLogger(threshold time.Duration, logger *zap.Logger) *SlowQueryLogger {
+	return &SlowQueryLogger{
+		threshold: threshold,
+		logger:    logger.With(zap.String("layer", "SlowQueryLogger")),
+	}
+}
+
+type slowQueryKey struct{}
+
+type slowQueryStart struct {
+	sql   string
+	args  int
+	start time.Time
+}
+
+func (l *SlowQueryLogger) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, slowQueryKey{}, slowQueryStart{
+		sql:   data.SQL,
+		args:  len(data.Args),
+		start: time.Now(),
+	})
+}
+
+func (l *SlowQueryLogger) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	q, ok := ctx.Value(slowQueryKey{}).(slowQueryStart)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(q.start)
+	if elapsed < l.threshold {
+		return
+	}
+
+	fields := []zap.Field{
+		zap.String("sql", strings.Join(strings.Fields(q.sql), " ")),
+		zap.Duration("duration", elapsed),
+		zap.Duration("threshold", l.threshold),
+		zap.Int("args", q.args),
+	}
+	if reqID, _ := ctx.Value(entity.RequestIDKey{}).(string); reqID != "" {
+		fields = append(fields, zap.String("request_id", reqID))
+	}
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		fields = append(fields, zap.String("trace_id", traceID))
+	}
+	if data.Err != nil {
+		fields = append(fields, zap.Error(data.Err))
+	}
+	l.logger.Warn("slow query", fields...)
+}
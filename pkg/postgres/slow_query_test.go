@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RozmiDan/gameReviewHub/internal/entity"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSlowQueryLogger(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	l := NewSlowQueryLogger(20*time.Millisecond, zap.New(core))
+
+	ctx := context.WithValue(context.Background(), entity.RequestIDKey{}, "req-1")
+	sql := `SELECT id
+        FROM games
+        WHERE id = $1`
+
+	// быстрый запрос — тишина
+	qctx := l.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: sql, Args: []any{"g1"}})
+	l.TraceQueryEnd(qctx, nil, pgx.TraceQueryEndData{})
+	require.Zero(t, logs.Len())
+
+	qctx = l.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: sql, Args: []any{"g1"}})
+	time.Sleep(30 * time.Millisecond)
+	l.TraceQueryEnd(qctx, nil, pgx.TraceQueryEndData{Err: errors.New("boom")})
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	require.Equal(t, "slow query", entry.Message)
+	fields := entry.ContextMap()
+	require.Equal(t, "SELECT id FROM games WHERE id = $1", fields["sql"])
+	require.Equal(t, int64(1), fields["args"])
+	require.Equal(t, "req-1", fields["request_id"])
+	require.Equal(t, "boom", fields["error"])
+	require.GreaterOrEqual(t, fields["duration"], 20*time.Millisecond)
+	// значения аргументов в лог не попадают
+	require.NotContains(t, fields, "g1")
+}
+
+func TestSlowQueryLogger_NoStart(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	l := NewSlowQueryLogger(0, zap.New(core))
+
+	// конец без начала (чужой ctx) не должен паниковать и писать мусор
+	l.TraceQueryEnd(context.Background(), nil, pgx.TraceQueryEndData{})
+	require.Zero(t, logs.Len())
+}
@@ -0,0 +1,192 @@
+// Package tracing настраивает OpenTelemetry: экспорт спанов и W3C trace context между сервисами.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// куда отправлять спаны
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"   // OTLP/gRPC в коллектор (Jaeger, Tempo, otel-collector)
+	ExporterStdout = "stdout" // JSON в stdout — посмотреть трейсы без коллектора
+	ExporterFile   = "file"   // JSON в файл, по спану на запись
+)
+
+const instrumentationName = "github.com/RozmiDan/gameReviewHub"
+
+const _defaultShutdownTimeout = 5 * time.Second
+
+type options struct {
+	exporter    string
+	endpoint    string
+	insecure    bool
+	file        string
+	sampleRatio float64
+	version     string
+	env         string
+}
+
+// Option -.
+type Option func(*options)
+
+// Exporter — none | otlp | stdout | file
+func Exporter(kind string) Option {
+	return func(o *options) {
+		o.exporter = kind
+	}
+}
+
+// OTLP — адрес коллектора (host:port) для ExporterOTLP
+func OTLP(endpoint string, insecure bool) Option {
+	return func(o *options) {
+		o.endpoint = endpoint
+		o.insecure = insecure
+	}
+}
+
+// File — путь для ExporterFile
+func File(path string) Option {
+	return func(o *options) {
+		o.file = path
+	}
+}
+
+// SampleRatio — доля новых трейсов, которые пишутся (0..1); решение вызывающего сервиса уважается
+func SampleRatio(ratio float64) Option {
+	return func(o *options) {
+		o.sampleRatio = ratio
+	}
+}
+
+// Service — версия и окружение в ресурсе спанов
+func Service(version, env string) Option {
+	return func(o *options) {
+		o.version = version
+		o.env = env
+	}
+}
+
+// Init ставит глобальные TracerProvider и пропагатор. Пропагатор ставится всегда — даже без экспорта
+// trace context входящего запроса уходит дальше в Kafka и gRPC.
+// Возвращаемый shutdown досылает накопленные спаны; вызывать при остановке сервиса.
+func Init(ctx context.Context, service string, logger *zap.Logger, opts ...Option) (func(context.Context) error, error) {
+	o := &options{exporter: ExporterNone, sampleRatio: 1}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+		err      error
+	)
+	switch o.exporter {
+	case ExporterNone, "":
+		logger.Info("tracing disabled")
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(o.endpoint)}
+		if o.insecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		// соединение ленивое: недоступный коллектор не мешает старту
+		exporter, err = otlptracegrpc.New(ctx, clientOpts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterFile:
+		var f *os.File
+		f, err = os.OpenFile(o.file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("tracing - open %s: %w", o.file, err)
+		}
+		closer = f
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return nil, fmt.Errorf("tracing - unknown exporter %q", o.exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tracing - create %s exporter: %w", o.exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(service),
+		semconv.ServiceVersion(o.version),
+		semconv.DeploymentEnvironment(o.env),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("tracing - resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(o.sampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	logger.Info("tracing enabled",
+		zap.String("exporter", o.exporter),
+		zap.String("endpoint", o.endpoint),
+		zap.String("file", o.file),
+		zap.Float64("sample_ratio", o.sampleRatio),
+	)
+
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, _defaultShutdownTimeout)
+		defer cancel()
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			closer.Close()
+		}
+		return err
+	}, nil
+}
+
+// Tracer — трейсер сервиса; до Init спаны никуда не пишутся
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// TraceID — id трейса из ctx для логов; пусто, если трейса нет
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// TraceParent — W3C traceparent текущего спана: сохраняется рядом с отложенной работой (outbox),
+// чтобы её обработка продолжила тот же трейс
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// ContextWithTraceParent — обратная операция к TraceParent
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{"traceparent": traceParent})
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// withRecorder подменяет глобальный провайдер на запоминающий спаны
+func withRecorder(t *testing.T) *tracetest.SpanRecorder {
+	rec := tracetest.NewSpanRecorder()
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+	return rec
+}
+
+func TestTraceParent_RoundTrip(t *testing.T) {
+	withRecorder(t)
+	ctx, span := Tracer().Start(context.Background(), "request")
+	defer span.End()
+
+	tp := TraceParent(ctx)
+	require.Contains(t, tp, span.SpanContext().TraceID().String())
+	require.Equal(t, span.SpanContext().TraceID().String(), TraceID(ctx))
+
+	restored := trace.SpanContextFromContext(ContextWithTraceParent(context.Background(), tp))
+	require.Equal(t, span.SpanContext().TraceID(), restored.TraceID())
+	require.Equal(t, span.SpanContext().SpanID(), restored.SpanID())
+	require.True(t, restored.IsRemote())
+
+	require.Empty(t, TraceParent(context.Background()))
+	require.Empty(t, TraceID(context.Background()))
+}
+
+func TestKafka_ProducerToConsumer(t *testing.T) {
+	rec := withRecorder(t)
+
+	ctx, req := Tracer().Start(context.Background(), "POST /games/{game_id}/rating")
+	pctx, producer := StartProducer(ctx, "ratings")
+	headers := InjectKafka(pctx, []kafka.Header{{Key: "event-id", Value: []byte("e1")}})
+	producer.End()
+	req.End()
+
+	// повторная инъекция (replay) не плодит дубли заголовка
+	headers = InjectKafka(pctx, headers)
+	n := 0
+	for _, h := range headers {
+		if h.Key == "traceparent" {
+			n++
+		}
+	}
+	require.Equal(t, 1, n)
+
+	_, consumer := StartConsumer(context.Background(), kafka.Message{Topic: "ratings", Headers: headers})
+	consumer.End()
+
+	spans := rec.Ended()
+	require.Len(t, spans, 3)
+	process := spans[2]
+	require.Equal(t, "ratings process", process.Name())
+	require.Equal(t, req.SpanContext().TraceID(), process.SpanContext().TraceID())
+	require.Equal(t, producer.SpanContext().SpanID(), process.Parent().SpanID())
+}
+
+func TestSQLOperation(t *testing.T) {
+	require.Equal(t, "SELECT", sqlOperation("\n        select id FROM games"))
+	require.Equal(t, "QUERY", sqlOperation("  "))
+	require.Equal(t, "SELECT 1 FROM t WHERE a = $1", compactSQL("SELECT 1\n   FROM t\n  WHERE a = $1"))
+}